@@ -65,6 +65,17 @@ func issueApprovalToken(account, action string, ttl time.Duration) (string, stri
 }
 
 func consumeApprovalToken(account, action, token string) error {
+	return useApprovalToken(account, action, token, true)
+}
+
+// checkApprovalToken reports whether token would be accepted for account and
+// action without using it up, so a command can reject a bad token before its
+// lookups and consume it only right before the write.
+func checkApprovalToken(account, action, token string) error {
+	return useApprovalToken(account, action, token, false)
+}
+
+func useApprovalToken(account, action, token string, consume bool) error {
 	account = normalizeEmail(account)
 	action = strings.ToLower(strings.TrimSpace(action))
 	token = strings.TrimSpace(token)
@@ -110,6 +121,9 @@ func consumeApprovalToken(account, action, token string) error {
 		if err != nil || time.Now().UTC().After(exp) {
 			return fmt.Errorf("approval token expired")
 		}
+		if !consume {
+			return nil
+		}
 
 		st.Used = true
 		out, err := json.Marshal(st)
@@ -27,6 +27,31 @@ func TestIssueAndConsumeApprovalToken(t *testing.T) {
 	}
 }
 
+func TestCheckApprovalToken_DoesNotConsume(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	token, _, err := issueApprovalToken("you@example.com", "calendar.delete", time.Minute)
+	if err != nil {
+		t.Fatalf("issueApprovalToken: %v", err)
+	}
+	if err := checkApprovalToken("you@example.com", "calendar.delete.series", token); err == nil {
+		t.Fatal("expected check for another action to fail")
+	}
+	for i := 0; i < 2; i++ {
+		if err := checkApprovalToken("you@example.com", "calendar.delete", token); err != nil {
+			t.Fatalf("checkApprovalToken #%d: %v", i+1, err)
+		}
+	}
+	if err := consumeApprovalToken("you@example.com", "calendar.delete", token); err != nil {
+		t.Fatalf("consumeApprovalToken after check: %v", err)
+	}
+	if err := checkApprovalToken("you@example.com", "calendar.delete", token); err == nil {
+		t.Fatal("expected check of a used token to fail")
+	}
+}
+
 func TestApprovalToken_Expired(t *testing.T) {
 	cfgHome := t.TempDir()
 	t.Setenv("HOME", cfgHome)
@@ -19,8 +19,11 @@ type auditEntry struct {
 	Account   string `json:"account,omitempty"`
 	Target    string `json:"target,omitempty"`
 	DryRun    bool   `json:"dry_run"`
-	PrevHash  string `json:"prev_hash,omitempty"`
-	Hash      string `json:"hash"`
+	// RevisionID is the Drive revision of the target before the write, so
+	// that the change can be rolled back with a restore command.
+	RevisionID string `json:"revision_id,omitempty"`
+	PrevHash   string `json:"prev_hash,omitempty"`
+	Hash       string `json:"hash"`
 }
 
 func appendAuditLog(path string, entry auditEntry) error {
@@ -121,15 +124,19 @@ func lastAuditHash(path string) (string, error) {
 }
 
 func computeAuditHash(entry auditEntry) string {
-	data := strings.Join([]string{
+	fields := []string{
 		entry.Timestamp,
 		entry.Action,
 		entry.Account,
 		entry.Target,
 		fmt.Sprintf("%t", entry.DryRun),
 		entry.PrevHash,
-	}, "|")
-	sum := sha256.Sum256([]byte(data))
+	}
+	// Appended only when set so that hashes of older entries stay valid.
+	if entry.RevisionID != "" {
+		fields = append(fields, entry.RevisionID)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "|")))
 
 	return hex.EncodeToString(sum[:])
 }
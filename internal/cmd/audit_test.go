@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -180,3 +182,44 @@ func TestResolveAuditLogPath_RejectsSymlinkEscape(t *testing.T) {
 		t.Fatal("expected symlink escape path to be rejected")
 	}
 }
+
+// readAuditEntries decodes the default audit log.
+func readAuditEntries(t *testing.T) []auditEntry {
+	t.Helper()
+
+	path, err := resolveAuditLogPath("")
+	if err != nil {
+		t.Fatalf("resolveAuditLogPath: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit file: %v", err)
+	}
+
+	var entries []auditEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var e auditEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("decode audit line %q: %v", line, err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries
+}
+
+func TestComputeAuditHash_RevisionIDOptional(t *testing.T) {
+	entry := auditEntry{Timestamp: "2026-01-01T00:00:00Z", Action: "docs.write", Target: "doc-123"}
+	legacy := computeAuditHash(entry)
+
+	// Entries without a revision hash exactly as before the field existed.
+	sum := sha256.Sum256([]byte("2026-01-01T00:00:00Z|docs.write||doc-123|false|"))
+	if legacy != hex.EncodeToString(sum[:]) {
+		t.Fatalf("hash of entry without revision changed")
+	}
+
+	entry.RevisionID = "17"
+	if computeAuditHash(entry) == legacy {
+		t.Error("revision_id must be covered by the hash")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,7 +31,7 @@ type AuthCmd struct {
 // Step 2 (--auth-url set): exchanges code, stores token, prints {"stored": true, ...}
 type AuthLoginCmd struct {
 	Account      string `name:"account" required:"" short:"a" help:"Google account email."`
-	Services     string `name:"services" default:"gmail,calendar,docs" help:"Comma-separated services to authorize (gmail,calendar,docs,drive)."`
+	Services     string `name:"services" default:"gmail,calendar,docs" help:"Comma-separated services to authorize (gmail,calendar,docs,drive,sheets,slides,tasks,contacts)."`
 	AuthURL      string `name:"auth-url" help:"Redirect URL from browser (step 2)."`
 	ForceConsent bool   `name:"force-consent" help:"Force Google consent screen (re-requests refresh token)."`
 }
@@ -225,11 +226,29 @@ func (c *AuthPreflightCmd) Run(_ context.Context, _ *RootFlags) error {
 		checks = append(checks, checkResult{Name: "keyring", OK: false, Message: err.Error()})
 	} else {
 		checks = append(checks, checkResult{Name: "keyring", OK: true})
-		if _, err := store.GetToken(account); err != nil {
+		tok, err := store.GetToken(account)
+		if err != nil {
 			ready = false
 			checks = append(checks, checkResult{Name: "token", OK: false, Message: err.Error()})
 		} else {
 			checks = append(checks, checkResult{Name: "token", OK: true})
+
+			// Tokens stored before scopes were recorded cannot be checked.
+			if len(tok.Scopes) > 0 {
+				for _, svc := range requiredServices(required) {
+					needed, _ := googleauth.Scopes(svc)
+					if missing := missingScopes(tok.Scopes, needed); len(missing) > 0 {
+						ready = false
+						checks = append(checks, checkResult{
+							Name:    "scope:" + string(svc),
+							OK:      false,
+							Message: fmt.Sprintf("token lacks %s; run auth login with --services including %s", strings.Join(missing, ", "), svc),
+						})
+					} else {
+						checks = append(checks, checkResult{Name: "scope:" + string(svc), OK: true})
+					}
+				}
+			}
 		}
 	}
 
@@ -249,6 +268,58 @@ func (c *AuthPreflightCmd) Run(_ context.Context, _ *RootFlags) error {
 	})
 }
 
+// driveBackedActions are actions of other services that always call Drive,
+// e.g. render copies its template with Drive, so their token also needs the
+// Drive scope.
+var driveBackedActions = []string{
+	"docs.render",
+	"slides.render",
+	"sheets.export",
+	"slides.export",
+	"sheets.revisions.list",
+	"sheets.revisions.export",
+	"sheets.restore",
+	"slides.revisions.list",
+	"slides.revisions.export",
+	"slides.restore",
+}
+
+// requiredServices returns the Google services the given action IDs call,
+// in first-seen order. Actions outside a service (e.g. auth.*) are skipped.
+func requiredServices(actions []string) []googleauth.Service {
+	var services []googleauth.Service
+	add := func(svc googleauth.Service) {
+		if !slices.Contains(services, svc) {
+			services = append(services, svc)
+		}
+	}
+	for _, action := range actions {
+		prefix, _, _ := strings.Cut(action, ".")
+		svc, err := googleauth.ParseService(prefix)
+		if err != nil {
+			continue
+		}
+		add(svc)
+		if slices.Contains(driveBackedActions, action) {
+			add(googleauth.ServiceDrive)
+		}
+	}
+
+	return services
+}
+
+// missingScopes returns the scopes in needed that were not granted.
+func missingScopes(granted, needed []string) []string {
+	var missing []string
+	for _, s := range needed {
+		if !slices.Contains(granted, s) {
+			missing = append(missing, s)
+		}
+	}
+
+	return missing
+}
+
 type AuthApprovalTokenCmd struct {
 	Account string `name:"account" required:"" short:"a" help:"Google account email."`
 	Action  string `name:"action" required:"" help:"Action ID (e.g. docs.write.replace)."`
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/googleauth"
 	"github.com/kubot64/gog-lite/internal/output"
 	"github.com/kubot64/gog-lite/internal/secrets"
 )
@@ -300,3 +302,28 @@ func TestEmergencyRevokeCmd_BlocksAccount(t *testing.T) {
 	}
 	t.Errorf("expected %q to be in blocked_accounts, got %v", account, p.BlockedAccounts)
 }
+
+func TestRequiredServices(t *testing.T) {
+	got := requiredServices([]string{"gmail.send", "auth.login", "contacts.search", "gmail.draft", "slides.thumbnail"})
+	want := []googleauth.Service{googleauth.ServiceGmail, googleauth.ServiceContacts, googleauth.ServiceSlides}
+	if !slices.Equal(got, want) {
+		t.Errorf("requiredServices = %v, want %v", got, want)
+	}
+
+	// Render copies its template with Drive, which slides alone does not grant.
+	got = requiredServices([]string{"slides.render", "slides.info"})
+	want = []googleauth.Service{googleauth.ServiceSlides, googleauth.ServiceDrive}
+	if !slices.Equal(got, want) {
+		t.Errorf("requiredServices = %v, want %v", got, want)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	granted := []string{"openid", "https://www.googleapis.com/auth/gmail.modify"}
+	if got := missingScopes(granted, []string{"https://www.googleapis.com/auth/gmail.modify"}); len(got) != 0 {
+		t.Errorf("missingScopes = %v, want none", got)
+	}
+	if got := missingScopes(granted, []string{"https://www.googleapis.com/auth/contacts.readonly"}); len(got) != 1 {
+		t.Errorf("missingScopes = %v, want contacts.readonly", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/api/calendar/v3"
@@ -20,6 +21,12 @@ type CalendarCmd struct {
 	Create    CalendarCreateCmd    `cmd:"" help:"Create a calendar event."`
 	Update    CalendarUpdateCmd    `cmd:"" help:"Update a calendar event."`
 	Delete    CalendarDeleteCmd    `cmd:"" help:"Delete a calendar event."`
+	Instances CalendarInstancesCmd `cmd:"" help:"List instances of a recurring event."`
+	Respond   CalendarRespondCmd   `cmd:"" help:"Respond to an event invitation (RSVP)."`
+	FreeBusy  CalendarFreeBusyCmd  `cmd:"" name:"freebusy" help:"Query busy intervals for calendars."`
+	FindSlot  CalendarFindSlotCmd  `cmd:"" help:"Find common free slots across calendars."`
+	Export    CalendarExportCmd    `cmd:"" help:"Export events to an iCalendar (.ics) file."`
+	Import    CalendarImportCmd    `cmd:"" help:"Create events from an iCalendar (.ics) file."`
 }
 
 // CalendarCalendarsCmd lists all calendars.
@@ -76,6 +83,8 @@ type CalendarListCmd struct {
 	AllPages   bool   `name:"all-pages" help:"Fetch all pages of results."`
 	Page       string `name:"page" help:"Page token for pagination."`
 	Query      string `name:"query" short:"q" help:"Free text search query."`
+	Expand     bool   `name:"expand-recurring" default:"true" negatable:"" help:"Expand recurring events into instances (--no-expand-recurring lists series instead)."`
+	TimeZone   string `name:"timezone" help:"IANA time zone for local --from/--to and for output times (e.g. Asia/Tokyo)."`
 }
 
 func (c *CalendarListCmd) Run(ctx context.Context, _ *RootFlags) error {
@@ -87,11 +96,18 @@ func (c *CalendarListCmd) Run(ctx context.Context, _ *RootFlags) error {
 		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
 	}
 
-	if err := validateRFC3339Optional("--from", c.From); err != nil {
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
+	}
+
+	from, err := resolveEventTimeOptional("--from", c.From, loc)
+	if err != nil {
 		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
 	}
 
-	if err := validateRFC3339Optional("--to", c.To); err != nil {
+	to, err := resolveEventTimeOptional("--to", c.To, loc)
+	if err != nil {
 		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
 	}
 
@@ -100,28 +116,21 @@ func (c *CalendarListCmd) Run(ctx context.Context, _ *RootFlags) error {
 		return calendarAuthError(err)
 	}
 
-	type eventRef struct {
-		ID          string `json:"id"`
-		Summary     string `json:"summary,omitempty"`
-		Start       string `json:"start,omitempty"`
-		End         string `json:"end,omitempty"`
-		Description string `json:"description,omitempty"`
-		Location    string `json:"location,omitempty"`
-		Status      string `json:"status,omitempty"`
-	}
-
-	events, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []eventRef, error) {
+	events, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []calendarEventRef, error) {
 		req := svc.Events.List(c.CalendarID).
 			MaxResults(c.Max).
-			SingleEvents(true).
-			OrderBy("startTime")
+			SingleEvents(c.Expand)
 
-		if c.From != "" {
-			req = req.TimeMin(c.From)
+		if c.Expand {
+			req = req.OrderBy("startTime")
 		}
 
-		if c.To != "" {
-			req = req.TimeMax(c.To)
+		if from != "" {
+			req = req.TimeMin(from)
+		}
+
+		if to != "" {
+			req = req.TimeMax(to)
 		}
 
 		if c.Query != "" {
@@ -139,40 +148,137 @@ func (c *CalendarListCmd) Run(ctx context.Context, _ *RootFlags) error {
 			return "", nil, fmt.Errorf("calendar list: %w", err)
 		}
 
-		refs := make([]eventRef, 0, len(resp.Items))
+		refs := make([]calendarEventRef, 0, len(resp.Items))
 		for _, e := range resp.Items {
-			ref := eventRef{
-				ID:          e.Id,
-				Summary:     e.Summary,
-				Description: e.Description,
-				Location:    e.Location,
-				Status:      e.Status,
-			}
+			refs = append(refs, toCalendarEventRef(e, loc))
+		}
 
-			if e.Start != nil {
-				ref.Start = eventTimeString(e.Start)
-			}
+		return resp.NextPageToken, refs, nil
+	})
 
-			if e.End != nil {
-				ref.End = eventTimeString(e.End)
-			}
+	if err != nil {
+		return writeGoogleAPIError("list_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"events":        events,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// CalendarInstancesCmd lists instances of a recurring event.
+type CalendarInstancesCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	EventID    string `name:"event-id" required:"" help:"Recurring event (series) ID."`
+	CalendarID string `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	From       string `name:"from" help:"Start time in RFC3339 format."`
+	To         string `name:"to" help:"End time in RFC3339 format."`
+	Max        int64  `name:"max" default:"20" help:"Maximum results."`
+	AllPages   bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page       string `name:"page" help:"Page token for pagination."`
+	TimeZone   string `name:"timezone" help:"IANA time zone for local --from/--to and for output times (e.g. Asia/Tokyo)."`
+}
+
+func (c *CalendarInstancesCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.instances"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("calendar.instances", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
+	}
+
+	from, err := resolveEventTimeOptional("--from", c.From, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	to, err := resolveEventTimeOptional("--to", c.To, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	svc, err := googleapi.NewCalendarReadOnly(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	instances, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []calendarEventRef, error) {
+		req := svc.Events.Instances(c.CalendarID, c.EventID).MaxResults(c.Max)
+
+		if from != "" {
+			req = req.TimeMin(from)
+		}
+
+		if to != "" {
+			req = req.TimeMax(to)
+		}
+
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		} else if c.Page != "" {
+			req = req.PageToken(c.Page)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("calendar instances: %w", err)
+		}
 
-			refs = append(refs, ref)
+		refs := make([]calendarEventRef, 0, len(resp.Items))
+		for _, e := range resp.Items {
+			refs = append(refs, toCalendarEventRef(e, loc))
 		}
 
 		return resp.NextPageToken, refs, nil
 	})
 
 	if err != nil {
-		return writeGoogleAPIError("list_error", err)
+		return writeGoogleAPIError("instances_error", err)
 	}
 
 	return output.WriteJSON(os.Stdout, map[string]any{
-		"events":        events,
+		"event_id":      c.EventID,
+		"instances":     instances,
 		"nextPageToken": nextPageToken,
 	})
 }
 
+// calendarEventRef is the compact event shape returned by list-style commands.
+type calendarEventRef struct {
+	ID               string   `json:"id"`
+	Summary          string   `json:"summary,omitempty"`
+	Start            string   `json:"start,omitempty"`
+	End              string   `json:"end,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	Location         string   `json:"location,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	RecurringEventID string   `json:"recurring_event_id,omitempty"`
+	OriginalStart    string   `json:"original_start,omitempty"`
+	Recurrence       []string `json:"recurrence,omitempty"`
+}
+
+// toCalendarEventRef converts e; timed values are expressed in loc when set.
+func toCalendarEventRef(e *calendar.Event, loc *time.Location) calendarEventRef {
+	return calendarEventRef{
+		ID:               e.Id,
+		Summary:          e.Summary,
+		Start:            eventTimeStringIn(e.Start, loc),
+		End:              eventTimeStringIn(e.End, loc),
+		Description:      e.Description,
+		Location:         e.Location,
+		Status:           e.Status,
+		RecurringEventID: e.RecurringEventId,
+		OriginalStart:    eventTimeStringIn(e.OriginalStartTime, loc),
+		Recurrence:       e.Recurrence,
+	}
+}
+
 // CalendarGetCmd gets a calendar event.
 type CalendarGetCmd struct {
 	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
@@ -200,13 +306,21 @@ func (c *CalendarGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 
 // CalendarCreateCmd creates a calendar event.
 type CalendarCreateCmd struct {
-	Account     string `name:"account" required:"" short:"a" help:"Google account email."`
-	CalendarID  string `name:"calendar-id" default:"primary" help:"Calendar ID."`
-	Title       string `name:"title" required:"" help:"Event title."`
-	Start       string `name:"start" required:"" help:"Start time in RFC3339 format."`
-	End         string `name:"end" required:"" help:"End time in RFC3339 format."`
-	Description string `name:"description" help:"Event description."`
-	Location    string `name:"location" help:"Event location."`
+	Account     string   `name:"account" required:"" short:"a" help:"Google account email."`
+	CalendarID  string   `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	Title       string   `name:"title" required:"" help:"Event title."`
+	Start       string   `name:"start" help:"Start time in RFC3339 format (or local time with --timezone)."`
+	End         string   `name:"end" help:"End time in RFC3339 format (or local time with --timezone)."`
+	AllDay      bool     `name:"all-day" help:"Create an all-day event (use --date/--end-date)."`
+	Date        string   `name:"date" help:"All-day event date (YYYY-MM-DD)."`
+	EndDate     string   `name:"end-date" help:"Last day of a multi-day all-day event, inclusive (YYYY-MM-DD)."`
+	TimeZone    string   `name:"timezone" help:"IANA time zone for the event (e.g. Asia/Tokyo)."`
+	Description string   `name:"description" help:"Event description."`
+	Location    string   `name:"location" help:"Event location."`
+	Recurrence  []string `name:"recurrence" sep:"none" help:"Recurrence rule (e.g. RRULE:FREQ=WEEKLY;BYDAY=MO). Repeatable."`
+	Attendees   string   `name:"attendees" help:"Attendee email addresses (comma-separated)."`
+	SendUpdates string   `name:"send-updates" default:"none" help:"Invitation emails to send: none, externalOnly, or all."`
+	Meet        bool     `name:"meet" help:"Create a Google Meet conference for the event."`
 }
 
 func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -214,14 +328,40 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
-	if err := validateRFC3339("--start", c.Start); err != nil {
-		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
 	}
 
-	if err := validateRFC3339("--end", c.End); err != nil {
+	if !c.AllDay && (c.Start == "" || c.End == "") {
+		return output.WriteError(output.ExitCodeError, "invalid_time",
+			"--start and --end are required (or use --all-day with --date)")
+	}
+
+	times, err := resolveEventTimes(c.Start, c.End, c.Date, c.EndDate, c.AllDay, loc)
+	if err != nil {
 		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
 	}
 
+	recurrence := normalizeRecurrence(c.Recurrence)
+	if err := validateRecurrence(recurrence); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_recurrence", err.Error())
+	}
+
+	attendees, err := parseAttendees(c.Attendees)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_attendees", err.Error())
+	}
+
+	sendUpdates, err := parseSendUpdates(c.SendUpdates)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_send_updates", err.Error())
+	}
+
+	if err := enforceInvitePolicy(c.Account, attendees, sendUpdates); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
 	dryRun := root.DryRun
 
 	if dryRun {
@@ -237,13 +377,20 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 			"dry_run": true,
 			"action":  "calendar.create",
 			"params": map[string]any{
-				"account":     c.Account,
-				"calendar_id": c.CalendarID,
-				"title":       c.Title,
-				"start":       c.Start,
-				"end":         c.End,
-				"description": c.Description,
-				"location":    c.Location,
+				"account":      c.Account,
+				"calendar_id":  c.CalendarID,
+				"title":        c.Title,
+				"start":        eventTimeString(times.Start),
+				"end":          eventTimeString(times.End),
+				"all_day":      c.AllDay,
+				"timezone":     c.TimeZone,
+				"description":  c.Description,
+				"location":     c.Location,
+				"recurrence":   recurrence,
+				"attendees":    attendees,
+				"external":     externalAttendees(c.Account, attendees),
+				"send_updates": sendUpdates,
+				"meet":         c.Meet,
 			},
 		})
 	}
@@ -257,11 +404,34 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 		Summary:     c.Title,
 		Description: c.Description,
 		Location:    c.Location,
-		Start:       &calendar.EventDateTime{DateTime: c.Start},
-		End:         &calendar.EventDateTime{DateTime: c.End},
+		Start:       times.Start,
+		End:         times.End,
+		Recurrence:  recurrence,
+		Attendees:   mergeAttendees(nil, attendees),
 	}
 
-	created, err := svc.Events.Insert(c.CalendarID, event).Do()
+	if c.Meet {
+		event.ConferenceData, err = newMeetConference()
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "create_error", err.Error())
+		}
+	}
+
+	// Recurring timed events need an IANA time zone to expand; without
+	// --timezone, use the calendar's.
+	if len(recurrence) > 0 && !c.AllDay && loc == nil {
+		cal, err := svc.Calendars.Get(c.CalendarID).Do()
+		if err != nil {
+			return writeGoogleAPIError("create_error", err)
+		}
+		event.Start.TimeZone = cal.TimeZone
+		event.End.TimeZone = cal.TimeZone
+	}
+
+	created, err := svc.Events.Insert(c.CalendarID, event).
+		SendUpdates(sendUpdates).
+		ConferenceDataVersion(1).
+		Do()
 	if err != nil {
 		return writeGoogleAPIError("create_error", err)
 	}
@@ -279,6 +449,9 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 		"summary":     created.Summary,
 		"start":       eventTimeString(created.Start),
 		"end":         eventTimeString(created.End),
+		"recurrence":  created.Recurrence,
+		"attendees":   attendeeEmails(created.Attendees),
+		"meet_link":   created.HangoutLink,
 		"html_link":   created.HtmlLink,
 		"calendar_id": c.CalendarID,
 	})
@@ -286,14 +459,23 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 
 // CalendarUpdateCmd updates a calendar event.
 type CalendarUpdateCmd struct {
-	Account     string `name:"account" required:"" short:"a" help:"Google account email."`
-	EventID     string `name:"event-id" required:"" help:"Calendar event ID."`
-	CalendarID  string `name:"calendar-id" default:"primary" help:"Calendar ID."`
-	Title       string `name:"title" help:"New event title."`
-	Start       string `name:"start" help:"New start time in RFC3339 format."`
-	End         string `name:"end" help:"New end time in RFC3339 format."`
-	Description string `name:"description" help:"New event description."`
-	Location    string `name:"location" help:"New event location."`
+	Account     string   `name:"account" required:"" short:"a" help:"Google account email."`
+	EventID     string   `name:"event-id" required:"" help:"Calendar event ID."`
+	CalendarID  string   `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	Title       string   `name:"title" help:"New event title."`
+	Start       string   `name:"start" help:"New start time in RFC3339 format (or local time with --timezone)."`
+	End         string   `name:"end" help:"New end time in RFC3339 format (or local time with --timezone)."`
+	AllDay      bool     `name:"all-day" help:"Make the event all-day (use --date/--end-date)."`
+	Date        string   `name:"date" help:"New all-day event date (YYYY-MM-DD)."`
+	EndDate     string   `name:"end-date" help:"New last day of an all-day event, inclusive (YYYY-MM-DD)."`
+	TimeZone    string   `name:"timezone" help:"IANA time zone for --start/--end (e.g. Asia/Tokyo)."`
+	Description string   `name:"description" help:"New event description."`
+	Location    string   `name:"location" help:"New event location."`
+	Recurrence  []string `name:"recurrence" sep:"none" help:"Replace recurrence rules (requires --scope all or following). Repeatable."`
+	Scope       string   `name:"scope" default:"instance" help:"For recurring events: instance, following, or all."`
+	Attendees   string   `name:"attendees" help:"Replace attendees with these email addresses (comma-separated)."`
+	SendUpdates string   `name:"send-updates" default:"none" help:"Update emails to send: none, externalOnly, or all."`
+	Meet        bool     `name:"meet" help:"Add a Google Meet conference if the event has none."`
 }
 
 func (c *CalendarUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -301,16 +483,42 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
-	if c.Start != "" {
-		if err := validateRFC3339("--start", c.Start); err != nil {
-			return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
-		}
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
 	}
 
-	if c.End != "" {
-		if err := validateRFC3339("--end", c.End); err != nil {
-			return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
-		}
+	times, err := resolveEventTimes(c.Start, c.End, c.Date, c.EndDate, c.AllDay, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	scope, err := parseRecurringScope(c.Scope)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_scope", err.Error())
+	}
+
+	recurrence := normalizeRecurrence(c.Recurrence)
+	if err := validateRecurrence(recurrence); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_recurrence", err.Error())
+	}
+	if len(recurrence) > 0 && scope == recurringScopeInstance {
+		return output.WriteError(output.ExitCodeError, "invalid_scope",
+			"--recurrence requires --scope all or --scope following")
+	}
+
+	attendees, err := parseAttendees(c.Attendees)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_attendees", err.Error())
+	}
+
+	sendUpdates, err := parseSendUpdates(c.SendUpdates)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_send_updates", err.Error())
+	}
+
+	if err := enforceInvitePolicy(c.Account, attendees, sendUpdates); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
 	dryRun := root.DryRun
@@ -328,14 +536,21 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 			"dry_run": true,
 			"action":  "calendar.update",
 			"params": map[string]any{
-				"account":     c.Account,
-				"event_id":    c.EventID,
-				"calendar_id": c.CalendarID,
-				"title":       c.Title,
-				"start":       c.Start,
-				"end":         c.End,
-				"description": c.Description,
-				"location":    c.Location,
+				"account":      c.Account,
+				"event_id":     c.EventID,
+				"calendar_id":  c.CalendarID,
+				"title":        c.Title,
+				"start":        eventTimeString(times.Start),
+				"end":          eventTimeString(times.End),
+				"all_day":      c.AllDay,
+				"timezone":     c.TimeZone,
+				"description":  c.Description,
+				"location":     c.Location,
+				"recurrence":   recurrence,
+				"scope":        scope,
+				"attendees":    attendees,
+				"send_updates": sendUpdates,
+				"meet":         c.Meet,
 			},
 		})
 	}
@@ -351,6 +566,110 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return writeGoogleAPIError("get_error", err)
 	}
 
+	// Existing attendees are notified too, so check the final attendee list.
+	finalAttendees := attendees
+	if c.Attendees == "" {
+		finalAttendees = attendeeEmails(event.Attendees)
+	}
+	if err := enforceInvitePolicy(c.Account, finalAttendees, sendUpdates); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	var updated *calendar.Event
+	seriesID := ""
+
+	switch scope {
+	case recurringScopeInstance:
+		if len(event.Recurrence) > 0 {
+			return output.WriteError(output.ExitCodeError, "invalid_scope",
+				fmt.Sprintf("event %q is a recurring series; pass an instance ID (see calendar instances) or use --scope all", c.EventID))
+		}
+
+		if err = c.applyTo(event, times, nil); err != nil {
+			break
+		}
+		updated, err = updateCalendarEvent(svc, c.CalendarID, event, sendUpdates)
+	case recurringScopeAll:
+		if event.RecurringEventId != "" {
+			event, err = svc.Events.Get(c.CalendarID, event.RecurringEventId).Do()
+			if err != nil {
+				return writeGoogleAPIError("get_error", err)
+			}
+		}
+		seriesID = event.Id
+
+		if err = c.applyTo(event, times, recurrence); err != nil {
+			break
+		}
+		updated, err = updateCalendarEvent(svc, c.CalendarID, event, sendUpdates)
+	case recurringScopeFollowing:
+		if event.RecurringEventId == "" {
+			return output.WriteError(output.ExitCodeError, "invalid_scope",
+				fmt.Sprintf("--scope following requires an instance ID of a recurring event; %q is not an instance", c.EventID))
+		}
+
+		master, tail, first, splitErr := splitSeriesAt(svc, c.CalendarID, event)
+		if splitErr != nil {
+			return writeGoogleAPIError("update_error", splitErr)
+		}
+		seriesID = master.Id
+
+		if first {
+			if err = c.applyTo(master, times, recurrence); err != nil {
+				break
+			}
+			updated, err = updateCalendarEvent(svc, c.CalendarID, master, sendUpdates)
+			break
+		}
+
+		next := newSeriesFrom(master, event, tail)
+		if err = c.applyTo(next, times, recurrence); err != nil {
+			break
+		}
+
+		// Create the new series before truncating the old one so a failure
+		// never loses the remaining occurrences.
+		updated, err = svc.Events.Insert(c.CalendarID, next).
+			SendUpdates(sendUpdates).
+			ConferenceDataVersion(1).
+			Do()
+		if err != nil {
+			break
+		}
+		_, err = updateCalendarEvent(svc, c.CalendarID, master, sendUpdates)
+	}
+	if err != nil {
+		return writeGoogleAPIError("update_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "calendar.update",
+		Account: normalizeEmail(c.Account),
+		Target:  c.EventID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"id":        updated.Id,
+		"summary":   updated.Summary,
+		"start":     eventTimeString(updated.Start),
+		"end":       eventTimeString(updated.End),
+		"scope":     scope,
+		"attendees": attendeeEmails(updated.Attendees),
+		"meet_link": updated.HangoutLink,
+		"updated":   true,
+	}
+	if seriesID != "" {
+		result["series_id"] = seriesID
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// applyTo copies the requested field changes onto event.
+func (c *CalendarUpdateCmd) applyTo(event *calendar.Event, times eventTimes, recurrence []string) error {
 	if c.Title != "" {
 		event.Summary = c.Title
 	}
@@ -363,34 +682,74 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		event.Location = c.Location
 	}
 
-	if c.Start != "" {
-		event.Start = &calendar.EventDateTime{DateTime: c.Start}
+	if times.Start != nil {
+		event.Start = withEventTimeZone(times.Start, event.Start)
 	}
 
-	if c.End != "" {
-		event.End = &calendar.EventDateTime{DateTime: c.End}
+	if times.End != nil {
+		event.End = withEventTimeZone(times.End, event.End)
 	}
 
-	updated, err := svc.Events.Update(c.CalendarID, c.EventID, event).Do()
-	if err != nil {
-		return writeGoogleAPIError("update_error", err)
+	if len(recurrence) > 0 {
+		event.Recurrence = recurrence
 	}
-	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "calendar.update",
-		Account: normalizeEmail(c.Account),
-		Target:  c.EventID,
-		DryRun:  false,
-	}); err != nil {
-		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+
+	if c.Attendees != "" {
+		attendees, err := parseAttendees(c.Attendees)
+		if err != nil {
+			return err
+		}
+		event.Attendees = mergeAttendees(event.Attendees, attendees)
 	}
 
-	return output.WriteJSON(os.Stdout, map[string]any{
-		"id":      updated.Id,
-		"summary": updated.Summary,
-		"start":   eventTimeString(updated.Start),
-		"end":     eventTimeString(updated.End),
-		"updated": true,
-	})
+	if c.Meet && event.ConferenceData == nil {
+		conf, err := newMeetConference()
+		if err != nil {
+			return err
+		}
+		event.ConferenceData = conf
+	}
+
+	return nil
+}
+
+func updateCalendarEvent(svc *calendar.Service, calendarID string, event *calendar.Event, sendUpdates string) (*calendar.Event, error) {
+	return svc.Events.Update(calendarID, event.Id, event).
+		SendUpdates(sendUpdates).
+		ConferenceDataVersion(1).
+		Do()
+}
+
+// newSeriesFrom builds a new series that continues master from instance onwards.
+func newSeriesFrom(master, instance *calendar.Event, recurrence []string) *calendar.Event {
+	next := *master
+	next.Id = ""
+	next.ICalUID = ""
+	next.Etag = ""
+	next.HtmlLink = ""
+	next.Created = ""
+	next.Updated = ""
+	next.Sequence = 0
+	next.RecurringEventId = ""
+	next.OriginalStartTime = nil
+	next.Start = instance.Start
+	next.End = instance.End
+	next.Recurrence = recurrence
+
+	return &next
+}
+
+// withEventTimeZone returns edt, keeping the existing event's time zone for
+// timed values when no --timezone was given.
+func withEventTimeZone(edt, existing *calendar.EventDateTime) *calendar.EventDateTime {
+	if edt.DateTime == "" || edt.TimeZone != "" || existing == nil {
+		return edt
+	}
+
+	out := *edt
+	out.TimeZone = existing.TimeZone
+
+	return &out
 }
 
 // CalendarDeleteCmd deletes a calendar event.
@@ -398,6 +757,7 @@ type CalendarDeleteCmd struct {
 	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
 	EventID       string `name:"event-id" required:"" help:"Calendar event ID."`
 	CalendarID    string `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	Scope         string `name:"scope" default:"instance" help:"For recurring events: instance, following, or all."`
 	ConfirmDelete bool   `name:"confirm-delete" help:"Required confirmation flag for delete operations."`
 	ApprovalToken string `name:"approval-token" help:"One-time approval token for dangerous actions."`
 }
@@ -407,28 +767,48 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	scope, err := parseRecurringScope(c.Scope)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_scope", err.Error())
+	}
+
+	// Series deletes remove many occurrences at once, so they are approved
+	// separately from single-event deletes.
+	approvalAction := "calendar.delete"
+	if scope != recurringScopeInstance {
+		approvalAction = "calendar.delete.series"
+	}
+
+	// The audit log records the same action as the approval and the scope,
+	// so a single-instance delete can be told apart from a series delete.
+	auditTarget := fmt.Sprintf("%s (scope=%s)", c.EventID, scope)
+
 	dryRun := root.DryRun
 	if !dryRun && !c.ConfirmDelete {
 		return output.WriteError(output.ExitCodeError, "delete_requires_confirmation",
 			"calendar delete requires --confirm-delete")
 	}
+	// The token is only checked here and consumed once the event and scope
+	// have been checked, so a wrong event ID or scope does not use it up.
+	approvalRequired := false
 	if !dryRun {
-		required, err := actionRequiresApproval("calendar.delete")
+		required, err := actionRequiresApproval(approvalAction)
 		if err != nil {
 			return output.WriteError(output.ExitCodeError, "policy_error", err.Error())
 		}
 		if required {
-			if err := consumeApprovalToken(c.Account, "calendar.delete", c.ApprovalToken); err != nil {
+			if err := checkApprovalToken(c.Account, approvalAction, c.ApprovalToken); err != nil {
 				return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
 			}
 		}
+		approvalRequired = required
 	}
 
 	if dryRun {
 		if err := appendAuditLog(root.AuditLog, auditEntry{
-			Action:  "calendar.delete",
+			Action:  approvalAction,
 			Account: normalizeEmail(c.Account),
-			Target:  c.EventID,
+			Target:  auditTarget,
 			DryRun:  true,
 		}); err != nil {
 			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
@@ -437,9 +817,11 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
 			"dry_run": true,
 			"action":  "calendar.delete",
 			"params": map[string]any{
-				"account":     c.Account,
-				"event_id":    c.EventID,
-				"calendar_id": c.CalendarID,
+				"account":         c.Account,
+				"event_id":        c.EventID,
+				"calendar_id":     c.CalendarID,
+				"scope":           scope,
+				"approval_action": approvalAction,
 			},
 		})
 	}
@@ -449,21 +831,174 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
 		return calendarAuthError(err)
 	}
 
-	if err := svc.Events.Delete(c.CalendarID, c.EventID).Do(); err != nil {
+	event, err := svc.Events.Get(c.CalendarID, c.EventID).Do()
+	if err != nil {
+		return writeGoogleAPIError("get_error", err)
+	}
+
+	// Everything the delete depends on is looked up before the write.
+	seriesID := ""
+	truncated := false
+	var master *calendar.Event
+
+	switch scope {
+	case recurringScopeInstance:
+		if len(event.Recurrence) > 0 {
+			return output.WriteError(output.ExitCodeError, "invalid_scope",
+				fmt.Sprintf("event %q is a recurring series; pass an instance ID (see calendar instances) or use --scope all", c.EventID))
+		}
+	case recurringScopeAll:
+		seriesID = event.Id
+		if event.RecurringEventId != "" {
+			seriesID = event.RecurringEventId
+		}
+	case recurringScopeFollowing:
+		if event.RecurringEventId == "" {
+			return output.WriteError(output.ExitCodeError, "invalid_scope",
+				fmt.Sprintf("--scope following requires an instance ID of a recurring event; %q is not an instance", c.EventID))
+		}
+
+		var first bool
+		var splitErr error
+		master, _, first, splitErr = splitSeriesAt(svc, c.CalendarID, event)
+		if splitErr != nil {
+			return writeGoogleAPIError("delete_error", splitErr)
+		}
+		seriesID = master.Id
+		truncated = !first
+	}
+
+	if approvalRequired {
+		if err := consumeApprovalToken(c.Account, approvalAction, c.ApprovalToken); err != nil {
+			return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
+		}
+	}
+
+	switch {
+	case scope == recurringScopeInstance:
+		err = svc.Events.Delete(c.CalendarID, event.Id).Do()
+	case truncated:
+		_, err = svc.Events.Update(c.CalendarID, master.Id, master).Do()
+	default:
+		err = svc.Events.Delete(c.CalendarID, seriesID).Do()
+	}
+	if err != nil {
 		return writeGoogleAPIError("delete_error", err)
 	}
+
 	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "calendar.delete",
+		Action:  approvalAction,
 		Account: normalizeEmail(c.Account),
-		Target:  c.EventID,
+		Target:  auditTarget,
 		DryRun:  false,
 	}); err != nil {
 		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
 	}
 
-	return output.WriteJSON(os.Stdout, map[string]any{
+	result := map[string]any{
 		"deleted":  true,
 		"event_id": c.EventID,
+		"scope":    scope,
+	}
+	if seriesID != "" {
+		result["series_id"] = seriesID
+		result["truncated"] = truncated
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// CalendarRespondCmd responds to an event invitation.
+type CalendarRespondCmd struct {
+	Account     string `name:"account" required:"" short:"a" help:"Google account email."`
+	EventID     string `name:"event-id" required:"" help:"Calendar event ID."`
+	CalendarID  string `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	Status      string `name:"status" required:"" help:"Response: accepted, declined, or tentative."`
+	Comment     string `name:"comment" help:"Optional response comment."`
+	SendUpdates string `name:"send-updates" default:"none" help:"Notify the organizer: none or all."`
+}
+
+func (c *CalendarRespondCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.respond"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	status := strings.ToLower(strings.TrimSpace(c.Status))
+	switch status {
+	case "accepted", "declined", "tentative":
+	default:
+		return output.WriteError(output.ExitCodeError, "invalid_status",
+			fmt.Sprintf("--status %q is invalid; use accepted, declined, or tentative", c.Status))
+	}
+
+	sendUpdates, err := parseSendUpdates(c.SendUpdates)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_send_updates", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "calendar.respond",
+			Account: normalizeEmail(c.Account),
+			Target:  c.EventID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "calendar.respond",
+			"params": map[string]any{
+				"account":      c.Account,
+				"event_id":     c.EventID,
+				"calendar_id":  c.CalendarID,
+				"status":       status,
+				"comment":      c.Comment,
+				"send_updates": sendUpdates,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewCalendarWrite(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	event, err := svc.Events.Get(c.CalendarID, c.EventID).Do()
+	if err != nil {
+		return writeGoogleAPIError("get_error", err)
+	}
+
+	self := findSelfAttendee(event.Attendees, c.Account)
+	if self == nil {
+		return output.WriteError(output.ExitCodeError, "not_attendee",
+			fmt.Sprintf("%s is not an attendee of event %q", normalizeEmail(c.Account), c.EventID))
+	}
+	self.ResponseStatus = status
+	if c.Comment != "" {
+		self.Comment = c.Comment
+	}
+
+	patched, err := svc.Events.Patch(c.CalendarID, c.EventID, &calendar.Event{Attendees: event.Attendees}).
+		SendUpdates(sendUpdates).
+		Do()
+	if err != nil {
+		return writeGoogleAPIError("respond_error", err)
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "calendar.respond",
+		Account: normalizeEmail(c.Account),
+		Target:  c.EventID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"id":        patched.Id,
+		"summary":   patched.Summary,
+		"status":    status,
+		"responded": true,
 	})
 }
 
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+// parseAttendees splits a comma-separated attendee list into normalized emails.
+func parseAttendees(csv string) ([]string, error) {
+	if strings.TrimSpace(csv) == "" {
+		return nil, nil
+	}
+
+	addrs, err := mail.ParseAddressList(csv)
+	if err != nil {
+		return nil, fmt.Errorf("--attendees contains invalid email address list: %v", err)
+	}
+
+	seen := make(map[string]struct{}, len(addrs))
+	out := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		email := normalizeEmail(a.Address)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		out = append(out, email)
+	}
+
+	return out, nil
+}
+
+// parseSendUpdates maps --send-updates to the Calendar API sendUpdates value.
+func parseSendUpdates(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "none":
+		return "none", nil
+	case "externalonly":
+		return "externalOnly", nil
+	case "all":
+		return "all", nil
+	}
+
+	return "", fmt.Errorf("--send-updates %q is invalid; use none, externalOnly, or all", v)
+}
+
+// emailDomain returns the lower-cased domain part of an email address.
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(normalizeEmail(email), "@")
+	if !ok {
+		return ""
+	}
+
+	return domain
+}
+
+// publicMailDomains are consumer mail domains shared by unrelated users, so
+// sharing one with the account says nothing about the recipient.
+var publicMailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"outlook.com":    true,
+	"hotmail.com":    true,
+	"live.com":       true,
+	"msn.com":        true,
+	"yahoo.com":      true,
+	"yahoo.co.jp":    true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"aol.com":        true,
+	"proton.me":      true,
+	"protonmail.com": true,
+}
+
+// externalAttendees returns attendees whose domain differs from the account's.
+// On a public mail domain every attendee other than the account is external.
+func externalAttendees(account string, attendees []string) []string {
+	own := emailDomain(account)
+	self := normalizeEmail(account)
+
+	var out []string
+	for _, a := range attendees {
+		if emailDomain(a) != own || (publicMailDomains[own] && normalizeEmail(a) != self) {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
+
+// inviteAction returns the policy action for notifying attendees, or "" when
+// no invitation email will be sent.
+func inviteAction(account string, attendees []string, sendUpdates string) string {
+	if len(attendees) == 0 || sendUpdates == "none" {
+		return ""
+	}
+
+	external := externalAttendees(account, attendees)
+	if len(external) > 0 {
+		return "calendar.invite.external"
+	}
+	if sendUpdates == "externalOnly" {
+		return ""
+	}
+
+	return "calendar.invite"
+}
+
+// enforceInvitePolicy checks that sending invitations to attendees is allowed.
+// Invitations are effectively outbound email, so they are gated by their own
+// actions and, for external recipients, by allowed_invite_domains.
+func enforceInvitePolicy(account string, attendees []string, sendUpdates string) error {
+	action := inviteAction(account, attendees, sendUpdates)
+	if action == "" {
+		return nil
+	}
+
+	if err := enforceActionPolicy(account, action); err != nil {
+		return err
+	}
+
+	if action != "calendar.invite.external" {
+		return nil
+	}
+
+	p, err := config.ReadPolicy()
+	if err != nil {
+		return fmt.Errorf("read policy: %w", err)
+	}
+	if len(p.AllowedInviteDomains) == 0 {
+		return nil
+	}
+
+	for _, a := range externalAttendees(account, attendees) {
+		allowed := false
+		for _, d := range p.AllowedInviteDomains {
+			if emailDomain(a) == strings.ToLower(strings.TrimSpace(d)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("attendee %q is outside allowed_invite_domains", a)
+		}
+	}
+
+	return nil
+}
+
+// mergeAttendees builds the attendee list for emails, keeping existing entries
+// (and their response status) for attendees that are already invited.
+func mergeAttendees(existing []*calendar.EventAttendee, emails []string) []*calendar.EventAttendee {
+	byEmail := make(map[string]*calendar.EventAttendee, len(existing))
+	for _, a := range existing {
+		byEmail[normalizeEmail(a.Email)] = a
+	}
+
+	out := make([]*calendar.EventAttendee, 0, len(emails))
+	for _, email := range emails {
+		if a, ok := byEmail[email]; ok {
+			out = append(out, a)
+			continue
+		}
+		out = append(out, &calendar.EventAttendee{Email: email})
+	}
+
+	return out
+}
+
+// attendeeEmails returns the emails of all attendees on an event.
+func attendeeEmails(attendees []*calendar.EventAttendee) []string {
+	out := make([]string, 0, len(attendees))
+	for _, a := range attendees {
+		if a.Email != "" {
+			out = append(out, normalizeEmail(a.Email))
+		}
+	}
+
+	return out
+}
+
+// findSelfAttendee returns the attendee entry for account on the event.
+func findSelfAttendee(attendees []*calendar.EventAttendee, account string) *calendar.EventAttendee {
+	account = normalizeEmail(account)
+	for _, a := range attendees {
+		if a.Self || normalizeEmail(a.Email) == account {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// newMeetConference returns a request that asks Calendar to create a Google Meet link.
+func newMeetConference() (*calendar.ConferenceData, error) {
+	requestID, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar.ConferenceData{
+		CreateRequest: &calendar.CreateConferenceRequest{
+			RequestId:             requestID,
+			ConferenceSolutionKey: &calendar.ConferenceSolutionKey{Type: "hangoutsMeet"},
+		},
+	}, nil
+}
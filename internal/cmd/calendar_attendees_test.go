@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+func TestParseAttendees(t *testing.T) {
+	got, err := parseAttendees("Alice <Alice@Example.com>, bob@example.com, alice@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"alice@example.com", "bob@example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := parseAttendees("alice@example.com, bad"); err == nil {
+		t.Error("expected error for invalid attendee")
+	}
+}
+
+func TestParseSendUpdates(t *testing.T) {
+	for in, want := range map[string]string{
+		"":             "none",
+		"none":         "none",
+		"externalOnly": "externalOnly",
+		"EXTERNALONLY": "externalOnly",
+		"all":          "all",
+	} {
+		got, err := parseSendUpdates(in)
+		if err != nil {
+			t.Errorf("parseSendUpdates(%q): unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseSendUpdates(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := parseSendUpdates("everyone"); err == nil {
+		t.Error("expected error for invalid value")
+	}
+}
+
+func TestInviteAction(t *testing.T) {
+	internal := []string{"bob@corp.example"}
+	mixed := []string{"bob@corp.example", "eve@partner.example"}
+
+	for _, tc := range []struct {
+		attendees   []string
+		sendUpdates string
+		want        string
+	}{
+		{attendees: mixed, sendUpdates: "none", want: ""},
+		{attendees: nil, sendUpdates: "all", want: ""},
+		{attendees: internal, sendUpdates: "all", want: "calendar.invite"},
+		{attendees: internal, sendUpdates: "externalOnly", want: ""},
+		{attendees: mixed, sendUpdates: "externalOnly", want: "calendar.invite.external"},
+		{attendees: mixed, sendUpdates: "all", want: "calendar.invite.external"},
+	} {
+		if got := inviteAction("me@corp.example", tc.attendees, tc.sendUpdates); got != tc.want {
+			t.Errorf("inviteAction(%v, %q) = %q, want %q", tc.attendees, tc.sendUpdates, got, tc.want)
+		}
+	}
+}
+
+func TestEnforceInvitePolicy_DomainRestriction(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{
+		AllowedInviteDomains: []string{"partner.example"},
+	}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	if err := enforceInvitePolicy("me@corp.example", []string{"eve@partner.example"}, "all"); err != nil {
+		t.Fatalf("unexpected error for allowed domain: %v", err)
+	}
+	if err := enforceInvitePolicy("me@corp.example", []string{"mallory@evil.example"}, "all"); err == nil {
+		t.Fatal("expected error for domain outside allowed_invite_domains")
+	}
+	if err := enforceInvitePolicy("me@corp.example", []string{"mallory@evil.example"}, "none"); err != nil {
+		t.Fatalf("no invitations are sent with none: %v", err)
+	}
+}
+
+func TestInviteAction_PublicMailDomain(t *testing.T) {
+	if got := inviteAction("me@gmail.com", []string{"friend@gmail.com"}, "all"); got != "calendar.invite.external" {
+		t.Errorf("gmail.com attendee of a gmail.com account: got %q, want calendar.invite.external", got)
+	}
+	if got := externalAttendees("me@gmail.com", []string{"Me@gmail.com", "friend@gmail.com"}); !reflect.DeepEqual(got, []string{"friend@gmail.com"}) {
+		t.Errorf("externalAttendees = %v, want only friend@gmail.com", got)
+	}
+}
+
+func TestEnforceInvitePolicy_DomainCaseInsensitive(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// Written by hand rather than through WritePolicy, which normalizes.
+	path, err := config.PolicyPath()
+	if err != nil {
+		t.Fatalf("PolicyPath: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"allowed_invite_domains":[" Partner.Example "]}`), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	if err := enforceInvitePolicy("me@corp.example", []string{"eve@partner.example"}, "all"); err != nil {
+		t.Errorf("mixed-case allowed domain rejected: %v", err)
+	}
+}
+
+func TestMergeAttendees_KeepsExistingResponses(t *testing.T) {
+	existing := []*calendar.EventAttendee{
+		{Email: "Bob@corp.example", ResponseStatus: "accepted"},
+		{Email: "old@corp.example", ResponseStatus: "declined"},
+	}
+	got := mergeAttendees(existing, []string{"bob@corp.example", "new@corp.example"})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attendees, got %d", len(got))
+	}
+	if got[0].ResponseStatus != "accepted" {
+		t.Errorf("existing response status lost: %+v", got[0])
+	}
+	if got[1].Email != "new@corp.example" || got[1].ResponseStatus != "" {
+		t.Errorf("unexpected new attendee: %+v", got[1])
+	}
+}
+
+func TestFindSelfAttendee(t *testing.T) {
+	attendees := []*calendar.EventAttendee{
+		{Email: "bob@corp.example"},
+		{Email: "Me@corp.example"},
+	}
+	if got := findSelfAttendee(attendees, "me@corp.example"); got != attendees[1] {
+		t.Errorf("got %+v, want %+v", got, attendees[1])
+	}
+	if got := findSelfAttendee(attendees, "other@corp.example"); got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+}
+
+func TestCalendarCreateCmd_ExternalInvitePolicyDenied(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// Creating events is allowed, but inviting external attendees is not.
+	if err := config.WritePolicy(config.PolicyFile{
+		AllowedActions: []string{"calendar.create", "calendar.invite"},
+	}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	cmd := &CalendarCreateCmd{
+		Account:     "me@corp.example",
+		CalendarID:  "primary",
+		Title:       "Sync",
+		Start:       "2026-03-01T10:00:00Z",
+		End:         "2026-03-01T11:00:00Z",
+		Attendees:   "eve@partner.example",
+		SendUpdates: "all",
+	}
+	assertPolicyDenied(t, func() error {
+		return cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+}
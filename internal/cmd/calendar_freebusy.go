@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// CalendarFreeBusyCmd queries busy intervals for one or more calendars.
+type CalendarFreeBusyCmd struct {
+	Account   string `name:"account" required:"" short:"a" help:"Google account email."`
+	Calendars string `name:"calendars" required:"" help:"Calendar IDs or attendee emails (comma-separated)."`
+	From      string `name:"from" required:"" help:"Start time in RFC3339 format."`
+	To        string `name:"to" required:"" help:"End time in RFC3339 format."`
+}
+
+func (c *CalendarFreeBusyCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.freebusy"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("calendar.freebusy", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	if err := validateRFC3339("--from", c.From); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	if err := validateRFC3339("--to", c.To); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	ids := splitCalendarIDs(c.Calendars)
+	if len(ids) == 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_calendars", "--calendars must not be empty")
+	}
+
+	svc, err := googleapi.NewCalendarReadOnly(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	resp, err := queryFreeBusy(svc, ids, c.From, c.To)
+	if err != nil {
+		return writeGoogleAPIError("freebusy_error", err)
+	}
+
+	type calendarBusy struct {
+		ID     string          `json:"id"`
+		Busy   []freeBusyRange `json:"busy"`
+		Errors []string        `json:"errors,omitempty"`
+	}
+
+	cals := make([]calendarBusy, 0, len(ids))
+	for _, id := range ids {
+		cb := calendarBusy{ID: id, Busy: []freeBusyRange{}}
+		if fb, ok := resp.Calendars[id]; ok {
+			for _, p := range fb.Busy {
+				cb.Busy = append(cb.Busy, freeBusyRange{Start: p.Start, End: p.End})
+			}
+			for _, e := range fb.Errors {
+				cb.Errors = append(cb.Errors, e.Reason)
+			}
+		}
+		cals = append(cals, cb)
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"from":      c.From,
+		"to":        c.To,
+		"calendars": cals,
+	})
+}
+
+// CalendarFindSlotCmd finds common free slots across calendars.
+type CalendarFindSlotCmd struct {
+	Account         string `name:"account" required:"" short:"a" help:"Google account email."`
+	Attendees       string `name:"attendees" help:"Other calendar IDs or attendee emails (comma-separated)."`
+	From            string `name:"from" required:"" help:"Search window start in RFC3339 format."`
+	To              string `name:"to" required:"" help:"Search window end in RFC3339 format."`
+	Duration        string `name:"duration" default:"30m" help:"Required slot length (e.g. 30m, 1h)."`
+	WorkingHours    string `name:"working-hours" default:"09:00-18:00" help:"Daily working hours as HH:MM-HH:MM in --tz."`
+	TZ              string `name:"tz" default:"UTC" help:"IANA time zone for working hours and output (e.g. Asia/Tokyo)."`
+	IncludeWeekends bool   `name:"include-weekends" help:"Also search Saturdays and Sundays."`
+	Max             int    `name:"max" default:"10" help:"Maximum number of slots to return."`
+}
+
+func (c *CalendarFindSlotCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.find_slot"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("calendar.find_slot", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	if err := validateRFC3339("--from", c.From); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	if err := validateRFC3339("--to", c.To); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	from, _ := time.Parse(time.RFC3339, c.From)
+	to, _ := time.Parse(time.RFC3339, c.To)
+	if !from.Before(to) {
+		return output.WriteError(output.ExitCodeError, "invalid_time", "--from must be before --to")
+	}
+
+	duration, err := time.ParseDuration(c.Duration)
+	if err != nil || duration <= 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_duration",
+			fmt.Sprintf("--duration %q must be a positive duration (e.g. 30m, 1h)", c.Duration))
+	}
+
+	loc, err := time.LoadLocation(c.TZ)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone",
+			fmt.Sprintf("--tz %q is not a valid IANA time zone: %v", c.TZ, err))
+	}
+
+	hours, err := parseWorkingHours(c.WorkingHours)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_working_hours", err.Error())
+	}
+
+	ids := splitCalendarIDs(normalizeEmail(c.Account) + "," + c.Attendees)
+
+	svc, err := googleapi.NewCalendarReadOnly(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	resp, err := queryFreeBusy(svc, ids, c.From, c.To)
+	if err != nil {
+		return writeGoogleAPIError("freebusy_error", err)
+	}
+
+	var busy []timeRange
+	for _, id := range ids {
+		fb, ok := resp.Calendars[id]
+		if !ok {
+			return output.WriteError(output.ExitCodeError, "freebusy_unavailable",
+				fmt.Sprintf("no free/busy data returned for %q", id))
+		}
+		if len(fb.Errors) > 0 {
+			return output.WriteError(output.ExitCodeError, "freebusy_unavailable",
+				fmt.Sprintf("free/busy for %q is unavailable: %s", id, fb.Errors[0].Reason))
+		}
+
+		for _, p := range fb.Busy {
+			r, err := parseTimeRange(p.Start, p.End)
+			if err != nil {
+				return output.WriteError(output.ExitCodeError, "freebusy_error", err.Error())
+			}
+			busy = append(busy, r)
+		}
+	}
+
+	slots := findFreeSlots(busy, workingWindows(from, to, loc, hours, c.IncludeWeekends), duration)
+	if c.Max > 0 && len(slots) > c.Max {
+		slots = slots[:c.Max]
+	}
+
+	out := make([]freeBusyRange, 0, len(slots))
+	for _, s := range slots {
+		out = append(out, freeBusyRange{
+			Start: s.Start.In(loc).Format(time.RFC3339),
+			End:   s.End.In(loc).Format(time.RFC3339),
+		})
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"calendars": ids,
+		"duration":  duration.String(),
+		"time_zone": loc.String(),
+		"slots":     out,
+	})
+}
+
+type freeBusyRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type timeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// workingHours is a daily window expressed as minutes since local midnight.
+type workingHours struct {
+	Start int
+	End   int
+}
+
+func queryFreeBusy(svc *calendar.Service, ids []string, from, to string) (*calendar.FreeBusyResponse, error) {
+	items := make([]*calendar.FreeBusyRequestItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, &calendar.FreeBusyRequestItem{Id: id})
+	}
+
+	return svc.Freebusy.Query(&calendar.FreeBusyRequest{
+		TimeMin: from,
+		TimeMax: to,
+		Items:   items,
+	}).Do()
+}
+
+// splitCalendarIDs splits a comma-separated list of calendar IDs, dropping
+// blanks and duplicates while keeping order.
+func splitCalendarIDs(csv string) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, id := range strings.Split(csv, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+
+	return out
+}
+
+func parseTimeRange(start, end string) (timeRange, error) {
+	s, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return timeRange{}, fmt.Errorf("parse busy start %q: %w", start, err)
+	}
+	e, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return timeRange{}, fmt.Errorf("parse busy end %q: %w", end, err)
+	}
+
+	return timeRange{Start: s, End: e}, nil
+}
+
+// parseWorkingHours parses "HH:MM-HH:MM".
+func parseWorkingHours(v string) (workingHours, error) {
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(v), "-")
+	if !ok {
+		return workingHours{}, fmt.Errorf("--working-hours %q must look like 09:00-18:00", v)
+	}
+
+	start, err := time.Parse("15:04", strings.TrimSpace(startStr))
+	if err != nil {
+		return workingHours{}, fmt.Errorf("--working-hours %q has invalid start: %v", v, err)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(endStr))
+	if err != nil {
+		return workingHours{}, fmt.Errorf("--working-hours %q has invalid end: %v", v, err)
+	}
+
+	wh := workingHours{
+		Start: start.Hour()*60 + start.Minute(),
+		End:   end.Hour()*60 + end.Minute(),
+	}
+	if wh.End <= wh.Start {
+		return workingHours{}, fmt.Errorf("--working-hours %q must end after it starts", v)
+	}
+
+	return wh, nil
+}
+
+// workingWindows returns the working-hour windows in loc that overlap [from, to).
+func workingWindows(from, to time.Time, loc *time.Location, hours workingHours, includeWeekends bool) []timeRange {
+	var out []timeRange
+
+	first := from.In(loc)
+	day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	for day.Before(to) {
+		weekend := day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+		if includeWeekends || !weekend {
+			start := time.Date(day.Year(), day.Month(), day.Day(), hours.Start/60, hours.Start%60, 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), hours.End/60, hours.End%60, 0, 0, loc)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if start.Before(end) {
+				out = append(out, timeRange{Start: start, End: end})
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return out
+}
+
+// findFreeSlots subtracts busy intervals from windows and keeps the free
+// ranges that are at least duration long.
+func findFreeSlots(busy, windows []timeRange, duration time.Duration) []timeRange {
+	sorted := append([]timeRange(nil), busy...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var out []timeRange
+	for _, w := range windows {
+		cursor := w.Start
+		for _, b := range sorted {
+			if !b.End.After(cursor) || !b.Start.Before(w.End) {
+				continue
+			}
+			if b.Start.After(cursor) && b.Start.Sub(cursor) >= duration {
+				out = append(out, timeRange{Start: cursor, End: b.Start})
+			}
+			if b.End.After(cursor) {
+				cursor = b.End
+			}
+			if !cursor.Before(w.End) {
+				break
+			}
+		}
+		if w.End.Sub(cursor) >= duration {
+			out = append(out, timeRange{Start: cursor, End: w.End})
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+func mustTime(t *testing.T, v string) time.Time {
+	t.Helper()
+	tm, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		t.Fatalf("parse %q: %v", v, err)
+	}
+	return tm
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	got, err := parseWorkingHours("09:30-18:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Start != 570 || got.End != 1080 {
+		t.Errorf("got %+v, want {570 1080}", got)
+	}
+
+	for _, v := range []string{"9-18", "18:00-09:00", "09:00", "25:00-26:00"} {
+		if _, err := parseWorkingHours(v); err == nil {
+			t.Errorf("parseWorkingHours(%q): expected error, got nil", v)
+		}
+	}
+}
+
+func TestSplitCalendarIDs(t *testing.T) {
+	got := splitCalendarIDs(" a@example.com,,b@example.com,a@example.com ")
+	want := []string{"a@example.com", "b@example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWorkingWindows_SkipsWeekends(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	// 2026-03-06 is a Friday; the range runs through Monday.
+	from := mustTime(t, "2026-03-06T00:00:00+09:00")
+	to := mustTime(t, "2026-03-10T00:00:00+09:00")
+	windows := workingWindows(from, to, loc, workingHours{Start: 9 * 60, End: 18 * 60}, false)
+	if len(windows) != 2 {
+		t.Fatalf("expected 2 windows (Fri, Mon), got %d: %v", len(windows), windows)
+	}
+	if got := windows[1].Start.In(loc).Format(time.RFC3339); got != "2026-03-09T09:00:00+09:00" {
+		t.Errorf("second window start = %s", got)
+	}
+
+	windows = workingWindows(from, to, loc, workingHours{Start: 9 * 60, End: 18 * 60}, true)
+	if len(windows) != 4 {
+		t.Fatalf("expected 4 windows with weekends, got %d", len(windows))
+	}
+}
+
+func TestWorkingWindows_ClipsToRange(t *testing.T) {
+	from := mustTime(t, "2026-03-02T10:15:00Z")
+	to := mustTime(t, "2026-03-02T12:00:00Z")
+	windows := workingWindows(from, to, time.UTC, workingHours{Start: 9 * 60, End: 18 * 60}, false)
+	want := []timeRange{{Start: from, End: to}}
+	if !reflect.DeepEqual(windows, want) {
+		t.Errorf("got %v, want %v", windows, want)
+	}
+}
+
+func TestFindFreeSlots_MergesOverlappingBusy(t *testing.T) {
+	windows := []timeRange{{
+		Start: mustTime(t, "2026-03-02T09:00:00Z"),
+		End:   mustTime(t, "2026-03-02T18:00:00Z"),
+	}}
+	busy := []timeRange{
+		// second attendee, overlapping the first
+		{Start: mustTime(t, "2026-03-02T10:30:00Z"), End: mustTime(t, "2026-03-02T12:00:00Z")},
+		{Start: mustTime(t, "2026-03-02T10:00:00Z"), End: mustTime(t, "2026-03-02T11:00:00Z")},
+		// leaves only a 15m gap, too short for 30m
+		{Start: mustTime(t, "2026-03-02T12:15:00Z"), End: mustTime(t, "2026-03-02T17:00:00Z")},
+	}
+
+	got := findFreeSlots(busy, windows, 30*time.Minute)
+	want := []timeRange{
+		{Start: mustTime(t, "2026-03-02T09:00:00Z"), End: mustTime(t, "2026-03-02T10:00:00Z")},
+		{Start: mustTime(t, "2026-03-02T17:00:00Z"), End: mustTime(t, "2026-03-02T18:00:00Z")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindFreeSlots_FullyBusy(t *testing.T) {
+	windows := []timeRange{{
+		Start: mustTime(t, "2026-03-02T09:00:00Z"),
+		End:   mustTime(t, "2026-03-02T18:00:00Z"),
+	}}
+	busy := []timeRange{
+		{Start: mustTime(t, "2026-03-02T08:00:00Z"), End: mustTime(t, "2026-03-02T19:00:00Z")},
+	}
+	if got := findFreeSlots(busy, windows, 30*time.Minute); len(got) != 0 {
+		t.Errorf("expected no slots, got %v", got)
+	}
+}
+
+func TestCalendarFindSlotCmd_PolicyDenied(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"calendar.list"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	cmd := &CalendarFindSlotCmd{
+		Account: "a@example.com",
+		From:    "2026-03-02T00:00:00Z",
+		To:      "2026-03-03T00:00:00Z",
+	}
+	assertPolicyDenied(t, func() error {
+		return cmd.Run(context.Background(), &RootFlags{})
+	})
+}
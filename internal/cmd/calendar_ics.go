@@ -0,0 +1,755 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+const (
+	icsDateLayout     = "20060102"
+	icsDateTimeLayout = "20060102T150405"
+	icsUTCLayout      = "20060102T150405Z"
+)
+
+// CalendarExportCmd exports events to an iCalendar (.ics) file.
+type CalendarExportCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	CalendarID string `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	From       string `name:"from" required:"" help:"Start time in RFC3339 format (or local time with --timezone)."`
+	To         string `name:"to" required:"" help:"End time in RFC3339 format (or local time with --timezone)."`
+	TimeZone   string `name:"timezone" help:"IANA time zone for local --from/--to (e.g. Asia/Tokyo)."`
+	Output     string `name:"output" required:"" help:"Output .ics file path."`
+	Overwrite  bool   `name:"overwrite" help:"Allow overwriting an existing output file (default: disabled)."`
+}
+
+func (c *CalendarExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.export"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+	if err := ensureWithinAllowedOutputDir(c.Output, root.AllowedOutputDir); err != nil {
+		return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
+	}
+
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
+	}
+
+	from, err := resolveEventTime("--from", c.From, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	to, err := resolveEventTime("--to", c.To, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "calendar.export",
+			Account: normalizeEmail(c.Account),
+			Target:  c.Output,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "calendar.export",
+			"params": map[string]any{
+				"account":     c.Account,
+				"calendar_id": c.CalendarID,
+				"from":        from,
+				"to":          to,
+				"output":      c.Output,
+				"overwrite":   c.Overwrite,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewCalendarReadOnly(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	// Export series rather than expanded instances so RRULEs survive the round trip.
+	events, _, err := collectAllPages(true, func(pageToken string) (string, []*calendar.Event, error) {
+		req := svc.Events.List(c.CalendarID).
+			SingleEvents(false).
+			TimeMin(from).
+			TimeMax(to).
+			MaxResults(250)
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("calendar export: %w", err)
+		}
+
+		return resp.NextPageToken, resp.Items, nil
+	})
+	if err != nil {
+		return writeGoogleAPIError("export_error", err)
+	}
+
+	ics := encodeICS(events, time.Now())
+
+	written, err := writeFileAtomically(c.Output, strings.NewReader(ics), c.Overwrite)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_write_error", fmt.Sprintf("write output file: %v", err))
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "calendar.export",
+		Account: normalizeEmail(c.Account),
+		Target:  c.Output,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"exported":      true,
+		"calendar_id":   c.CalendarID,
+		"events":        len(events),
+		"output":        c.Output,
+		"bytes_written": written,
+	})
+}
+
+// CalendarImportCmd creates events from an iCalendar (.ics) file.
+type CalendarImportCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	CalendarID string `name:"calendar-id" default:"primary" help:"Calendar ID."`
+	File       string `name:"file" required:"" help:"Input .ics file path (- for stdin)."`
+	TimeZone   string `name:"timezone" help:"IANA time zone for floating (zone-less) times in the file (default: UTC)."`
+}
+
+func (c *CalendarImportCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "calendar.import"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	loc, err := loadTimeZone("--timezone", c.TimeZone)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_timezone", err.Error())
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	data, err := readInputFile(c.File)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+	}
+
+	parsed, err := decodeICS(data, loc)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_ics", err.Error())
+	}
+
+	type importedEvent struct {
+		UID     string   `json:"uid,omitempty"`
+		ID      string   `json:"id,omitempty"`
+		Summary string   `json:"summary,omitempty"`
+		Start   string   `json:"start"`
+		End     string   `json:"end"`
+		Rules   []string `json:"recurrence,omitempty"`
+		Reason  string   `json:"reason,omitempty"`
+	}
+
+	toImported := func(e *calendar.Event) importedEvent {
+		return importedEvent{
+			UID:     e.ICalUID,
+			Summary: e.Summary,
+			Start:   eventTimeString(e.Start),
+			End:     eventTimeString(e.End),
+			Rules:   e.Recurrence,
+		}
+	}
+
+	// Duplicates within the file are skipped up front; duplicates already in
+	// the calendar are only known after a lookup at import time.
+	var pending []*calendar.Event
+	skipped := []importedEvent{}
+	seen := make(map[string]struct{})
+	for _, e := range parsed.Events {
+		if e.ICalUID != "" {
+			if _, ok := seen[e.ICalUID]; ok {
+				ev := toImported(e)
+				ev.Reason = "duplicate_in_file"
+				skipped = append(skipped, ev)
+				continue
+			}
+			seen[e.ICalUID] = struct{}{}
+		}
+		pending = append(pending, e)
+	}
+	for _, o := range parsed.Overrides {
+		ev := toImported(o)
+		ev.Reason = "recurrence_override_unsupported"
+		skipped = append(skipped, ev)
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "calendar.import",
+			Account: normalizeEmail(c.Account),
+			Target:  c.CalendarID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+
+		preview := make([]importedEvent, 0, len(pending))
+		for _, e := range pending {
+			preview = append(preview, toImported(e))
+		}
+
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "calendar.import",
+			"params": map[string]any{
+				"account":     c.Account,
+				"calendar_id": c.CalendarID,
+				"file":        c.File,
+				"events":      preview,
+				"skipped":     skipped,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewCalendarWrite(ctx, c.Account)
+	if err != nil {
+		return calendarAuthError(err)
+	}
+
+	created := []importedEvent{}
+	auditCreated := func() error {
+		return appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "calendar.import",
+			Account: normalizeEmail(c.Account),
+			Target:  c.CalendarID,
+			DryRun:  false,
+		})
+	}
+	// importFailed records the events created before err, so a partial import
+	// is neither missing from the audit log nor invisible to the caller.
+	importFailed := func(err error) error {
+		if len(created) == 0 {
+			return writeGoogleAPIError("import_error", err)
+		}
+		if auditErr := auditCreated(); auditErr != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", auditErr.Error())
+		}
+		ids := make([]string, 0, len(created))
+		for _, ev := range created {
+			ids = append(ids, ev.ID)
+		}
+		return writeGoogleAPIError("import_error", fmt.Errorf("%w (created before failure: %s)", err, strings.Join(ids, ", ")))
+	}
+
+	for _, e := range pending {
+		var inserted *calendar.Event
+		if e.ICalUID != "" {
+			existing, err := svc.Events.List(c.CalendarID).ICalUID(e.ICalUID).MaxResults(1).Do()
+			if err != nil {
+				return importFailed(err)
+			}
+			if len(existing.Items) > 0 {
+				ev := toImported(e)
+				ev.ID = existing.Items[0].Id
+				ev.Reason = "duplicate"
+				skipped = append(skipped, ev)
+				continue
+			}
+
+			// Import keeps the file's UID, so a later import of the same
+			// file finds these events by iCalUID and skips them.
+			inserted, err = svc.Events.Import(c.CalendarID, e).Do()
+			if err != nil {
+				return importFailed(err)
+			}
+		} else {
+			inserted, err = svc.Events.Insert(c.CalendarID, e).SendUpdates("none").Do()
+			if err != nil {
+				return importFailed(err)
+			}
+		}
+
+		ev := toImported(inserted)
+		ev.ID = inserted.Id
+		created = append(created, ev)
+	}
+
+	if err := auditCreated(); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"calendar_id": c.CalendarID,
+		"created":     created,
+		"skipped":     skipped,
+	})
+}
+
+// encodeICS renders events as an RFC 5545 VCALENDAR. Timed events are written
+// in UTC, except recurring ones with a time zone, which keep TZID so that
+// occurrences follow DST changes.
+func encodeICS(events []*calendar.Event, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//gog-lite//calendar export//EN")
+	line("CALSCALE:GREGORIAN")
+
+	stamp := now.UTC().Format(icsUTCLayout)
+	for _, e := range events {
+		if e.Status == "cancelled" {
+			continue
+		}
+
+		keepZone := len(e.Recurrence) > 0 || e.RecurringEventId != ""
+
+		line("BEGIN:VEVENT")
+		uid := e.ICalUID
+		if uid == "" {
+			uid = e.Id
+		}
+		line("UID:" + escapeICSText(uid))
+		line("DTSTAMP:" + stamp)
+		line(icsDateTimeProperty("DTSTART", e.Start, keepZone))
+		if e.End != nil {
+			line(icsDateTimeProperty("DTEND", e.End, keepZone))
+		}
+		if e.OriginalStartTime != nil {
+			line(icsDateTimeProperty("RECURRENCE-ID", e.OriginalStartTime, keepZone))
+		}
+		for _, r := range e.Recurrence {
+			line(r)
+		}
+		if e.Summary != "" {
+			line("SUMMARY:" + escapeICSText(e.Summary))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION:" + escapeICSText(e.Location))
+		}
+		if e.Status != "" {
+			line("STATUS:" + strings.ToUpper(e.Status))
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return b.String()
+}
+
+func icsDateTimeProperty(name string, edt *calendar.EventDateTime, keepZone bool) string {
+	if edt == nil {
+		return name + ":"
+	}
+	if edt.DateTime == "" {
+		d, err := time.Parse("2006-01-02", edt.Date)
+		if err != nil {
+			return name + ";VALUE=DATE:" + strings.ReplaceAll(edt.Date, "-", "")
+		}
+		return name + ";VALUE=DATE:" + d.Format(icsDateLayout)
+	}
+
+	t, err := time.Parse(time.RFC3339, edt.DateTime)
+	if err != nil {
+		return name + ":" + edt.DateTime
+	}
+
+	if keepZone && edt.TimeZone != "" {
+		if loc, err := time.LoadLocation(edt.TimeZone); err == nil {
+			return name + ";TZID=" + edt.TimeZone + ":" + t.In(loc).Format(icsDateTimeLayout)
+		}
+	}
+
+	return name + ":" + t.UTC().Format(icsUTCLayout)
+}
+
+// foldICSLine splits lines longer than 75 octets (RFC 5545 §3.1) without
+// breaking UTF-8 sequences.
+func foldICSLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		width = limit - 1
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+var icsTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	return icsTextUnescaper.Replace(s)
+}
+
+// icsProperty is one content line: NAME;PARAM=VALUE:value.
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+	Raw    string
+}
+
+// icsData holds the events decoded from a VCALENDAR. Overrides are modified
+// instances of a recurring event (VEVENTs with RECURRENCE-ID).
+type icsData struct {
+	Events    []*calendar.Event
+	Overrides []*calendar.Event
+}
+
+// decodeICS parses the VEVENTs in an iCalendar document. Floating (zone-less)
+// times are interpreted in loc; TZIDs must be IANA names.
+func decodeICS(data string, loc *time.Location) (icsData, error) {
+	var out icsData
+
+	var (
+		current  *calendar.Event
+		duration *icsDuration
+		override bool
+		depth    int
+	)
+
+	for i, raw := range unfoldICSLines(data) {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		prop, err := parseICSProperty(raw)
+		if err != nil {
+			return icsData{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT") && current == nil:
+			current = &calendar.Event{}
+			duration = nil
+			override = false
+			depth = 0
+			continue
+		case current == nil:
+			continue
+		case prop.Name == "BEGIN":
+			// Nested components such as VALARM are ignored.
+			depth++
+			continue
+		case prop.Name == "END" && depth > 0:
+			depth--
+			continue
+		case depth > 0:
+			continue
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VEVENT"):
+			if current.Start == nil {
+				return icsData{}, fmt.Errorf("VEVENT %q has no DTSTART", current.ICalUID)
+			}
+			switch {
+			case current.End != nil:
+			case duration != nil:
+				end, err := duration.endFrom(current.Start)
+				if err != nil {
+					return icsData{}, fmt.Errorf("VEVENT %q: %w", current.ICalUID, err)
+				}
+				current.End = end
+			default:
+				current.End = defaultICSEnd(current.Start)
+			}
+			if override {
+				out.Overrides = append(out.Overrides, current)
+			} else {
+				out.Events = append(out.Events, current)
+			}
+			current = nil
+			continue
+		}
+
+		switch prop.Name {
+		case "UID":
+			current.ICalUID = prop.Value
+		case "SUMMARY":
+			current.Summary = unescapeICSText(prop.Value)
+		case "DESCRIPTION":
+			current.Description = unescapeICSText(prop.Value)
+		case "LOCATION":
+			current.Location = unescapeICSText(prop.Value)
+		case "DTSTART", "DTEND":
+			edt, err := parseICSDateTime(prop, loc)
+			if err != nil {
+				return icsData{}, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if prop.Name == "DTSTART" {
+				current.Start = edt
+			} else {
+				current.End = edt
+			}
+		case "DURATION":
+			d, err := parseICSDuration(prop.Value)
+			if err != nil {
+				return icsData{}, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			duration = &d
+		case "RECURRENCE-ID":
+			override = true
+		case "RRULE", "EXRULE", "RDATE", "EXDATE":
+			current.Recurrence = append(current.Recurrence, prop.Raw)
+		}
+	}
+
+	if current != nil {
+		return icsData{}, fmt.Errorf("unterminated VEVENT %q", current.ICalUID)
+	}
+
+	// Recurring timed events need an IANA time zone to expand.
+	for _, e := range out.Events {
+		if len(e.Recurrence) > 0 && e.Start.DateTime != "" && e.Start.TimeZone == "" {
+			e.Start.TimeZone = loc.String()
+			e.End.TimeZone = loc.String()
+		}
+	}
+
+	return out, nil
+}
+
+// unfoldICSLines joins continuation lines (RFC 5545 §3.1).
+func unfoldICSLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+
+	var out []string
+	for _, l := range strings.Split(data, "\n") {
+		if len(out) > 0 && (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) {
+			out[len(out)-1] += l[1:]
+			continue
+		}
+		out = append(out, strings.TrimSuffix(l, "\r"))
+	}
+
+	return out
+}
+
+func parseICSProperty(line string) (icsProperty, error) {
+	// The value starts at the first colon outside a quoted parameter value.
+	inQuote := false
+	sep := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuote = !inQuote
+		}
+		if r == ':' && !inQuote {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return icsProperty{}, fmt.Errorf("malformed content line %q", line)
+	}
+
+	parts := strings.Split(line[:sep], ";")
+	prop := icsProperty{
+		Name:   strings.ToUpper(parts[0]),
+		Params: make(map[string]string, len(parts)-1),
+		Value:  line[sep+1:],
+		Raw:    line,
+	}
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return prop, nil
+}
+
+func parseICSDateTime(prop icsProperty, loc *time.Location) (*calendar.EventDateTime, error) {
+	v := prop.Value
+	if prop.Params["VALUE"] == "DATE" || len(v) == len(icsDateLayout) {
+		d, err := time.Parse(icsDateLayout, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a valid date: %v", prop.Name, v, err)
+		}
+		return &calendar.EventDateTime{Date: d.Format("2006-01-02")}, nil
+	}
+
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse(icsUTCLayout, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q is not a valid UTC time: %v", prop.Name, v, err)
+		}
+		return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}, nil
+	}
+
+	zone := loc
+	tzid := prop.Params["TZID"]
+	if tzid != "" {
+		var err error
+		zone, err = time.LoadLocation(tzid)
+		if err != nil {
+			return nil, fmt.Errorf("%s has unknown TZID %q (use an IANA name)", prop.Name, tzid)
+		}
+	}
+
+	t, err := time.ParseInLocation(icsDateTimeLayout, v, zone)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q is not a valid time: %v", prop.Name, v, err)
+	}
+
+	return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339), TimeZone: tzid}, nil
+}
+
+// defaultICSEnd applies the RFC 5545 default when both DTEND and DURATION
+// are absent: one day for
+// all-day events, zero duration otherwise.
+func defaultICSEnd(start *calendar.EventDateTime) *calendar.EventDateTime {
+	if start.DateTime != "" {
+		end := *start
+		return &end
+	}
+
+	d, err := time.Parse("2006-01-02", start.Date)
+	if err != nil {
+		return &calendar.EventDateTime{Date: start.Date}
+	}
+
+	return &calendar.EventDateTime{Date: d.AddDate(0, 0, 1).Format("2006-01-02")}
+}
+
+// icsDuration is an RFC 5545 dur-value. Days (and weeks) are nominal, so they
+// follow the calendar across DST changes; the time part is exact.
+type icsDuration struct {
+	Days int
+	Time time.Duration
+}
+
+// parseICSDuration parses a dur-value such as P1W, P1DT2H or PT30M.
+func parseICSDuration(v string) (icsDuration, error) {
+	invalid := fmt.Errorf("DURATION %q is not a valid duration", v)
+
+	rest := strings.TrimPrefix(strings.ToUpper(v), "+")
+	if strings.HasPrefix(rest, "-") {
+		return icsDuration{}, fmt.Errorf("DURATION %q must not be negative", v)
+	}
+	rest, ok := strings.CutPrefix(rest, "P")
+	if !ok || rest == "" {
+		return icsDuration{}, invalid
+	}
+
+	var d icsDuration
+	inTime := false
+	units := 0
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return icsDuration{}, invalid
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+
+		n := 0
+		for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == 0 || n == len(rest) {
+			return icsDuration{}, invalid
+		}
+		value, err := strconv.Atoi(rest[:n])
+		if err != nil {
+			return icsDuration{}, invalid
+		}
+
+		switch unit := rest[n]; {
+		case unit == 'W' && !inTime:
+			d.Days += 7 * value
+		case unit == 'D' && !inTime:
+			d.Days += value
+		case unit == 'H' && inTime:
+			d.Time += time.Duration(value) * time.Hour
+		case unit == 'M' && inTime:
+			d.Time += time.Duration(value) * time.Minute
+		case unit == 'S' && inTime:
+			d.Time += time.Duration(value) * time.Second
+		default:
+			return icsDuration{}, invalid
+		}
+		units++
+		rest = rest[n+1:]
+	}
+	if units == 0 {
+		return icsDuration{}, invalid
+	}
+
+	return d, nil
+}
+
+// endFrom returns the end of an event that starts at start and lasts d.
+func (d icsDuration) endFrom(start *calendar.EventDateTime) (*calendar.EventDateTime, error) {
+	if start.DateTime == "" {
+		if d.Time != 0 {
+			return nil, fmt.Errorf("DURATION of an all-day event must be whole days or weeks")
+		}
+		day, err := time.Parse("2006-01-02", start.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date %q: %v", start.Date, err)
+		}
+		return &calendar.EventDateTime{Date: day.AddDate(0, 0, d.Days).Format("2006-01-02")}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, start.DateTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time %q: %v", start.DateTime, err)
+	}
+	if start.TimeZone != "" {
+		if zone, err := time.LoadLocation(start.TimeZone); err == nil {
+			t = t.In(zone)
+		}
+	}
+
+	return &calendar.EventDateTime{
+		DateTime: t.AddDate(0, 0, d.Days).Add(d.Time).Format(time.RFC3339),
+		TimeZone: start.TimeZone,
+	}, nil
+}
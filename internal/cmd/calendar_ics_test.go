@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+func TestEncodeICS_RoundTrip(t *testing.T) {
+	events := []*calendar.Event{
+		{
+			Id:          "ev1",
+			ICalUID:     "ev1@google.com",
+			Summary:     "Planning; Q2, draft",
+			Description: "line one\nline two",
+			Start:       &calendar.EventDateTime{DateTime: "2026-03-02T10:00:00+09:00"},
+			End:         &calendar.EventDateTime{DateTime: "2026-03-02T11:00:00+09:00"},
+		},
+		{
+			Id:      "ev2",
+			ICalUID: "ev2@google.com",
+			Summary: "Offsite",
+			Start:   &calendar.EventDateTime{Date: "2026-03-05"},
+			End:     &calendar.EventDateTime{Date: "2026-03-07"},
+		},
+		{
+			Id:         "ev3",
+			ICalUID:    "ev3@google.com",
+			Summary:    "Weekly",
+			Start:      &calendar.EventDateTime{DateTime: "2026-03-02T09:00:00+09:00", TimeZone: "Asia/Tokyo"},
+			End:        &calendar.EventDateTime{DateTime: "2026-03-02T09:30:00+09:00", TimeZone: "Asia/Tokyo"},
+			Recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO"},
+		},
+		{Id: "gone", Status: "cancelled"},
+	}
+
+	ics := encodeICS(events, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Fatalf("unexpected envelope:\n%s", ics)
+	}
+	if strings.Contains(ics, "gone") {
+		t.Error("cancelled event should not be exported")
+	}
+
+	if _, err := time.LoadLocation("Asia/Tokyo"); err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	got, err := decodeICS(ics, time.UTC)
+	if err != nil {
+		t.Fatalf("decodeICS: %v", err)
+	}
+	if len(got.Events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got.Events))
+	}
+
+	if e := got.Events[0]; e.Summary != "Planning; Q2, draft" || e.Description != "line one\nline two" {
+		t.Errorf("text not round-tripped: %+v", e)
+	}
+	if e := got.Events[0]; e.Start.DateTime != "2026-03-02T01:00:00Z" {
+		t.Errorf("timed start = %q, want UTC", e.Start.DateTime)
+	}
+	if e := got.Events[1]; e.Start.Date != "2026-03-05" || e.End.Date != "2026-03-07" {
+		t.Errorf("all-day dates = %s..%s", e.Start.Date, e.End.Date)
+	}
+	if e := got.Events[2]; e.Start.TimeZone != "Asia/Tokyo" || len(e.Recurrence) != 1 {
+		t.Errorf("recurring event lost zone or rule: %+v %v", e.Start, e.Recurrence)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("あ", 40)
+	folded := foldICSLine(long)
+	for _, l := range strings.Split(folded, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line exceeds 75 octets: %d", len(l))
+		}
+	}
+	if got := strings.Join(unfoldICSLines(folded), ""); got != long {
+		t.Errorf("unfold mismatch: %q", got)
+	}
+}
+
+func TestDecodeICS_DefaultsAndOverrides(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:a",
+		"DTSTART;VALUE=DATE:20260301",
+		"SUMMARY:Holiday",
+		"BEGIN:VALARM",
+		"SUMMARY:ignored",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:b",
+		"RECURRENCE-ID:20260302T000000Z",
+		"DTSTART:20260302T010000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\n")
+
+	got, err := decodeICS(ics, time.UTC)
+	if err != nil {
+		t.Fatalf("decodeICS: %v", err)
+	}
+	if len(got.Events) != 1 || len(got.Overrides) != 1 {
+		t.Fatalf("got %d events, %d overrides", len(got.Events), len(got.Overrides))
+	}
+	e := got.Events[0]
+	if e.Summary != "Holiday" || e.End.Date != "2026-03-02" {
+		t.Errorf("unexpected event: summary=%q end=%+v", e.Summary, e.End)
+	}
+
+	if _, err := decodeICS("BEGIN:VEVENT\nUID:x\nEND:VEVENT\n", time.UTC); err == nil {
+		t.Error("expected error for VEVENT without DTSTART")
+	}
+}
+
+func TestDecodeICS_Duration(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VEVENT",
+		"UID:timed",
+		"DTSTART;TZID=America/New_York:20260307T233000",
+		"DURATION:P1DT1H",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:allday",
+		"DTSTART;VALUE=DATE:20260301",
+		"DURATION:P1W",
+		"END:VEVENT",
+	}, "\n")
+
+	got, err := decodeICS(ics, time.UTC)
+	if err != nil {
+		t.Fatalf("decodeICS: %v", err)
+	}
+	// The day is nominal: it spans the DST change on 2026-03-08, so the
+	// wall-clock time is kept and the offset moves from -05:00 to -04:00.
+	if end := got.Events[0].End; end.DateTime != "2026-03-09T00:30:00-04:00" || end.TimeZone != "America/New_York" {
+		t.Errorf("timed end = %+v", end)
+	}
+	if end := got.Events[1].End; end.Date != "2026-03-08" {
+		t.Errorf("all-day end = %+v", end)
+	}
+
+	for _, bad := range []string{"PT", "P1H", "1D", "-PT1H", "PT1D"} {
+		if _, err := parseICSDuration(bad); err == nil {
+			t.Errorf("parseICSDuration(%q) succeeded, want error", bad)
+		}
+	}
+	if d, err := parseICSDuration("PT1H30M"); err != nil || d.Time != 90*time.Minute || d.Days != 0 {
+		t.Errorf("parseICSDuration(PT1H30M) = %+v, %v", d, err)
+	}
+}
+
+func TestCalendarImportCmd_DryRunSkipsDuplicateUIDs(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"calendar.import"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "events.ics")
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nUID:dup\r\nDTSTART:20260302T010000Z\r\nDTEND:20260302T020000Z\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:dup\r\nDTSTART:20260303T010000Z\r\nDTEND:20260303T020000Z\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	if err := os.WriteFile(path, []byte(ics), 0o600); err != nil {
+		t.Fatalf("write ics: %v", err)
+	}
+
+	cmd := &CalendarImportCmd{Account: "a@example.com", CalendarID: "primary", File: path}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	var resp struct {
+		Params struct {
+			Events  []map[string]any `json:"events"`
+			Skipped []map[string]any `json:"skipped"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if len(resp.Params.Events) != 1 || len(resp.Params.Skipped) != 1 {
+		t.Fatalf("got %d events, %d skipped", len(resp.Params.Events), len(resp.Params.Skipped))
+	}
+	if resp.Params.Skipped[0]["reason"] != "duplicate_in_file" {
+		t.Errorf("unexpected skip reason: %v", resp.Params.Skipped[0]["reason"])
+	}
+}
+
+func TestCalendarExportCmd_OutputNotAllowed(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &CalendarExportCmd{
+		Account: "a@example.com",
+		From:    "2026-03-01T00:00:00Z",
+		To:      "2026-04-01T00:00:00Z",
+		Output:  filepath.Join(t.TempDir(), "events.ics"),
+	}
+
+	var runErr error
+	errOut := captureStderr(t, func() {
+		runErr = cmd.Run(context.Background(), &RootFlags{DryRun: true, AllowedOutputDir: t.TempDir()})
+	})
+	if runErr == nil {
+		t.Fatal("expected error for output outside allowed dir")
+	}
+	if !strings.Contains(errOut, `"output_not_allowed"`) {
+		t.Errorf("expected output_not_allowed, got %s", errOut)
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	recurringScopeInstance  = "instance"
+	recurringScopeFollowing = "following"
+	recurringScopeAll       = "all"
+)
+
+// parseRecurringScope normalizes --scope for recurring event updates and deletes.
+func parseRecurringScope(v string) (string, error) {
+	scope := strings.ToLower(strings.TrimSpace(v))
+	switch scope {
+	case "":
+		return recurringScopeInstance, nil
+	case recurringScopeInstance, recurringScopeFollowing, recurringScopeAll:
+		return scope, nil
+	}
+
+	return "", fmt.Errorf("--scope %q is invalid; use instance, following, or all", v)
+}
+
+// validateRecurrence checks that each line is an RFC 5545 recurrence property
+// accepted by the Calendar API (RRULE, EXRULE, RDATE, EXDATE).
+func validateRecurrence(rules []string) error {
+	for _, rule := range rules {
+		name, value, ok := strings.Cut(strings.TrimSpace(rule), ":")
+		if !ok || strings.TrimSpace(value) == "" {
+			return fmt.Errorf("--recurrence %q must look like RRULE:FREQ=WEEKLY;BYDAY=MO", rule)
+		}
+
+		prop, _, _ := strings.Cut(strings.ToUpper(name), ";")
+		switch prop {
+		case "RRULE", "EXRULE":
+			if !strings.Contains(strings.ToUpper(value), "FREQ=") {
+				return fmt.Errorf("--recurrence %q must include FREQ=", rule)
+			}
+		case "RDATE", "EXDATE":
+		default:
+			return fmt.Errorf("--recurrence %q has unsupported property %q; use RRULE, EXRULE, RDATE, or EXDATE", rule, prop)
+		}
+	}
+
+	return nil
+}
+
+// normalizeRecurrence trims whitespace and drops empty recurrence lines.
+func normalizeRecurrence(rules []string) []string {
+	out := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule != "" {
+			out = append(out, rule)
+		}
+	}
+
+	return out
+}
+
+// recurrenceUntil returns the RRULE UNTIL value that ends a series just before start.
+func recurrenceUntil(start *calendar.EventDateTime) (string, error) {
+	if start == nil {
+		return "", fmt.Errorf("instance has no original start time")
+	}
+
+	if start.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, start.DateTime)
+		if err != nil {
+			return "", fmt.Errorf("parse instance start %q: %w", start.DateTime, err)
+		}
+
+		return t.Add(-time.Second).UTC().Format("20060102T150405Z"), nil
+	}
+
+	d, err := time.Parse("2006-01-02", start.Date)
+	if err != nil {
+		return "", fmt.Errorf("parse instance start date %q: %w", start.Date, err)
+	}
+
+	return d.AddDate(0, 0, -1).Format("20060102"), nil
+}
+
+// endRecurrence replaces COUNT/UNTIL on every RRULE with UNTIL=until.
+func endRecurrence(rules []string, until string) []string {
+	out := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if !isRRule(rule) {
+			out = append(out, rule)
+			continue
+		}
+
+		out = append(out, rewriteRRule(rule, func(parts []string) []string {
+			kept := make([]string, 0, len(parts)+1)
+			for _, p := range parts {
+				key, _, _ := strings.Cut(strings.ToUpper(p), "=")
+				if key != "COUNT" && key != "UNTIL" {
+					kept = append(kept, p)
+				}
+			}
+
+			return append(kept, "UNTIL="+until)
+		}))
+	}
+
+	return out
+}
+
+// continueRecurrence returns rules for a series that resumes after elapsed
+// occurrences, reducing any RRULE COUNT accordingly.
+func continueRecurrence(rules []string, elapsed int) []string {
+	out := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if !isRRule(rule) || elapsed <= 0 {
+			out = append(out, rule)
+			continue
+		}
+
+		out = append(out, rewriteRRule(rule, func(parts []string) []string {
+			for i, p := range parts {
+				key, value, _ := strings.Cut(p, "=")
+				if strings.ToUpper(key) != "COUNT" {
+					continue
+				}
+
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					continue
+				}
+
+				remaining := n - elapsed
+				if remaining < 1 {
+					remaining = 1
+				}
+				parts[i] = key + "=" + strconv.Itoa(remaining)
+			}
+
+			return parts
+		}))
+	}
+
+	return out
+}
+
+func isRRule(rule string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+}
+
+func rewriteRRule(rule string, fn func(parts []string) []string) string {
+	name, value, _ := strings.Cut(strings.TrimSpace(rule), ":")
+
+	return name + ":" + strings.Join(fn(strings.Split(value, ";")), ";")
+}
+
+// countInstancesBefore counts occurrences (including cancelled ones) of a
+// series that start before the given instance start.
+func countInstancesBefore(svc *calendar.Service, calendarID, seriesID string, start *calendar.EventDateTime) (int, error) {
+	timeMax := start.DateTime
+	if timeMax == "" {
+		timeMax = start.Date + "T00:00:00Z"
+	}
+
+	items, _, err := collectAllPages(true, func(pageToken string) (string, []*calendar.Event, error) {
+		req := svc.Events.Instances(calendarID, seriesID).
+			TimeMax(timeMax).
+			ShowDeleted(true).
+			MaxResults(2500)
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("calendar instances: %w", err)
+		}
+
+		return resp.NextPageToken, resp.Items, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
+
+// splitSeriesAt rewrites the recurrence of the series that instance belongs to
+// so that it ends just before the instance; the caller persists the master.
+// It returns the series master, the rules that continue the series from the
+// instance onwards, and whether the instance is the first occurrence (in which
+// case the master is left untouched and the caller should act on the whole
+// series instead).
+func splitSeriesAt(svc *calendar.Service, calendarID string, instance *calendar.Event) (*calendar.Event, []string, bool, error) {
+	master, err := svc.Events.Get(calendarID, instance.RecurringEventId).Do()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	origStart := instance.OriginalStartTime
+	if origStart == nil {
+		origStart = instance.Start
+	}
+
+	elapsed, err := countInstancesBefore(svc, calendarID, master.Id, origStart)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if elapsed == 0 {
+		return master, master.Recurrence, true, nil
+	}
+
+	until, err := recurrenceUntil(origStart)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	tail := continueRecurrence(master.Recurrence, elapsed)
+	master.Recurrence = endRecurrence(master.Recurrence, until)
+
+	return master, tail, false, nil
+}
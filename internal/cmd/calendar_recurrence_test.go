@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseRecurringScope(t *testing.T) {
+	for in, want := range map[string]string{
+		"":          recurringScopeInstance,
+		"instance":  recurringScopeInstance,
+		"FOLLOWING": recurringScopeFollowing,
+		" all ":     recurringScopeAll,
+	} {
+		got, err := parseRecurringScope(in)
+		if err != nil {
+			t.Errorf("parseRecurringScope(%q): unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseRecurringScope(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if _, err := parseRecurringScope("series"); err == nil {
+		t.Error("expected error for unknown scope")
+	}
+}
+
+func TestValidateRecurrence_Valid(t *testing.T) {
+	rules := []string{
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"EXDATE;TZID=Asia/Tokyo:20260302T100000",
+		"RDATE;VALUE=DATE:20260310",
+	}
+	if err := validateRecurrence(rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRecurrence_Invalid(t *testing.T) {
+	for _, rule := range []string{
+		"FREQ=WEEKLY",
+		"RRULE:",
+		"RRULE:BYDAY=MO",
+		"VEVENT:FREQ=DAILY",
+	} {
+		if err := validateRecurrence([]string{rule}); err == nil {
+			t.Errorf("validateRecurrence(%q): expected error, got nil", rule)
+		}
+	}
+}
+
+func TestRecurrenceUntil_DateTime(t *testing.T) {
+	got, err := recurrenceUntil(&calendar.EventDateTime{DateTime: "2026-03-09T10:00:00+09:00"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "20260309T005959Z" {
+		t.Errorf("got %q, want %q", got, "20260309T005959Z")
+	}
+}
+
+func TestRecurrenceUntil_Date(t *testing.T) {
+	got, err := recurrenceUntil(&calendar.EventDateTime{Date: "2026-03-01"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "20260228" {
+		t.Errorf("got %q, want %q", got, "20260228")
+	}
+}
+
+func TestEndRecurrence_ReplacesCountAndUntil(t *testing.T) {
+	got := endRecurrence([]string{
+		"RRULE:FREQ=WEEKLY;COUNT=10;BYDAY=MO",
+		"EXDATE:20260302T010000Z",
+	}, "20260309T005959Z")
+	want := []string{
+		"RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20260309T005959Z",
+		"EXDATE:20260302T010000Z",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestContinueRecurrence_ReducesCount(t *testing.T) {
+	got := continueRecurrence([]string{"RRULE:FREQ=DAILY;COUNT=10"}, 4)
+	want := []string{"RRULE:FREQ=DAILY;COUNT=6"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestContinueRecurrence_KeepsUntil(t *testing.T) {
+	rules := []string{"RRULE:FREQ=DAILY;UNTIL=20261231T000000Z"}
+	got := continueRecurrence(rules, 4)
+	if !reflect.DeepEqual(got, rules) {
+		t.Errorf("got %v, want %v", got, rules)
+	}
+}
@@ -173,3 +173,121 @@ func TestCalendarDeleteRejectsReusedApprovalToken(t *testing.T) {
 		t.Errorf("code = %q, want %q", payload.Code, "approval_required")
 	}
 }
+
+func TestCalendarDeleteSeriesRequiresSeriesApproval(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// A token for single-event deletes must not unlock a series delete.
+	token, _, err := issueApprovalToken("a@example.com", "calendar.delete", time.Minute)
+	if err != nil {
+		t.Fatalf("issueApprovalToken: %v", err)
+	}
+
+	cmd := &CalendarDeleteCmd{
+		Account:       "a@example.com",
+		EventID:       "event-123",
+		Scope:         "all",
+		ConfirmDelete: true,
+		ApprovalToken: token,
+	}
+
+	var runErr error
+	stderr := captureStderr(t, func() {
+		runErr = cmd.Run(context.Background(), &RootFlags{DryRun: false})
+	})
+	if runErr == nil {
+		t.Fatal("expected error for mismatched approval action")
+	}
+	if output.ExitCode(runErr) != output.ExitCodePermission {
+		t.Fatalf("expected ExitCodePermission, got %d", output.ExitCode(runErr))
+	}
+
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &payload); err != nil {
+		t.Fatalf("parse stderr JSON: %v (got %q)", err, stderr)
+	}
+	if payload.Code != "approval_required" {
+		t.Errorf("code = %q, want %q", payload.Code, "approval_required")
+	}
+}
+
+func TestCalendarUpdateRecurrenceRequiresSeriesScope(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &CalendarUpdateCmd{
+		Account:    "a@example.com",
+		EventID:    "event-123",
+		Recurrence: []string{"RRULE:FREQ=DAILY"},
+	}
+
+	var runErr error
+	stderr := captureStderr(t, func() {
+		runErr = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if runErr == nil {
+		t.Fatal("expected error for --recurrence with instance scope")
+	}
+
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &payload); err != nil {
+		t.Fatalf("parse stderr JSON: %v (got %q)", err, stderr)
+	}
+	if payload.Code != "invalid_scope" {
+		t.Errorf("code = %q, want %q", payload.Code, "invalid_scope")
+	}
+}
+
+func TestCalendarDeleteAuditRecordsScope(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	for _, scope := range []string{"instance", "all"} {
+		cmd := &CalendarDeleteCmd{Account: "a@example.com", CalendarID: "primary", EventID: "event-123", Scope: scope}
+		captureStdout(t, func() {
+			if err := cmd.Run(context.Background(), &RootFlags{DryRun: true}); err != nil {
+				t.Fatalf("dry-run delete: %v", err)
+			}
+		})
+	}
+
+	entries := readAuditEntries(t)
+	if len(entries) != 2 {
+		t.Fatalf("audit entries = %+v", entries)
+	}
+	if entries[0].Action != "calendar.delete" || entries[0].Target != "event-123 (scope=instance)" {
+		t.Errorf("instance entry = %+v", entries[0])
+	}
+	if entries[1].Action != "calendar.delete.series" || entries[1].Target != "event-123 (scope=all)" {
+		t.Errorf("series entry = %+v", entries[1])
+	}
+}
+
+func TestCalendarDeleteKeepsTokenWhenLookupFails(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	token, _, err := issueApprovalToken("a@example.com", "calendar.delete", time.Minute)
+	if err != nil {
+		t.Fatalf("issueApprovalToken: %v", err)
+	}
+
+	// With no stored credentials the event cannot be looked up, so the
+	// command fails before the delete and the token must stay usable.
+	cmd := &CalendarDeleteCmd{Account: "a@example.com", EventID: "event-123", ConfirmDelete: true, ApprovalToken: token}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{}) }); code == "approval_required" {
+		t.Fatalf("code = %q, want a lookup failure", code)
+	}
+	if err := consumeApprovalToken("a@example.com", "calendar.delete", token); err != nil {
+		t.Errorf("token was consumed by a failed lookup: %v", err)
+	}
+}
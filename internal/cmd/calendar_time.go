@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// localTimeLayouts are accepted for time input when a time zone flag is set.
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// loadTimeZone resolves an IANA time zone name; an empty name yields nil.
+func loadTimeZone(flag, name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q is not a valid IANA time zone (e.g. Asia/Tokyo): %v", flag, name, err)
+	}
+
+	return loc, nil
+}
+
+// resolveEventTime returns value as RFC3339. When loc is set, local times
+// without an offset (e.g. 2026-03-01T10:00:00) are interpreted in loc.
+func resolveEventTime(flag, value string, loc *time.Location) (string, error) {
+	if loc != nil {
+		for _, layout := range localTimeLayouts {
+			if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+				return t.Format(time.RFC3339), nil
+			}
+		}
+	}
+
+	if err := validateRFC3339(flag, value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// resolveEventTimeOptional is resolveEventTime for optional flags.
+func resolveEventTimeOptional(flag, value string, loc *time.Location) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	return resolveEventTime(flag, value, loc)
+}
+
+// timedEventDateTime builds a timed EventDateTime; an empty value yields nil.
+func timedEventDateTime(flag, value string, loc *time.Location) (*calendar.EventDateTime, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := resolveEventTime(flag, value, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	edt := &calendar.EventDateTime{DateTime: v}
+	if loc != nil {
+		edt.TimeZone = loc.String()
+	}
+
+	return edt, nil
+}
+
+// allDayEventDates builds start/end for an all-day event. endDate is
+// inclusive and defaults to date; the API end date is exclusive.
+func allDayEventDates(date, endDate string) (*calendar.EventDateTime, *calendar.EventDateTime, error) {
+	if date == "" {
+		return nil, nil, fmt.Errorf("--all-day requires --date (YYYY-MM-DD)")
+	}
+
+	start, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, nil, fmt.Errorf("--date %q is not a valid date (e.g. 2026-03-01): %v", date, err)
+	}
+
+	last := start
+	if endDate != "" {
+		last, err = time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("--end-date %q is not a valid date (e.g. 2026-03-03): %v", endDate, err)
+		}
+		if last.Before(start) {
+			return nil, nil, fmt.Errorf("--end-date %q must not be before --date %q", endDate, date)
+		}
+	}
+
+	return &calendar.EventDateTime{Date: start.Format("2006-01-02")},
+		&calendar.EventDateTime{Date: last.AddDate(0, 0, 1).Format("2006-01-02")},
+		nil
+}
+
+// eventTimeStringIn is eventTimeString with timed values converted to loc.
+// All-day dates are returned unchanged.
+func eventTimeStringIn(edt *calendar.EventDateTime, loc *time.Location) string {
+	s := eventTimeString(edt)
+	if loc == nil || edt == nil || edt.DateTime == "" {
+		return s
+	}
+
+	t, err := time.Parse(time.RFC3339, edt.DateTime)
+	if err != nil {
+		return s
+	}
+
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// eventTimes holds the start/end resolved from create/update flags.
+type eventTimes struct {
+	Start *calendar.EventDateTime
+	End   *calendar.EventDateTime
+}
+
+// resolveEventTimes turns the time flags shared by create and update into
+// EventDateTimes. Unset flags leave the corresponding field nil.
+func resolveEventTimes(start, end, date, endDate string, allDay bool, loc *time.Location) (eventTimes, error) {
+	if allDay {
+		if start != "" || end != "" {
+			return eventTimes{}, fmt.Errorf("--all-day uses --date/--end-date instead of --start/--end")
+		}
+
+		s, e, err := allDayEventDates(date, endDate)
+		if err != nil {
+			return eventTimes{}, err
+		}
+
+		return eventTimes{Start: s, End: e}, nil
+	}
+
+	if date != "" || endDate != "" {
+		return eventTimes{}, fmt.Errorf("--date/--end-date require --all-day")
+	}
+
+	s, err := timedEventDateTime("--start", start, loc)
+	if err != nil {
+		return eventTimes{}, err
+	}
+	e, err := timedEventDateTime("--end", end, loc)
+	if err != nil {
+		return eventTimes{}, err
+	}
+
+	return eventTimes{Start: s, End: e}, nil
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+func TestResolveEventTime_LocalTimeInZone(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	for in, want := range map[string]string{
+		"2026-03-01T10:00:00":       "2026-03-01T10:00:00+09:00",
+		"2026-03-01T10:00":          "2026-03-01T10:00:00+09:00",
+		"2026-03-01T10:00:00-05:00": "2026-03-01T10:00:00-05:00",
+	} {
+		got, err := resolveEventTime("--start", in, loc)
+		if err != nil {
+			t.Errorf("resolveEventTime(%q): unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("resolveEventTime(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// Without a zone, a local time is ambiguous and must be rejected.
+	if _, err := resolveEventTime("--start", "2026-03-01T10:00:00", nil); err == nil {
+		t.Error("expected error for local time without --timezone")
+	}
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	if loc, err := loadTimeZone("--timezone", ""); err != nil || loc != nil {
+		t.Errorf("empty name: got (%v, %v), want (nil, nil)", loc, err)
+	}
+	if _, err := loadTimeZone("--timezone", "Mars/Olympus"); err == nil {
+		t.Error("expected error for unknown zone")
+	}
+}
+
+func TestAllDayEventDates(t *testing.T) {
+	start, end, err := allDayEventDates("2026-03-01", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if start.Date != "2026-03-01" || end.Date != "2026-03-02" {
+		t.Errorf("single day: got %s..%s", start.Date, end.Date)
+	}
+
+	// --end-date is inclusive; the API end date is exclusive.
+	_, end, err = allDayEventDates("2026-02-27", "2026-03-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if end.Date != "2026-03-02" {
+		t.Errorf("multi day: end = %s, want 2026-03-02", end.Date)
+	}
+
+	for _, tc := range [][2]string{{"", ""}, {"2026/03/01", ""}, {"2026-03-02", "2026-03-01"}} {
+		if _, _, err := allDayEventDates(tc[0], tc[1]); err == nil {
+			t.Errorf("allDayEventDates(%q, %q): expected error", tc[0], tc[1])
+		}
+	}
+}
+
+func TestResolveEventTimes_RejectsMixedFlags(t *testing.T) {
+	if _, err := resolveEventTimes("2026-03-01T10:00:00Z", "", "2026-03-01", "", true, nil); err == nil {
+		t.Error("expected error for --start with --all-day")
+	}
+	if _, err := resolveEventTimes("", "", "2026-03-01", "", false, nil); err == nil {
+		t.Error("expected error for --date without --all-day")
+	}
+}
+
+func TestEventTimeStringIn(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+
+	timed := &calendar.EventDateTime{DateTime: "2026-03-01T01:00:00Z"}
+	if got := eventTimeStringIn(timed, loc); got != "2026-03-01T10:00:00+09:00" {
+		t.Errorf("timed: got %q", got)
+	}
+	if got := eventTimeStringIn(timed, nil); got != "2026-03-01T01:00:00Z" {
+		t.Errorf("no zone: got %q", got)
+	}
+
+	allDay := &calendar.EventDateTime{Date: "2026-03-01"}
+	if got := eventTimeStringIn(allDay, loc); got != "2026-03-01" {
+		t.Errorf("all-day: got %q", got)
+	}
+}
+
+func TestCalendarCreateCmd_AllDayDryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"calendar.create"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	cmd := &CalendarCreateCmd{
+		Account:    "a@example.com",
+		CalendarID: "primary",
+		Title:      "Offsite",
+		AllDay:     true,
+		Date:       "2026-03-02",
+		EndDate:    "2026-03-03",
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	var resp struct {
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if resp.Params["start"] != "2026-03-02" || resp.Params["end"] != "2026-03-04" {
+		t.Errorf("unexpected dates: start=%v end=%v", resp.Params["start"], resp.Params["end"])
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// writeConflictError reports that data changed since the caller read it.
+func writeConflictError(msg string) error {
+	return output.WriteError(output.ExitCodeConflict, "conflict", msg)
+}
+
+// isRevisionConflict reports whether err is the rejection of a Docs or
+// Slides batch update whose WriteControl.RequiredRevisionId is stale. The
+// API answers 400 with status FAILED_PRECONDITION; other 400s that mention
+// the revision, such as a malformed ID, are not conflicts.
+func isRevisionConflict(err error) bool {
+	var apiErr *gapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "failedPrecondition" {
+			return true
+		}
+	}
+	var body struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	if json.Unmarshal([]byte(apiErr.Body), &body) == nil && body.Error.Status == "FAILED_PRECONDITION" {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(apiErr.Message), "does not match the latest revision")
+}
+
+// normalizeValues returns values as display strings without trailing empty
+// cells and rows, so that equal content compares equal however it was
+// written or returned.
+func normalizeValues(values [][]any) [][]string {
+	rows := make([][]string, 0, len(values))
+	for _, row := range values {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = cellString(v)
+		}
+		for len(cells) > 0 && cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+		rows = append(rows, cells)
+	}
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+
+	return rows
+}
+
+// contentHash returns a token for values read with the given render
+// options. The options are part of the token so that a later check reads
+// the range the same way before comparing.
+func contentHash(values [][]any, valueRender, dateTimeRender string) string {
+	if valueRender == "" {
+		valueRender = "FORMATTED_VALUE"
+	}
+	if dateTimeRender == "" {
+		dateTimeRender = "SERIAL_NUMBER"
+	}
+
+	b, _ := json.Marshal(normalizeValues(values))
+	sum := sha256.Sum256(b)
+
+	return valueRender + ":" + dateTimeRender + ":" + hex.EncodeToString(sum[:])
+}
+
+// parseContentHash returns the render options recorded in a contentHash
+// token.
+func parseContentHash(token string) (valueRender, dateTimeRender string, err error) {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 || len(parts[2]) != sha256.Size*2 {
+		return "", "", fmt.Errorf("invalid --expect-hash %q; use the hash returned by sheets get", token)
+	}
+	if !slices.Contains([]string{"FORMATTED_VALUE", "UNFORMATTED_VALUE", "FORMULA"}, parts[0]) ||
+		!slices.Contains([]string{"SERIAL_NUMBER", "FORMATTED_STRING"}, parts[1]) {
+		return "", "", fmt.Errorf("invalid --expect-hash %q; use the hash returned by sheets get", token)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// firstDifference returns the 1-based row offset of the first row that
+// differs between a and b, or 0 when they are equal.
+func firstDifference(a, b [][]string) int {
+	for i := range max(len(a), len(b)) {
+		if i >= len(a) || i >= len(b) || !slices.Equal(a[i], b[i]) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// sheetsExpectation is what a range must still hold for an update to go
+// ahead: either the displayed values or a contentHash token.
+type sheetsExpectation struct {
+	values         [][]string
+	hash           string
+	valueRender    string
+	dateTimeRender string
+}
+
+// parseSheetsExpectation reads --expect-values and --expect-hash. It returns
+// nil when neither is set.
+func parseSheetsExpectation(valuesJSON, hash string) (*sheetsExpectation, string, error) {
+	switch {
+	case valuesJSON != "" && hash != "":
+		return nil, "invalid_arguments", fmt.Errorf("--expect-values and --expect-hash cannot be combined")
+	case hash != "":
+		valueRender, dateTimeRender, err := parseContentHash(hash)
+		if err != nil {
+			return nil, "invalid_arguments", err
+		}
+		return &sheetsExpectation{hash: hash, valueRender: valueRender, dateTimeRender: dateTimeRender}, "", nil
+	case valuesJSON != "":
+		var values [][]any
+		if err := json.Unmarshal([]byte(valuesJSON), &values); err != nil {
+			return nil, "invalid_values", fmt.Errorf("parse --expect-values JSON: %w", err)
+		}
+		return &sheetsExpectation{values: normalizeValues(values)}, "", nil
+	}
+
+	return nil, "", nil
+}
+
+// kind names the check for dry-run output.
+func (e *sheetsExpectation) kind() string {
+	switch {
+	case e == nil:
+		return "none"
+	case e.hash != "":
+		return "hash"
+	}
+
+	return "values"
+}
+
+// check reads rangeA1 and fails with a conflict when it no longer matches.
+// The read and the following write are separate requests, so this narrows
+// the window for lost updates rather than closing it.
+func (e *sheetsExpectation) check(svc *sheets.Service, spreadsheetID, rangeA1 string) error {
+	call := svc.Spreadsheets.Values.Get(spreadsheetID, rangeA1)
+	if e.valueRender != "" {
+		call = call.ValueRenderOption(e.valueRender).DateTimeRenderOption(e.dateTimeRender)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_update_error", err)
+	}
+
+	if e.hash != "" {
+		if got := contentHash(resp.Values, e.valueRender, e.dateTimeRender); got != e.hash {
+			return writeConflictError(fmt.Sprintf("%s changed since it was read: hash is %s, expected %s", rangeA1, got, e.hash))
+		}
+		return nil
+	}
+	if row := firstDifference(normalizeValues(resp.Values), e.values); row > 0 {
+		return writeConflictError(fmt.Sprintf("%s no longer matches --expect-values (first difference in row %d of the range)", rangeA1, row))
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestContentHash(t *testing.T) {
+	a := contentHash([][]any{{"Alice", "30"}, {"Bob", ""}}, "", "")
+	// Trailing empty cells and rows do not change the hash, and numbers
+	// hash like their display string.
+	b := contentHash([][]any{{"Alice", float64(30)}, {"Bob"}, {}}, "FORMATTED_VALUE", "SERIAL_NUMBER")
+	if a != b {
+		t.Errorf("equal content hashed differently: %s vs %s", a, b)
+	}
+	if !strings.HasPrefix(a, "FORMATTED_VALUE:SERIAL_NUMBER:") {
+		t.Errorf("hash = %s", a)
+	}
+	if c := contentHash([][]any{{"Alice", "31"}}, "", ""); c == a {
+		t.Error("different content hashed equally")
+	}
+
+	vr, dr, err := parseContentHash(contentHash(nil, "FORMULA", ""))
+	if err != nil || vr != "FORMULA" || dr != "SERIAL_NUMBER" {
+		t.Errorf("parseContentHash: %q %q %v", vr, dr, err)
+	}
+	for _, bad := range []string{"abc", "FORMULA:SERIAL_NUMBER:abc", "RAW:SERIAL_NUMBER:" + strings.Repeat("0", 64)} {
+		if _, _, err := parseContentHash(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseSheetsExpectation(t *testing.T) {
+	if e, _, err := parseSheetsExpectation("", ""); e != nil || err != nil || e.kind() != "none" {
+		t.Errorf("no expectation: %+v, %v", e, err)
+	}
+	if _, code, err := parseSheetsExpectation(`[["x"]]`, contentHash(nil, "", "")); err == nil || code != "invalid_arguments" {
+		t.Errorf("both flags: code=%q err=%v", code, err)
+	}
+	if _, code, err := parseSheetsExpectation(`[["x"`, ""); err == nil || code != "invalid_values" {
+		t.Errorf("bad JSON: code=%q err=%v", code, err)
+	}
+
+	e, _, err := parseSheetsExpectation(`[["Alice",30],["Bob",null]]`, "")
+	if err != nil || e.kind() != "values" {
+		t.Fatalf("values: %+v, %v", e, err)
+	}
+	current := normalizeValues([][]any{{"Alice", "30"}, {"Bob"}})
+	if row := firstDifference(current, e.values); row != 0 {
+		t.Errorf("equal values differ at row %d", row)
+	}
+	changed := normalizeValues([][]any{{"Alice", "30"}, {"Bob", "done"}})
+	if row := firstDifference(changed, e.values); row != 2 {
+		t.Errorf("first difference = %d, want 2", row)
+	}
+}
+
+func TestIsRevisionConflict(t *testing.T) {
+	stale := &gapi.Error{Code: http.StatusBadRequest, Message: "The required revision ID 'abc' does not match the latest revision."}
+	if !isRevisionConflict(stale) {
+		t.Error("stale revision not detected")
+	}
+	if isRevisionConflict(&gapi.Error{Code: http.StatusBadRequest, Message: "Invalid requests[0].insertText"}) {
+		t.Error("unrelated bad request treated as conflict")
+	}
+	if isRevisionConflict(errors.New("revision")) {
+		t.Error("non-API error treated as conflict")
+	}
+
+	byReason := &gapi.Error{Code: http.StatusBadRequest, Message: "Precondition check failed.",
+		Errors: []gapi.ErrorItem{{Reason: "failedPrecondition"}}}
+	if !isRevisionConflict(byReason) {
+		t.Error("failedPrecondition reason not detected")
+	}
+	byStatus := &gapi.Error{Code: http.StatusBadRequest, Message: "Precondition check failed.",
+		Body: `{"error":{"code":400,"message":"Precondition check failed.","status":"FAILED_PRECONDITION"}}`}
+	if !isRevisionConflict(byStatus) {
+		t.Error("FAILED_PRECONDITION status not detected")
+	}
+
+	// A malformed revision ID is a plain bad request even though it
+	// mentions the revision.
+	malformed := &gapi.Error{Code: http.StatusBadRequest, Message: "Invalid value for requiredRevisionId: revision 'xyz' is malformed.",
+		Errors: []gapi.ErrorItem{{Reason: "badRequest"}},
+		Body:   `{"error":{"code":400,"message":"Invalid value for requiredRevisionId","status":"INVALID_ARGUMENT"}}`}
+	if isRevisionConflict(malformed) {
+		t.Error("malformed revision ID treated as conflict")
+	}
+}
+
+func TestSheetsUpdateCmd_ExpectFlags(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SheetsUpdateCmd{
+		Account:       "a@example.com",
+		SpreadsheetID: "ss-1",
+		Range:         "A1:B1",
+		Values:        `[["Alice",31]]`,
+		ExpectHash:    "stale",
+	}
+	code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{DryRun: true}) })
+	if code != "invalid_arguments" {
+		t.Errorf("malformed hash: code = %q", code)
+	}
+}
+
+func TestDocsWriteCmd_CheckRevision(t *testing.T) {
+	cmd := &DocsWriteCmd{DocID: "doc-1", ExpectRevision: "rev-1"}
+	if err := cmd.checkRevision(&docs.Document{RevisionId: "rev-1"}); err != nil {
+		t.Errorf("matching revision: %v", err)
+	}
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = cmd.checkRevision(&docs.Document{RevisionId: "rev-2"})
+	})
+	if output.ExitCode(err) != output.ExitCodeConflict || !strings.Contains(stderr, `"conflict"`) {
+		t.Errorf("stale revision: exit=%d stderr=%s", output.ExitCode(err), stderr)
+	}
+}
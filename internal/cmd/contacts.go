@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// contactFields are the person fields read for contacts output.
+const contactFields = "names,emailAddresses,phoneNumbers,organizations"
+
+// ContactsCmd groups Google Contacts subcommands.
+type ContactsCmd struct {
+	Search ContactsSearchCmd `cmd:"" help:"Search contacts by name, email or phone."`
+	Get    ContactsGetCmd    `cmd:"" help:"Get a contact by resource name."`
+}
+
+type contactEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// contactInfo is a contact in command output.
+type contactInfo struct {
+	ResourceName string         `json:"resource_name"`
+	Name         string         `json:"name"`
+	Emails       []contactEmail `json:"emails"`
+	Phones       []string       `json:"phones,omitempty"`
+	Organization string         `json:"organization,omitempty"`
+}
+
+func toContactInfo(p *people.Person) contactInfo {
+	info := contactInfo{ResourceName: p.ResourceName, Emails: []contactEmail{}}
+	for _, n := range p.Names {
+		if info.Name == "" || (n.Metadata != nil && n.Metadata.Primary) {
+			info.Name = n.DisplayName
+		}
+	}
+	for _, e := range p.EmailAddresses {
+		info.Emails = append(info.Emails, contactEmail{
+			Value:   e.Value,
+			Type:    e.Type,
+			Primary: e.Metadata != nil && e.Metadata.Primary,
+		})
+	}
+	for _, ph := range p.PhoneNumbers {
+		info.Phones = append(info.Phones, ph.Value)
+	}
+	if len(p.Organizations) > 0 {
+		info.Organization = p.Organizations[0].Name
+	}
+
+	return info
+}
+
+// contactSearcher returns the contacts matching query.
+type contactSearcher func(query string) ([]contactInfo, error)
+
+// newContactSearcher searches the account's contacts. The People API serves
+// searches from a cache that an empty query refreshes, so the first search
+// sends one.
+func newContactSearcher(ctx context.Context, svc *people.Service, max int64) contactSearcher {
+	warmed := false
+	return func(query string) ([]contactInfo, error) {
+		if !warmed {
+			if _, err := svc.People.SearchContacts().Query("").ReadMask(contactFields).Context(ctx).Do(); err != nil {
+				return nil, err
+			}
+			warmed = true
+		}
+
+		resp, err := svc.People.SearchContacts().Query(query).ReadMask(contactFields).PageSize(max).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+
+		contacts := make([]contactInfo, 0, len(resp.Results))
+		for _, r := range resp.Results {
+			if r.Person != nil {
+				contacts = append(contacts, toContactInfo(r.Person))
+			}
+		}
+
+		return contacts, nil
+	}
+}
+
+// ContactsSearchCmd searches contacts.
+type ContactsSearchCmd struct {
+	Account string `name:"account" required:"" short:"a" help:"Google account email."`
+	Query   string `name:"query" required:"" short:"q" help:"Prefix of a name, email address or phone number."`
+	Max     int64  `name:"max" default:"10" help:"Maximum results (up to 30)."`
+}
+
+func (c *ContactsSearchCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "contacts.search"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if strings.TrimSpace(c.Query) == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--query must not be empty")
+	}
+	if c.Max < 1 || c.Max > 30 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--max must be between 1 and 30")
+	}
+
+	svc, err := googleapi.NewContactsReadOnly(ctx, c.Account)
+	if err != nil {
+		return contactsAuthError(err)
+	}
+
+	contacts, err := newContactSearcher(ctx, svc, c.Max)(c.Query)
+	if err != nil {
+		return writeGoogleAPIError("contacts_search_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"query":    c.Query,
+		"contacts": contacts,
+	})
+}
+
+// ContactsGetCmd gets a contact.
+type ContactsGetCmd struct {
+	Account      string `name:"account" required:"" short:"a" help:"Google account email."`
+	ResourceName string `name:"resource-name" required:"" help:"Contact resource name from contacts search (e.g. people/c123)."`
+}
+
+func (c *ContactsGetCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "contacts.get"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if !strings.HasPrefix(c.ResourceName, "people/") {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--resource-name must start with people/")
+	}
+
+	svc, err := googleapi.NewContactsReadOnly(ctx, c.Account)
+	if err != nil {
+		return contactsAuthError(err)
+	}
+
+	person, err := svc.People.Get(c.ResourceName).PersonFields(contactFields).Do()
+	if err != nil {
+		return writeGoogleAPIError("contacts_get_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, toContactInfo(person))
+}
+
+// resolvedRecipient records how a name given as a recipient was resolved.
+type resolvedRecipient struct {
+	Query        string `json:"query"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	ResourceName string `json:"resource_name"`
+}
+
+// needsResolution reports whether a comma-separated recipient list has
+// entries that are names rather than addresses.
+func needsResolution(list string) bool {
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" && !strings.Contains(entry, "@") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveRecipientList replaces each entry of a comma-separated recipient
+// list that has no "@" with the single contact address it names. It fails
+// rather than guess when a name matches no address or several.
+func resolveRecipientList(list string, search contactSearcher) (string, []resolvedRecipient, string, error) {
+	var entries []string
+	var resolved []resolvedRecipient
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || strings.Contains(entry, "@") {
+			entries = append(entries, entry)
+			continue
+		}
+
+		contacts, err := search(entry)
+		if err != nil {
+			return "", nil, "contacts_search_error", err
+		}
+		r, code, err := pickRecipient(entry, contacts)
+		if err != nil {
+			return "", nil, code, err
+		}
+		entries = append(entries, r.Email)
+		resolved = append(resolved, r)
+	}
+
+	return strings.Join(entries, ", "), resolved, "", nil
+}
+
+// pickRecipient chooses the address for name among search results. A
+// contact whose full name equals name wins over prefix matches; a contact
+// with several addresses needs one marked primary.
+func pickRecipient(name string, contacts []contactInfo) (resolvedRecipient, string, error) {
+	var withEmail []contactInfo
+	for _, c := range contacts {
+		if len(c.Emails) > 0 {
+			withEmail = append(withEmail, c)
+		}
+	}
+	if len(withEmail) == 0 {
+		return resolvedRecipient{}, "recipient_not_found", fmt.Errorf("no contact with an email address matches %q", name)
+	}
+
+	if len(withEmail) > 1 {
+		var exact []contactInfo
+		for _, c := range withEmail {
+			if strings.EqualFold(strings.Join(strings.Fields(c.Name), " "), strings.Join(strings.Fields(name), " ")) {
+				exact = append(exact, c)
+			}
+		}
+		if len(exact) != 1 {
+			return resolvedRecipient{}, "ambiguous_recipient", fmt.Errorf("%q matches several contacts: %s; use an email address", name, describeCandidates(withEmail))
+		}
+		withEmail = exact
+	}
+
+	c := withEmail[0]
+	email := c.Emails[0].Value
+	if len(c.Emails) > 1 {
+		email = ""
+		for _, e := range c.Emails {
+			if e.Primary {
+				email = e.Value
+				break
+			}
+		}
+		if email == "" {
+			return resolvedRecipient{}, "ambiguous_recipient", fmt.Errorf("%q has several email addresses: %s; use one of them", name, describeCandidates(withEmail))
+		}
+	}
+
+	return resolvedRecipient{Query: name, Name: c.Name, Email: email, ResourceName: c.ResourceName}, "", nil
+}
+
+func describeCandidates(contacts []contactInfo) string {
+	var parts []string
+	for _, c := range contacts {
+		for _, e := range c.Emails {
+			parts = append(parts, fmt.Sprintf("%s <%s>", c.Name, e.Value))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func contactsAuthError(err error) error {
+	var authErr *googleapi.AuthRequiredError
+	if isAuthErr(err, &authErr) {
+		return output.WriteError(output.ExitCodeAuth, "auth_required", err.Error())
+	}
+
+	return output.WriteError(output.ExitCodeError, "contacts_error", err.Error())
+}
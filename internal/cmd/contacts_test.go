@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestToContactInfo(t *testing.T) {
+	info := toContactInfo(&people.Person{
+		ResourceName: "people/c1",
+		Names: []*people.Name{
+			{DisplayName: "T. Tanaka"},
+			{DisplayName: "Taro Tanaka", Metadata: &people.FieldMetadata{Primary: true}},
+		},
+		EmailAddresses: []*people.EmailAddress{
+			{Value: "taro@example.com", Type: "work", Metadata: &people.FieldMetadata{Primary: true}},
+			{Value: "taro@home.example"},
+		},
+		Organizations: []*people.Organization{{Name: "Example Inc."}},
+	})
+	if info.Name != "Taro Tanaka" || len(info.Emails) != 2 || !info.Emails[0].Primary || info.Emails[1].Primary ||
+		info.Organization != "Example Inc." {
+		t.Errorf("toContactInfo = %+v", info)
+	}
+}
+
+func TestResolveRecipientList(t *testing.T) {
+	book := map[string][]contactInfo{
+		"Tanaka": {
+			{ResourceName: "people/c1", Name: "Taro Tanaka", Emails: []contactEmail{{Value: "taro@example.com"}}},
+			{ResourceName: "people/c2", Name: "Hanako Tanaka", Emails: []contactEmail{{Value: "hanako@example.com"}}},
+		},
+		"taro tanaka": {
+			{ResourceName: "people/c1", Name: "Taro Tanaka", Emails: []contactEmail{{Value: "taro@example.com"}}},
+			{ResourceName: "people/c3", Name: "Taro Tanakayama", Emails: []contactEmail{{Value: "tt@example.com"}}},
+		},
+		"Sato": {
+			{ResourceName: "people/c4", Name: "Jiro Sato", Emails: []contactEmail{
+				{Value: "jiro@example.com"}, {Value: "jiro@home.example", Primary: true},
+			}},
+		},
+		"Suzuki": {
+			{ResourceName: "people/c5", Name: "Ichiro Suzuki", Emails: []contactEmail{
+				{Value: "ichiro@example.com"}, {Value: "ichiro@home.example"},
+			}},
+		},
+		"Ito": {{ResourceName: "people/c6", Name: "Ito", Emails: []contactEmail{}}},
+	}
+	search := func(q string) ([]contactInfo, error) {
+		if q == "Broken" {
+			return nil, errors.New("boom")
+		}
+		return book[q], nil
+	}
+
+	tests := []struct {
+		list string
+		want string
+		code string
+	}{
+		{"", "", ""},
+		{"bob@example.com", "bob@example.com", ""},
+		{"taro tanaka, bob@example.com", "taro@example.com, bob@example.com", ""},
+		{"Sato", "jiro@home.example", ""},
+		{"Tanaka", "", "ambiguous_recipient"},
+		{"Suzuki", "", "ambiguous_recipient"},
+		{"Ito", "", "recipient_not_found"},
+		{"Nobody", "", "recipient_not_found"},
+		{"Broken", "", "contacts_search_error"},
+	}
+	for _, tt := range tests {
+		got, resolved, code, err := resolveRecipientList(tt.list, search)
+		if code != tt.code {
+			t.Errorf("resolveRecipientList(%q) code = %q (err %v), want %q", tt.list, code, err, tt.code)
+			continue
+		}
+		if tt.code == "" && got != tt.want {
+			t.Errorf("resolveRecipientList(%q) = %q, want %q", tt.list, got, tt.want)
+		}
+		if tt.list == "taro tanaka, bob@example.com" && (len(resolved) != 1 || resolved[0].ResourceName != "people/c1") {
+			t.Errorf("resolved = %+v, want only taro tanaka", resolved)
+		}
+	}
+
+	_, _, _, err := resolveRecipientList("Tanaka", search)
+	if err == nil || !strings.Contains(err.Error(), "hanako@example.com") {
+		t.Errorf("ambiguous error = %v, want candidates listed", err)
+	}
+}
+
+func TestContactsSearchCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	for _, cmd := range []*ContactsSearchCmd{
+		{Account: "a@example.com", Query: " ", Max: 10},
+		{Account: "a@example.com", Query: "Tanaka", Max: 31},
+	} {
+		code, _ := runForCode(t, func() error {
+			return cmd.Run(context.Background(), &RootFlags{})
+		})
+		if code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q, want invalid_arguments", cmd, code)
+		}
+	}
+}
+
+func TestGmailSendCmd_ResolveRecipientsPolicyDenied(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"gmail.draft"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	code, exit := runForCode(t, func() error {
+		return (&GmailSendCmd{Account: "a@example.com", To: "Tanaka", Subject: "hi", ResolveRecipients: true}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "policy_denied" || exit != output.ExitCodePermission {
+		t.Errorf("got %s (exit %d), want policy_denied for contacts.search", code, exit)
+	}
+}
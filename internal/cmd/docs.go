@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -20,9 +22,13 @@ type DocsCmd struct {
 	Info        DocsInfoCmd        `cmd:"" help:"Get document metadata."`
 	Cat         DocsCatCmd         `cmd:"" help:"Print document text content."`
 	Create      DocsCreateCmd      `cmd:"" help:"Create a new document."`
-	Export      DocsExportCmd      `cmd:"" help:"Export a document to PDF/DOCX/TXT."`
+	Export      DocsExportCmd      `cmd:"" help:"Export a document to PDF, DOCX, Markdown and other formats."`
 	Write       DocsWriteCmd       `cmd:"" help:"Write content to a document."`
 	FindReplace DocsFindReplaceCmd `cmd:"" help:"Find and replace text in a document."`
+	Comments    DocsCommentsCmd    `cmd:"" help:"List, add, reply to and resolve comments."`
+	Revisions   DocsRevisionsCmd   `cmd:"" help:"List and export document revisions."`
+	Restore     DocsRestoreCmd     `cmd:"" help:"Restore a document to an earlier revision."`
+	Render      DocsRenderCmd      `cmd:"" help:"Copy a template document and fill {{key}} placeholders."`
 }
 
 // DocsInfoCmd gets document metadata.
@@ -56,9 +62,12 @@ func (c *DocsInfoCmd) Run(ctx context.Context, _ *RootFlags) error {
 
 // DocsCatCmd prints document text content.
 type DocsCatCmd struct {
-	Account  string `name:"account" required:"" short:"a" help:"Google account email."`
-	DocID    string `name:"doc-id" required:"" help:"Google Docs document ID."`
-	MaxBytes int    `name:"max-bytes" default:"2000000" help:"Maximum bytes to return."`
+	Account     string   `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID       string   `name:"doc-id" required:"" help:"Google Docs document ID."`
+	MaxBytes    int      `name:"max-bytes" default:"2000000" help:"Maximum bytes to return."`
+	Format      string   `name:"format" default:"text" help:"Content format: text or markdown."`
+	Include     []string `name:"include" help:"Extra parts to include, comma-separated: headers, footers, footnotes, tabs."`
+	Suggestions bool     `name:"suggestions" help:"Mark pending suggested insertions and deletions instead of merging them into the text."`
 }
 
 func (c *DocsCatCmd) Run(ctx context.Context, _ *RootFlags) error {
@@ -70,24 +79,95 @@ func (c *DocsCatCmd) Run(ctx context.Context, _ *RootFlags) error {
 		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
 	}
 
+	format := strings.ToLower(c.Format)
+	if format != "text" && format != "markdown" {
+		return output.WriteError(output.ExitCodeError, "invalid_format",
+			fmt.Sprintf("unsupported format %q; use text or markdown", c.Format))
+	}
+
+	include, err := parseDocsInclude(c.Include)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_include", err.Error())
+	}
+
 	svc, err := googleapi.NewDocsReadOnly(ctx, c.Account)
 	if err != nil {
 		return docsAuthError(err)
 	}
 
-	doc, err := svc.Documents.Get(c.DocID).Do()
+	call := svc.Documents.Get(c.DocID)
+	if include["tabs"] {
+		call = call.IncludeTabsContent(true)
+	}
+	if c.Suggestions {
+		call = call.SuggestionsViewMode("SUGGESTIONS_INLINE")
+	}
+
+	doc, err := call.Do()
 	if err != nil {
 		return writeGoogleAPIError("docs_cat_error", err)
 	}
 
-	text, truncated := truncateText(docsPlainText(doc), c.MaxBytes)
+	r := docsContentRenderer{format: format, suggestions: c.Suggestions}
+	budget := newTextBudget(c.MaxBytes)
 
-	return output.WriteJSON(os.Stdout, map[string]any{
-		"id":        doc.DocumentId,
-		"title":     doc.Title,
-		"content":   text,
-		"truncated": truncated,
-	})
+	result := map[string]any{
+		"id":     doc.DocumentId,
+		"title":  doc.Title,
+		"format": format,
+	}
+	if include["tabs"] {
+		// With tab content requested, the body of every tab is returned under
+		// tabs and the document-level body is empty.
+		tabs := []map[string]any{}
+		for _, t := range flattenDocsTabs(doc.Tabs) {
+			if t.DocumentTab == nil {
+				continue
+			}
+			tabs = append(tabs, r.tabFields(t, include, budget))
+		}
+		result["tabs"] = tabs
+	} else {
+		for k, v := range r.partFields(docsPartOf(doc), include, budget) {
+			result[k] = v
+		}
+	}
+	result["truncated"] = budget.truncated
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// parseDocsContentFormat validates --format for commands that write content.
+func parseDocsContentFormat(v string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(v))
+	switch format {
+	case "", "text":
+		return "text", nil
+	case "markdown":
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unsupported format %q; use text or markdown", v)
+}
+
+// docsContentRequests returns the requests that insert content at index at.
+// When trailingNewline is false, content fills the paragraph at at instead of
+// ending in a paragraph break of its own.
+func docsContentRequests(content, format string, at int64, trailingNewline bool) []*docs.Request {
+	if format == "markdown" {
+		return markdownDocsRequests(content, at, trailingNewline)
+	}
+
+	if !trailingNewline {
+		content = strings.TrimRight(content, "\n")
+	}
+
+	return []*docs.Request{{
+		InsertText: &docs.InsertTextRequest{
+			Text:     content,
+			Location: &docs.Location{Index: at},
+		},
+	}}
 }
 
 func truncateText(text string, maxBytes int) (string, bool) {
@@ -104,6 +184,7 @@ type DocsCreateCmd struct {
 	Title        string `name:"title" required:"" help:"Document title."`
 	Content      string `name:"content" help:"Initial document content."`
 	ContentStdin bool   `name:"content-stdin" help:"Read initial content from stdin."`
+	Format       string `name:"format" default:"text" help:"Content format: text or markdown."`
 }
 
 func (c *DocsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -111,6 +192,11 @@ func (c *DocsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	format, err := parseDocsContentFormat(c.Format)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_format", err.Error())
+	}
+
 	content := c.Content
 
 	if c.ContentStdin {
@@ -139,6 +225,8 @@ func (c *DocsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 				"account":        c.Account,
 				"title":          c.Title,
 				"content_length": len(content),
+				"format":         format,
+				"requests":       len(docsContentRequests(content, format, 1, true)),
 			},
 		})
 	}
@@ -158,14 +246,7 @@ func (c *DocsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 	// If initial content provided, insert it.
 	if strings.TrimSpace(content) != "" {
 		req := &docs.BatchUpdateDocumentRequest{
-			Requests: []*docs.Request{
-				{
-					InsertText: &docs.InsertTextRequest{
-						Text:     content,
-						Location: &docs.Location{Index: 1},
-					},
-				},
-			},
+			Requests: docsContentRequests(content, format, 1, true),
 		}
 
 		if _, err := docSvc.Documents.BatchUpdate(created.DocumentId, req).Do(); err != nil {
@@ -188,94 +269,36 @@ func (c *DocsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
 	})
 }
 
-// DocsExportCmd exports a document to PDF, DOCX, TXT, ODT, or HTML.
+// DocsExportCmd exports a document with Drive in one of exportMIMETypes.
 type DocsExportCmd struct {
 	Account   string `name:"account" required:"" short:"a" help:"Google account email."`
 	DocID     string `name:"doc-id" required:"" help:"Google Docs document ID."`
-	Format    string `name:"format" required:"" help:"Export format: pdf, docx, txt, odt, html."`
-	Output    string `name:"output" required:"" help:"Output file path."`
+	Format    string `name:"format" required:"" help:"Export format: pdf, docx, txt, odt, html, epub, rtf, markdown, zip (HTML)."`
+	Output    string `name:"output" required:"" help:"Output file path, or - to stream to stdout."`
 	Overwrite bool   `name:"overwrite" help:"Allow overwriting an existing output file (default: disabled)."`
+	MaxBytes  int64  `name:"max-bytes" help:"Abort when the export is larger than this many bytes (0: no limit)."`
 }
 
 var exportMIMETypes = map[string]string{
-	"pdf":  "application/pdf",
-	"docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
-	"txt":  "text/plain",
-	"odt":  "application/vnd.oasis.opendocument.text",
-	"html": "text/html",
+	"pdf":      "application/pdf",
+	"docx":     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"txt":      "text/plain",
+	"odt":      "application/vnd.oasis.opendocument.text",
+	"html":     "text/html",
+	"epub":     "application/epub+zip",
+	"rtf":      "application/rtf",
+	"markdown": "text/markdown",
+	"zip":      "application/zip",
 }
 
 func (c *DocsExportCmd) Run(ctx context.Context, root *RootFlags) error {
-	if err := enforceActionPolicy(c.Account, "docs.export"); err != nil {
-		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
-	}
-	if err := ensureWithinAllowedOutputDir(c.Output, root.AllowedOutputDir); err != nil {
-		return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
-	}
-
-	mimeType, ok := exportMIMETypes[strings.ToLower(c.Format)]
-	if !ok {
-		return output.WriteError(output.ExitCodeError, "invalid_format",
-			fmt.Sprintf("unsupported format %q; use pdf, docx, txt, odt, or html", c.Format))
-	}
-
-	dryRun := root.DryRun
-	if err := ensureWithinAllowedOutputDir(c.Output, root.AllowedOutputDir); err != nil {
-		return output.WriteError(output.ExitCodeError, "output_not_allowed", err.Error())
-	}
-	if dryRun {
-		if err := appendAuditLog(root.AuditLog, auditEntry{
-			Action:  "docs.export",
-			Account: normalizeEmail(c.Account),
-			Target:  c.Output,
-			DryRun:  true,
-		}); err != nil {
-			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
-		}
-		return output.WriteJSON(os.Stdout, map[string]any{
-			"dry_run": true,
-			"action":  "docs.export",
-			"params": map[string]any{
-				"account":   c.Account,
-				"doc_id":    c.DocID,
-				"format":    c.Format,
-				"output":    c.Output,
-				"overwrite": c.Overwrite,
-			},
-		})
-	}
-
-	driveSvc, err := googleapi.NewDriveReadOnly(ctx, c.Account)
-	if err != nil {
-		return docsAuthError(err)
-	}
-
-	resp, err := driveSvc.Files.Export(c.DocID, mimeType).Download()
-	if err != nil {
-		return writeGoogleAPIError("docs_export_error", err)
-	}
-
-	defer resp.Body.Close()
-
-	written, err := writeFileAtomically(c.Output, resp.Body, c.Overwrite)
-	if err != nil {
-		return output.WriteError(output.ExitCodeError, "file_write_error", fmt.Sprintf("write output file: %v", err))
-	}
-	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "docs.export",
-		Account: normalizeEmail(c.Account),
-		Target:  c.Output,
-		DryRun:  false,
-	}); err != nil {
-		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
-	}
-
-	return output.WriteJSON(os.Stdout, map[string]any{
-		"exported":      true,
-		"doc_id":        c.DocID,
-		"format":        c.Format,
-		"output":        c.Output,
-		"bytes_written": written,
+	return docsFiles.export(ctx, root, exportRequest{
+		account:   c.Account,
+		fileID:    c.DocID,
+		format:    c.Format,
+		output:    c.Output,
+		overwrite: c.Overwrite,
+		maxBytes:  c.MaxBytes,
 	})
 }
 
@@ -285,9 +308,40 @@ type DocsWriteCmd struct {
 	DocID          string `name:"doc-id" required:"" help:"Google Docs document ID."`
 	Content        string `name:"content" help:"Content to write."`
 	ContentStdin   bool   `name:"content-stdin" help:"Read content from stdin."`
+	Format         string `name:"format" default:"text" help:"Content format: text or markdown."`
 	Replace        bool   `name:"replace" help:"Replace all existing content."`
-	ConfirmReplace bool   `name:"confirm-replace" help:"Required confirmation flag when using --replace."`
+	Append         bool   `name:"append" help:"Append content at the end of the document."`
+	AfterHeading   string `name:"after-heading" help:"Insert content directly below this heading."`
+	ReplaceSection string `name:"replace-section" help:"Replace the content under this heading (up to the next heading of the same level)."`
+	ConfirmReplace bool   `name:"confirm-replace" help:"Required confirmation flag when using --replace or --replace-section."`
 	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+	ExpectRevision string `name:"expect-revision-id" help:"Revision ID (from docs info) the document must still be at; refuse with a conflict otherwise."`
+}
+
+// mode returns the write mode selected by flags, or an error when several are set.
+func (c *DocsWriteCmd) mode() (string, error) {
+	var modes []string
+	if c.Replace {
+		modes = append(modes, "replace")
+	}
+	if c.Append {
+		modes = append(modes, "append")
+	}
+	if c.AfterHeading != "" {
+		modes = append(modes, "after_heading")
+	}
+	if c.ReplaceSection != "" {
+		modes = append(modes, "replace_section")
+	}
+
+	switch len(modes) {
+	case 0:
+		return "prepend", nil
+	case 1:
+		return modes[0], nil
+	}
+
+	return "", fmt.Errorf("--replace, --append, --after-heading and --replace-section are mutually exclusive")
 }
 
 func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -295,6 +349,11 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	format, err := parseDocsContentFormat(c.Format)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_format", err.Error())
+	}
+
 	content := c.Content
 
 	if c.ContentStdin {
@@ -306,18 +365,35 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		content = s
 	}
 
+	mode, err := c.mode()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_write_mode", err.Error())
+	}
+
 	dryRun := root.DryRun
 	if c.Replace && !c.ConfirmReplace {
 		return output.WriteError(output.ExitCodeError, "replace_requires_confirmation",
 			"--replace requires --confirm-replace to reduce destructive mistakes")
 	}
-	if !dryRun && c.Replace {
-		required, err := actionRequiresApproval("docs.write.replace")
+	if c.ReplaceSection != "" && !c.ConfirmReplace {
+		return output.WriteError(output.ExitCodeError, "replace_requires_confirmation",
+			"--replace-section requires --confirm-replace to reduce destructive mistakes")
+	}
+
+	approvalAction := ""
+	switch mode {
+	case "replace":
+		approvalAction = "docs.write.replace"
+	case "replace_section":
+		approvalAction = "docs.write.replace_section"
+	}
+	if !dryRun && approvalAction != "" {
+		required, err := actionRequiresApproval(approvalAction)
 		if err != nil {
 			return output.WriteError(output.ExitCodeError, "policy_error", err.Error())
 		}
 		if required {
-			if err := consumeApprovalToken(c.Account, "docs.write.replace", c.ApprovalToken); err != nil {
+			if err := consumeApprovalToken(c.Account, approvalAction, c.ApprovalToken); err != nil {
 				return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
 			}
 		}
@@ -341,6 +417,11 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 				"content_length":  len(content),
 				"replace":         c.Replace,
 				"confirm_replace": c.ConfirmReplace,
+				"format":          format,
+				"mode":            mode,
+				"after_heading":   c.AfterHeading,
+				"replace_section": c.ReplaceSection,
+				"expect_revision": c.ExpectRevision,
 			},
 		})
 	}
@@ -358,6 +439,9 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		if err != nil {
 			return writeGoogleAPIError("docs_get_error", err)
 		}
+		if err := c.checkRevision(doc); err != nil {
+			return err
+		}
 
 		docLen := docBodyLength(doc)
 
@@ -373,13 +457,38 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		}
 	}
 
-	if strings.TrimSpace(content) != "" {
-		requests = append(requests, &docs.Request{
-			InsertText: &docs.InsertTextRequest{
-				Text:     content,
-				Location: &docs.Location{Index: 1},
-			},
-		})
+	switch mode {
+	case "append", "after_heading", "replace_section":
+		if mode != "replace_section" && strings.TrimSpace(content) == "" {
+			break
+		}
+
+		doc, err := docSvc.Documents.Get(c.DocID).Do()
+		if err != nil {
+			return writeGoogleAPIError("docs_get_error", err)
+		}
+		if err := c.checkRevision(doc); err != nil {
+			return err
+		}
+
+		switch mode {
+		case "append":
+			requests = docsAppendRequests(doc, content, format)
+		case "after_heading":
+			requests, err = docsAfterHeadingRequests(doc, c.AfterHeading, content, format)
+		case "replace_section":
+			requests, err = docsReplaceSectionRequests(doc, c.ReplaceSection, content, format)
+		}
+		if errors.Is(err, errAmbiguousHeading) {
+			return output.WriteError(output.ExitCodeError, "ambiguous_heading", err.Error())
+		}
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "heading_not_found", err.Error())
+		}
+	default:
+		if strings.TrimSpace(content) != "" {
+			requests = append(requests, docsContentRequests(content, format, 1, true)...)
+		}
 	}
 
 	if len(requests) == 0 {
@@ -390,33 +499,64 @@ func (c *DocsWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		})
 	}
 
-	if _, err := docSvc.Documents.BatchUpdate(c.DocID, &docs.BatchUpdateDocumentRequest{
-		Requests: requests,
-	}).Do(); err != nil {
+	previousRevision, revisionErr := preWriteRevisionID(ctx, c.Account, approvalAction, c.DocID)
+
+	// With --expect-revision-id the API applies the batch only if nobody has
+	// edited the document since that revision, closing the gap between the
+	// check above and the write.
+	req := &docs.BatchUpdateDocumentRequest{Requests: requests}
+	if c.ExpectRevision != "" {
+		req.WriteControl = &docs.WriteControl{RequiredRevisionId: c.ExpectRevision}
+	}
+	resp, err := docSvc.Documents.BatchUpdate(c.DocID, req).Do()
+	if err != nil {
+		if c.ExpectRevision != "" && isRevisionConflict(err) {
+			return writeConflictError(fmt.Sprintf("document %s changed since revision %s: %v", c.DocID, c.ExpectRevision, err))
+		}
 		return writeGoogleAPIError("docs_write_error", err)
 	}
 	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "docs.write",
-		Account: normalizeEmail(c.Account),
-		Target:  c.DocID,
-		DryRun:  false,
+		Action:     "docs.write",
+		Account:    normalizeEmail(c.Account),
+		Target:     c.DocID,
+		DryRun:     false,
+		RevisionID: previousRevision,
 	}); err != nil {
 		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
 	}
 
-	return output.WriteJSON(os.Stdout, map[string]any{
+	result := map[string]any{
 		"written": true,
 		"doc_id":  c.DocID,
+		"mode":    mode,
 		"replace": c.Replace,
-	})
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+	if resp.WriteControl != nil && resp.WriteControl.RequiredRevisionId != "" {
+		result["revision_id"] = resp.WriteControl.RequiredRevisionId
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// checkRevision fails with a conflict when --expect-revision-id is set and
+// doc has moved past it.
+func (c *DocsWriteCmd) checkRevision(doc *docs.Document) error {
+	if c.ExpectRevision == "" || doc.RevisionId == c.ExpectRevision {
+		return nil
+	}
+
+	return writeConflictError(fmt.Sprintf("document %s is at revision %s, expected %s", c.DocID, doc.RevisionId, c.ExpectRevision))
 }
 
 // DocsFindReplaceCmd performs find-and-replace in a document.
 type DocsFindReplaceCmd struct {
 	Account            string `name:"account" required:"" short:"a" help:"Google account email."`
 	DocID              string `name:"doc-id" required:"" help:"Google Docs document ID."`
-	Find               string `name:"find" required:"" help:"Text to find."`
-	Replace            string `name:"replace" required:"" help:"Replacement text."`
+	Find               string `name:"find" help:"Text to find."`
+	Replace            string `name:"replace" help:"Replacement text."`
+	Pairs              string `name:"pairs" help:"JSON file of find/replace pairs applied in one batch (- for stdin)."`
+	Regex              bool   `name:"regex" help:"Treat find texts as regular expressions; $1 in replacements expands groups."`
 	MatchCase          bool   `name:"match-case" help:"Case-sensitive matching."`
 	ConfirmFindReplace bool   `name:"confirm-find-replace" help:"Required confirmation flag for find-replace operations."`
 	ApprovalToken      string `name:"approval-token" help:"One-time approval token for dangerous actions."`
@@ -427,6 +567,18 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, root *RootFlags) error {
 	if err := enforceActionPolicy(c.Account, "docs.find_replace"); err != nil {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
+
+	pairs, code, err := replacePairsFrom(c.Find, c.Replace, c.Pairs)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, code, err.Error())
+	}
+	var patterns []*regexp.Regexp
+	if c.Regex {
+		if patterns, err = compileReplacePatterns(pairs, c.MatchCase); err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+		}
+	}
+
 	if !dryRun && !c.ConfirmFindReplace {
 		return output.WriteError(output.ExitCodeError, "find_replace_requires_confirmation",
 			"docs find-replace requires --confirm-find-replace")
@@ -458,8 +610,8 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, root *RootFlags) error {
 			"params": map[string]any{
 				"account":    c.Account,
 				"doc_id":     c.DocID,
-				"find":       c.Find,
-				"replace":    c.Replace,
+				"pairs":      pairs,
+				"regex":      c.Regex,
 				"match_case": c.MatchCase,
 			},
 		})
@@ -470,45 +622,80 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, root *RootFlags) error {
 		return docsAuthError(err)
 	}
 
-	req := &docs.BatchUpdateDocumentRequest{
-		Requests: []*docs.Request{
-			{
+	var req *docs.BatchUpdateDocumentRequest
+	var counts []int64
+	if c.Regex {
+		// The API only matches literal text, so regex matches are located
+		// here and replaced by index. The revision guard rejects the batch if
+		// the document changed after it was read.
+		doc, err := docSvc.Documents.Get(c.DocID).Do()
+		if err != nil {
+			return writeGoogleAPIError("docs_find_replace_error", err)
+		}
+		var matches []textMatch
+		matches, counts = regexMatches(docsDocumentSegments(doc), pairs, patterns)
+		req = &docs.BatchUpdateDocumentRequest{
+			Requests:     docsRegexRequests(matches),
+			WriteControl: &docs.WriteControl{RequiredRevisionId: doc.RevisionId},
+		}
+	} else {
+		req = &docs.BatchUpdateDocumentRequest{}
+		for _, p := range pairs {
+			req.Requests = append(req.Requests, &docs.Request{
 				ReplaceAllText: &docs.ReplaceAllTextRequest{
 					ContainsText: &docs.SubstringMatchCriteria{
-						Text:      c.Find,
+						Text:      p.Find,
 						MatchCase: c.MatchCase,
 					},
-					ReplaceText: c.Replace,
+					ReplaceText: p.Replace,
 				},
-			},
-		},
+			})
+		}
 	}
 
-	resp, err := docSvc.Documents.BatchUpdate(c.DocID, req).Do()
-	if err != nil {
-		return writeGoogleAPIError("docs_find_replace_error", err)
-	}
-	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "docs.find_replace",
-		Account: normalizeEmail(c.Account),
-		Target:  c.DocID,
-		DryRun:  false,
-	}); err != nil {
-		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
-	}
+	// A regex with no matches leaves nothing to write or audit.
+	replaced := len(req.Requests) > 0
+	previousRevision := ""
+	var revisionErr error
+	if replaced {
+		previousRevision, revisionErr = preWriteRevisionID(ctx, c.Account, "docs.find_replace", c.DocID)
 
-	occurrences := int64(0)
-	if resp != nil && len(resp.Replies) > 0 && resp.Replies[0].ReplaceAllText != nil {
-		occurrences = resp.Replies[0].ReplaceAllText.OccurrencesChanged
+		resp, err := docSvc.Documents.BatchUpdate(c.DocID, req).Do()
+		if err != nil {
+			return writeGoogleAPIError("docs_find_replace_error", err)
+		}
+		if !c.Regex {
+			counts = make([]int64, len(pairs))
+			for i := range pairs {
+				if resp != nil && i < len(resp.Replies) && resp.Replies[i].ReplaceAllText != nil {
+					counts[i] = resp.Replies[i].ReplaceAllText.OccurrencesChanged
+				}
+			}
+		}
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:     "docs.find_replace",
+			Account:    normalizeEmail(c.Account),
+			Target:     c.DocID,
+			DryRun:     false,
+			RevisionID: previousRevision,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
 	}
 
-	return output.WriteJSON(os.Stdout, map[string]any{
-		"replaced":    true,
+	result := map[string]any{
+		"replaced":    replaced,
 		"doc_id":      c.DocID,
-		"find":        c.Find,
-		"replace":     c.Replace,
-		"occurrences": occurrences,
-	})
+		"occurrences": sumOccurrences(counts),
+		"pairs":       replacePairResults(pairs, counts),
+	}
+	if c.Find != "" {
+		result["find"] = c.Find
+		result["replace"] = c.Replace
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+
+	return output.WriteJSON(os.Stdout, result)
 }
 
 // docsPlainText extracts plain text from a Google Docs document.
@@ -517,17 +704,37 @@ func docsPlainText(doc *docs.Document) string {
 		return ""
 	}
 
+	return docsText(doc.Body.Content, false)
+}
+
+// docsText returns the paragraph text of content. With suggestions set,
+// suggested insertions and deletions are marked as {++text++} and {--text--}.
+func docsText(content []*docs.StructuralElement, suggestions bool) string {
 	var sb strings.Builder
 
-	for _, elem := range doc.Body.Content {
+	for _, elem := range content {
 		if elem.Paragraph == nil {
 			continue
 		}
 
 		for _, pe := range elem.Paragraph.Elements {
-			if pe.TextRun != nil {
+			if pe.TextRun == nil {
+				continue
+			}
+			if !suggestions {
 				sb.WriteString(pe.TextRun.Content)
+				continue
+			}
+
+			// Keep the paragraph break outside the markers.
+			text := pe.TextRun.Content
+			core := strings.TrimRight(text, "\n")
+			if core == "" {
+				sb.WriteString(text)
+				continue
 			}
+			sb.WriteString(suggestionMarkup(core, docsSuggestionKind(pe.TextRun)))
+			sb.WriteString(text[len(core):])
 		}
 	}
 
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// DocsCommentsCmd groups document comment subcommands.
+type DocsCommentsCmd struct {
+	List    DocsCommentsListCmd    `cmd:"" help:"List comments with their quoted text and replies."`
+	Add     DocsCommentsAddCmd     `cmd:"" help:"Add a comment to a document."`
+	Reply   DocsCommentsReplyCmd   `cmd:"" help:"Reply to a comment."`
+	Resolve DocsCommentsResolveCmd `cmd:"" help:"Resolve a comment."`
+}
+
+// driveCommentFields are the comment fields requested from the Drive API,
+// which returns nothing unless fields are listed explicitly.
+const driveCommentFields = "id,content,author(displayName,emailAddress),createdTime,modifiedTime,resolved,quotedFileContent,anchor," +
+	"replies(id,content,author(displayName,emailAddress),createdTime,action)"
+
+const driveReplyFields = "id,content,author(displayName,emailAddress),createdTime,action"
+
+type docsCommentReply struct {
+	ID          string `json:"id"`
+	Content     string `json:"content"`
+	Author      string `json:"author,omitempty"`
+	AuthorEmail string `json:"author_email,omitempty"`
+	Created     string `json:"created,omitempty"`
+	Action      string `json:"action,omitempty"`
+}
+
+type docsComment struct {
+	ID          string             `json:"id"`
+	Content     string             `json:"content"`
+	QuotedText  string             `json:"quoted_text,omitempty"`
+	Anchor      string             `json:"anchor,omitempty"`
+	Author      string             `json:"author,omitempty"`
+	AuthorEmail string             `json:"author_email,omitempty"`
+	Created     string             `json:"created,omitempty"`
+	Modified    string             `json:"modified,omitempty"`
+	Resolved    bool               `json:"resolved"`
+	Replies     []docsCommentReply `json:"replies"`
+}
+
+func toDocsCommentReply(r *drive.Reply) docsCommentReply {
+	out := docsCommentReply{ID: r.Id, Content: r.Content, Created: r.CreatedTime, Action: r.Action}
+	if r.Author != nil {
+		out.Author = r.Author.DisplayName
+		out.AuthorEmail = r.Author.EmailAddress
+	}
+
+	return out
+}
+
+func toDocsComment(c *drive.Comment) docsComment {
+	out := docsComment{
+		ID:       c.Id,
+		Content:  c.Content,
+		Anchor:   c.Anchor,
+		Created:  c.CreatedTime,
+		Modified: c.ModifiedTime,
+		Resolved: c.Resolved,
+		Replies:  make([]docsCommentReply, 0, len(c.Replies)),
+	}
+	if c.QuotedFileContent != nil {
+		out.QuotedText = c.QuotedFileContent.Value
+	}
+	if c.Author != nil {
+		out.Author = c.Author.DisplayName
+		out.AuthorEmail = c.Author.EmailAddress
+	}
+	for _, r := range c.Replies {
+		out.Replies = append(out.Replies, toDocsCommentReply(r))
+	}
+
+	return out
+}
+
+// DocsCommentsListCmd lists comments on a document.
+type DocsCommentsListCmd struct {
+	Account         string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID           string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	IncludeResolved bool   `name:"include-resolved" help:"Include resolved comments."`
+	Max             int64  `name:"max" default:"100" help:"Maximum comments per page (1-100)."`
+	AllPages        bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page            string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *DocsCommentsListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "docs.comments.list"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("docs.comments.list", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	svc, err := googleapi.NewDriveReadOnly(ctx, c.Account)
+	if err != nil {
+		return docsAuthError(err)
+	}
+
+	comments, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []docsComment, error) {
+		req := svc.Comments.List(c.DocID).
+			PageSize(c.Max).
+			Fields(gapi.Field("nextPageToken,comments(" + driveCommentFields + ")"))
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		} else if c.Page != "" {
+			req = req.PageToken(c.Page)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("list comments: %w", err)
+		}
+
+		out := make([]docsComment, 0, len(resp.Comments))
+		for _, cm := range resp.Comments {
+			if cm.Resolved && !c.IncludeResolved {
+				continue
+			}
+			out = append(out, toDocsComment(cm))
+		}
+
+		return resp.NextPageToken, out, nil
+	})
+	if err != nil {
+		return writeGoogleAPIError("docs_comments_list_error", err)
+	}
+	if comments == nil {
+		comments = []docsComment{}
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"doc_id":        c.DocID,
+		"comments":      comments,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// DocsCommentsAddCmd adds a comment to a document.
+type DocsCommentsAddCmd struct {
+	Account string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID   string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	Content string `name:"content" required:"" help:"Comment text."`
+	Quote   string `name:"quote" help:"Document text the comment refers to, stored as its quoted text."`
+}
+
+func (c *DocsCommentsAddCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "docs.comments.add"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if strings.TrimSpace(c.Content) == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--content must not be empty")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "docs.comments.add",
+			Account: normalizeEmail(c.Account),
+			Target:  c.DocID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "docs.comments.add",
+			"params": map[string]any{
+				"account": c.Account,
+				"doc_id":  c.DocID,
+				"content": c.Content,
+				"quote":   c.Quote,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewDriveWrite(ctx, c.Account)
+	if err != nil {
+		return docsAuthError(err)
+	}
+
+	comment := &drive.Comment{Content: c.Content}
+	if c.Quote != "" {
+		comment.QuotedFileContent = &drive.CommentQuotedFileContent{MimeType: "text/plain", Value: c.Quote}
+	}
+
+	created, err := svc.Comments.Create(c.DocID, comment).Fields(gapi.Field(driveCommentFields)).Do()
+	if err != nil {
+		return writeGoogleAPIError("docs_comments_add_error", err)
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "docs.comments.add",
+		Account: normalizeEmail(c.Account),
+		Target:  c.DocID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"created": true,
+		"doc_id":  c.DocID,
+		"comment": toDocsComment(created),
+	})
+}
+
+// DocsCommentsReplyCmd replies to a comment.
+type DocsCommentsReplyCmd struct {
+	Account   string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID     string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	CommentID string `name:"comment-id" required:"" help:"Comment ID to reply to."`
+	Content   string `name:"content" required:"" help:"Reply text."`
+}
+
+func (c *DocsCommentsReplyCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "docs.comments.reply"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if strings.TrimSpace(c.Content) == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--content must not be empty")
+	}
+
+	return createDocsCommentReply(ctx, root, "docs.comments.reply", c.Account, c.DocID, c.CommentID, &drive.Reply{Content: c.Content})
+}
+
+// DocsCommentsResolveCmd resolves a comment, optionally with a closing reply.
+type DocsCommentsResolveCmd struct {
+	Account   string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID     string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	CommentID string `name:"comment-id" required:"" help:"Comment ID to resolve."`
+	Content   string `name:"content" help:"Optional reply text posted with the resolution."`
+}
+
+func (c *DocsCommentsResolveCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "docs.comments.resolve"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	return createDocsCommentReply(ctx, root, "docs.comments.resolve", c.Account, c.DocID, c.CommentID,
+		&drive.Reply{Content: c.Content, Action: "resolve"})
+}
+
+// createDocsCommentReply posts a reply. Drive resolves a comment through a
+// reply whose action is "resolve".
+func createDocsCommentReply(ctx context.Context, root *RootFlags, action, account, docID, commentID string, reply *drive.Reply) error {
+	target := docID + "/" + commentID
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  action,
+			Account: normalizeEmail(account),
+			Target:  target,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"params": map[string]any{
+				"account":    account,
+				"doc_id":     docID,
+				"comment_id": commentID,
+				"content":    reply.Content,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewDriveWrite(ctx, account)
+	if err != nil {
+		return docsAuthError(err)
+	}
+
+	created, err := svc.Replies.Create(docID, commentID, reply).Fields(gapi.Field(driveReplyFields)).Do()
+	if err != nil {
+		return writeGoogleAPIError(strings.ReplaceAll(action, ".", "_")+"_error", err)
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  action,
+		Account: normalizeEmail(account),
+		Target:  target,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"doc_id":     docID,
+		"comment_id": commentID,
+		"reply":      toDocsCommentReply(created),
+	}
+	if reply.Action == "resolve" {
+		result["resolved"] = true
+	} else {
+		result["created"] = true
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
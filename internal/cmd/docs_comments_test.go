@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+)
+
+func TestToDocsComment(t *testing.T) {
+	got := toDocsComment(&drive.Comment{
+		Id:                "c1",
+		Content:           "Is this number right?",
+		Author:            &drive.User{DisplayName: "Reviewer", EmailAddress: "r@example.com"},
+		QuotedFileContent: &drive.CommentQuotedFileContent{MimeType: "text/html", Value: "42%"},
+		Anchor:            "kix.abc",
+		Replies: []*drive.Reply{
+			{Id: "r1", Content: "Fixed", Action: "resolve"},
+		},
+		Resolved: true,
+	})
+
+	if got.QuotedText != "42%" || got.Anchor != "kix.abc" || got.AuthorEmail != "r@example.com" {
+		t.Errorf("unexpected comment: %+v", got)
+	}
+	if len(got.Replies) != 1 || got.Replies[0].Action != "resolve" {
+		t.Errorf("unexpected replies: %+v", got.Replies)
+	}
+
+	empty := toDocsComment(&drive.Comment{Id: "c2"})
+	if empty.Replies == nil {
+		t.Error("replies should encode as an empty array, not null")
+	}
+}
+
+func TestDocsCommentsCmds_PolicyDenied(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// Reading comments does not imply permission to write them.
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"docs.comments.list"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	root := &RootFlags{DryRun: true}
+	assertPolicyDenied(t, func() error {
+		return (&DocsCommentsAddCmd{Account: "a@example.com", DocID: "doc-123", Content: "x"}).Run(context.Background(), root)
+	})
+	assertPolicyDenied(t, func() error {
+		return (&DocsCommentsReplyCmd{Account: "a@example.com", DocID: "doc-123", CommentID: "c1", Content: "x"}).Run(context.Background(), root)
+	})
+	assertPolicyDenied(t, func() error {
+		return (&DocsCommentsResolveCmd{Account: "a@example.com", DocID: "doc-123", CommentID: "c1"}).Run(context.Background(), root)
+	})
+}
+
+func TestDocsCommentsResolveCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &DocsCommentsResolveCmd{Account: "A@example.com", DocID: "doc-123", CommentID: "c1", Content: "Done"}
+
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		DryRun bool           `json:"dry_run"`
+		Action string         `json:"action"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if !payload.DryRun || payload.Action != "docs.comments.resolve" || payload.Params["comment_id"] != "c1" {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+
+	entries := readAuditEntries(t)
+	if len(entries) != 1 || entries[0].Target != "doc-123/c1" || entries[0].Account != "a@example.com" {
+		t.Errorf("unexpected audit entries: %+v", entries)
+	}
+}
+
+func TestDocsCommentsAddCmd_EmptyContent(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &DocsCommentsAddCmd{Account: "a@example.com", DocID: "doc-123", Content: "  "}
+	var err error
+	stderr := captureStderr(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err == nil {
+		t.Fatal("expected error for empty comment")
+	}
+	if !strings.Contains(stderr, `"invalid_arguments"`) {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// headingPrefixes maps paragraph named styles to Markdown heading markers.
+var headingPrefixes = map[string]string{
+	"TITLE":     "# ",
+	"SUBTITLE":  "## ",
+	"HEADING_1": "# ",
+	"HEADING_2": "## ",
+	"HEADING_3": "### ",
+	"HEADING_4": "#### ",
+	"HEADING_5": "##### ",
+	"HEADING_6": "###### ",
+}
+
+// orderedGlyphTypes are list glyph types that render as numbered items.
+var orderedGlyphTypes = map[string]bool{
+	"DECIMAL":      true,
+	"ZERO_DECIMAL": true,
+	"UPPER_ALPHA":  true,
+	"ALPHA":        true,
+	"UPPER_ROMAN":  true,
+	"ROMAN":        true,
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// docsMarkdown renders a document body as GitHub-flavored Markdown: headings,
+// lists, tables, links, emphasis, inline images and footnotes.
+func docsMarkdown(doc *docs.Document) string {
+	if doc.Body == nil {
+		return ""
+	}
+
+	return renderMarkdown(doc.Body.Content, docResources{
+		Lists:         doc.Lists,
+		Footnotes:     doc.Footnotes,
+		InlineObjects: doc.InlineObjects,
+	}, false)
+}
+
+// docResources are the document-level maps that body content refers to by ID.
+type docResources struct {
+	Lists         map[string]docs.List
+	Footnotes     map[string]docs.Footnote
+	InlineObjects map[string]docs.InlineObject
+}
+
+// renderMarkdown renders content as Markdown. With suggestions set, suggested
+// insertions and deletions are marked as {++text++} and {--text--}.
+func renderMarkdown(content []*docs.StructuralElement, res docResources, suggestions bool) string {
+	r := &markdownRenderer{res: res, counters: make(map[string][]int), suggestions: suggestions}
+	out := r.renderContent(content)
+	if len(r.footnotes) == 0 {
+		return out
+	}
+
+	var sb strings.Builder
+	sb.WriteString(out)
+	sb.WriteString("\n")
+	for _, fn := range r.footnotes {
+		text := ""
+		if f, ok := res.Footnotes[fn.id]; ok {
+			sub := &markdownRenderer{res: res, counters: make(map[string][]int), inline: true, suggestions: suggestions}
+			text = strings.TrimSpace(sub.renderContent(f.Content))
+		}
+		fmt.Fprintf(&sb, "[^%s]: %s\n", fn.number, text)
+	}
+
+	return sb.String()
+}
+
+type markdownFootnote struct {
+	id     string
+	number string
+}
+
+type markdownRenderer struct {
+	res       docResources
+	counters  map[string][]int
+	footnotes []markdownFootnote
+	// inline renders paragraphs for a single-line context (table cells,
+	// footnote bodies): no block markers, soft breaks as <br>.
+	inline      bool
+	suggestions bool
+}
+
+type markdownBlock struct {
+	text     string
+	listItem bool
+}
+
+func (r *markdownRenderer) renderContent(content []*docs.StructuralElement) string {
+	var blocks []markdownBlock
+	for _, elem := range content {
+		switch {
+		case elem.Paragraph != nil:
+			text, listItem := r.renderParagraph(elem.Paragraph)
+			if text == "" {
+				continue
+			}
+			blocks = append(blocks, markdownBlock{text: text, listItem: listItem})
+		case elem.Table != nil:
+			blocks = append(blocks, markdownBlock{text: r.renderTable(elem.Table)})
+		}
+	}
+
+	sep := "\n\n"
+	if r.inline {
+		sep = "<br>"
+	}
+
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			if !r.inline && b.listItem && blocks[i-1].listItem {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString(sep)
+			}
+		}
+		sb.WriteString(b.text)
+	}
+	if !r.inline && sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (r *markdownRenderer) renderParagraph(p *docs.Paragraph) (string, bool) {
+	text := r.renderElements(p.Elements)
+	if strings.TrimSpace(text) == "" {
+		return "", false
+	}
+	if r.inline {
+		return text, false
+	}
+
+	if p.Bullet != nil {
+		return r.listMarker(p.Bullet) + text, true
+	}
+
+	if p.ParagraphStyle != nil {
+		if prefix, ok := headingPrefixes[p.ParagraphStyle.NamedStyleType]; ok {
+			return prefix + text, false
+		}
+	}
+
+	return text, false
+}
+
+// listMarker returns the indented bullet or number for a list paragraph.
+func (r *markdownRenderer) listMarker(b *docs.Bullet) string {
+	level := int(b.NestingLevel)
+	indent := strings.Repeat("    ", level)
+
+	if !r.isOrderedList(b.ListId, level) {
+		return indent + "- "
+	}
+
+	counts := r.counters[b.ListId]
+	if len(counts) < level+1 {
+		counts = append(counts, make([]int, level+1-len(counts))...)
+	}
+	counts = counts[:level+1]
+	counts[level]++
+	r.counters[b.ListId] = counts
+
+	return fmt.Sprintf("%s%d. ", indent, counts[level])
+}
+
+func (r *markdownRenderer) isOrderedList(listID string, level int) bool {
+	list, ok := r.res.Lists[listID]
+	if !ok || list.ListProperties == nil || level >= len(list.ListProperties.NestingLevels) {
+		return false
+	}
+
+	return orderedGlyphTypes[list.ListProperties.NestingLevels[level].GlyphType]
+}
+
+// markdownSpan is a run of text with the inline formatting Markdown can express.
+type markdownSpan struct {
+	text   string
+	bold   bool
+	italic bool
+	strike bool
+	link   string
+	raw    bool
+	// suggestion is "insertion" or "deletion" for suggested text.
+	suggestion string
+}
+
+func (r *markdownRenderer) renderElements(elems []*docs.ParagraphElement) string {
+	var spans []markdownSpan
+	for _, pe := range elems {
+		switch {
+		case pe.TextRun != nil:
+			s := markdownSpan{text: pe.TextRun.Content}
+			if r.suggestions {
+				s.suggestion = docsSuggestionKind(pe.TextRun)
+			}
+			if ts := pe.TextRun.TextStyle; ts != nil {
+				s.bold = ts.Bold
+				s.italic = ts.Italic
+				s.strike = ts.Strikethrough
+				if ts.Link != nil {
+					s.link = ts.Link.Url
+				}
+			}
+			spans = append(spans, s)
+		case pe.InlineObjectElement != nil:
+			spans = append(spans, markdownSpan{text: r.renderInlineObject(pe.InlineObjectElement.InlineObjectId), raw: true})
+		case pe.FootnoteReference != nil:
+			fr := pe.FootnoteReference
+			r.footnotes = append(r.footnotes, markdownFootnote{id: fr.FootnoteId, number: fr.FootnoteNumber})
+			spans = append(spans, markdownSpan{text: "[^" + fr.FootnoteNumber + "]", raw: true})
+		case pe.HorizontalRule != nil:
+			spans = append(spans, markdownSpan{text: "---", raw: true})
+		}
+	}
+
+	var sb strings.Builder
+	for _, s := range mergeMarkdownSpans(spans) {
+		sb.WriteString(r.renderSpan(s))
+	}
+
+	text := strings.TrimRight(sb.String(), "\n")
+	if r.inline {
+		text = strings.ReplaceAll(text, "\n", "<br>")
+	}
+
+	return text
+}
+
+// mergeMarkdownSpans joins adjacent spans with identical formatting so that
+// style runs split by the API do not produce "**a****b**".
+func mergeMarkdownSpans(spans []markdownSpan) []markdownSpan {
+	var out []markdownSpan
+	for _, s := range spans {
+		if n := len(out); n > 0 && !s.raw && !out[n-1].raw {
+			last := &out[n-1]
+			if last.bold == s.bold && last.italic == s.italic && last.strike == s.strike && last.link == s.link && last.suggestion == s.suggestion {
+				last.text += s.text
+				continue
+			}
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
+
+func (r *markdownRenderer) renderSpan(s markdownSpan) string {
+	if s.raw {
+		return s.text
+	}
+
+	text := strings.ReplaceAll(s.text, "\v", "\n")
+	text = markdownEscaper.Replace(text)
+	if r.inline {
+		text = strings.ReplaceAll(text, "|", `\|`)
+	}
+
+	// Emphasis markers must hug the text, so keep surrounding whitespace
+	// (including the paragraph's trailing newline) outside them.
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, core)]
+	trail := text[len(lead)+len(core):]
+
+	if s.strike {
+		core = "~~" + core + "~~"
+	}
+	if s.italic {
+		core = "*" + core + "*"
+	}
+	if s.bold {
+		core = "**" + core + "**"
+	}
+	if s.link != "" {
+		core = "[" + core + "](" + s.link + ")"
+	}
+	core = suggestionMarkup(core, s.suggestion)
+
+	return lead + core + trail
+}
+
+func (r *markdownRenderer) renderInlineObject(id string) string {
+	obj, ok := r.res.InlineObjects[id]
+	if !ok || obj.InlineObjectProperties == nil || obj.InlineObjectProperties.EmbeddedObject == nil {
+		return ""
+	}
+
+	eo := obj.InlineObjectProperties.EmbeddedObject
+	alt := eo.Title
+	if alt == "" {
+		alt = eo.Description
+	}
+	uri := ""
+	if eo.ImageProperties != nil {
+		uri = eo.ImageProperties.ContentUri
+	}
+
+	return "![" + markdownEscaper.Replace(alt) + "](" + uri + ")"
+}
+
+// renderTable renders a table as a GFM table; the first row is the header.
+func (r *markdownRenderer) renderTable(t *docs.Table) string {
+	cells := &markdownRenderer{res: r.res, counters: r.counters, inline: true, suggestions: r.suggestions}
+
+	cols := int(t.Columns)
+	var rows [][]string
+	for _, row := range t.TableRows {
+		var out []string
+		for _, cell := range row.TableCells {
+			out = append(out, strings.TrimSpace(cells.renderContent(cell.Content)))
+		}
+		if len(out) > cols {
+			cols = len(out)
+		}
+		rows = append(rows, out)
+	}
+	r.footnotes = append(r.footnotes, cells.footnotes...)
+
+	if len(rows) == 0 || cols == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for i := 0; i < cols; i++ {
+			v := ""
+			if i < len(row) {
+				v = row[i]
+			}
+			sb.WriteString(" " + v + " |")
+		}
+	}
+
+	writeRow(rows[0])
+	sb.WriteString("\n|")
+	sb.WriteString(strings.Repeat(" --- |", cols))
+	for _, row := range rows[1:] {
+		sb.WriteString("\n")
+		writeRow(row)
+	}
+
+	return sb.String()
+}
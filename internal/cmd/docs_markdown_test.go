@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func mdPara(style string, runs ...*docs.ParagraphElement) *docs.StructuralElement {
+	p := &docs.Paragraph{Elements: runs}
+	if style != "" {
+		p.ParagraphStyle = &docs.ParagraphStyle{NamedStyleType: style}
+	}
+	return &docs.StructuralElement{Paragraph: p}
+}
+
+func mdRun(text string, style *docs.TextStyle) *docs.ParagraphElement {
+	return &docs.ParagraphElement{TextRun: &docs.TextRun{Content: text, TextStyle: style}}
+}
+
+func TestDocsMarkdown_HeadingsAndEmphasis(t *testing.T) {
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		mdPara("HEADING_2", mdRun("Weekly notes\n", nil)),
+		mdPara("NORMAL_TEXT",
+			mdRun("See ", nil),
+			mdRun("the spec ", &docs.TextStyle{Bold: true}),
+			mdRun("here", &docs.TextStyle{Link: &docs.Link{Url: "https://example.com"}}),
+			mdRun(" and *stars*.\n", nil),
+		),
+		mdPara("NORMAL_TEXT", mdRun("\n", nil)),
+	}}}
+
+	want := "## Weekly notes\n\nSee **the spec** [here](https://example.com) and \\*stars\\*.\n"
+	if got := docsMarkdown(doc); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestDocsMarkdown_MergesSplitRuns(t *testing.T) {
+	bold := &docs.TextStyle{Bold: true}
+	doc := &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		mdPara("", mdRun("ab", bold), mdRun("cd", bold), mdRun("\n", nil)),
+	}}}
+
+	if got, want := docsMarkdown(doc), "**abcd**\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDocsMarkdown_Lists(t *testing.T) {
+	item := func(listID string, level int64, text string) *docs.StructuralElement {
+		e := mdPara("", mdRun(text+"\n", nil))
+		e.Paragraph.Bullet = &docs.Bullet{ListId: listID, NestingLevel: level}
+		return e
+	}
+
+	doc := &docs.Document{
+		Lists: map[string]docs.List{
+			"ol": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{
+				{GlyphType: "DECIMAL"},
+				{GlyphSymbol: "●"},
+			}}},
+			"ul": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{
+				{GlyphSymbol: "●"},
+			}}},
+		},
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			item("ol", 0, "first"),
+			item("ol", 1, "nested"),
+			item("ol", 0, "second"),
+			mdPara("", mdRun("between\n", nil)),
+			item("ul", 0, "bullet"),
+		}},
+	}
+
+	want := "1. first\n    - nested\n2. second\n\nbetween\n\n- bullet\n"
+	if got := docsMarkdown(doc); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestDocsMarkdown_TableAndFootnote(t *testing.T) {
+	cell := func(text string) *docs.TableCell {
+		return &docs.TableCell{Content: []*docs.StructuralElement{mdPara("", mdRun(text+"\n", nil))}}
+	}
+
+	doc := &docs.Document{
+		Footnotes: map[string]docs.Footnote{
+			"fn1": {Content: []*docs.StructuralElement{mdPara("", mdRun(" Source: survey\n", nil))}},
+		},
+		InlineObjects: map[string]docs.InlineObject{
+			"img": {InlineObjectProperties: &docs.InlineObjectProperties{EmbeddedObject: &docs.EmbeddedObject{
+				Title:           "chart",
+				ImageProperties: &docs.ImageProperties{ContentUri: "https://img.example/1"},
+			}}},
+		},
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{Table: &docs.Table{Columns: 2, TableRows: []*docs.TableRow{
+				{TableCells: []*docs.TableCell{cell("Name"), cell("Score")}},
+				{TableCells: []*docs.TableCell{cell("a|b"), cell("3")}},
+			}}},
+			mdPara("",
+				mdRun("Result", nil),
+				&docs.ParagraphElement{FootnoteReference: &docs.FootnoteReference{FootnoteId: "fn1", FootnoteNumber: "1"}},
+				&docs.ParagraphElement{InlineObjectElement: &docs.InlineObjectElement{InlineObjectId: "img"}},
+				mdRun("\n", nil),
+			),
+		}},
+	}
+
+	want := "| Name | Score |\n| --- | --- |\n| a\\|b | 3 |\n\n" +
+		"Result[^1]![chart](https://img.example/1)\n\n" +
+		"[^1]: Source: survey\n"
+	if got := docsMarkdown(doc); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
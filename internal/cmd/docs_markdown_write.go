@@ -0,0 +1,595 @@
+package cmd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// mdSpan is a run of inline text with the formatting Docs can express.
+type mdSpan struct {
+	text   string
+	bold   bool
+	italic bool
+	strike bool
+	code   bool
+	link   string
+}
+
+type mdBlockKind int
+
+const (
+	mdParagraph mdBlockKind = iota
+	mdHeading
+	mdListItem
+	mdCode
+	mdTable
+)
+
+// mdBlock is one parsed Markdown block. For headings level is 1-6; for list
+// items it is the nesting depth.
+type mdBlock struct {
+	kind    mdBlockKind
+	level   int
+	ordered bool
+	spans   []mdSpan
+	rows    [][][]mdSpan
+}
+
+var (
+	mdHeadingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdListItemRe  = regexp.MustCompile(`^([ \t]*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdRuleRe      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdBreakTagsRe = regexp.MustCompile(`(?i)<br\s*/?>`)
+)
+
+// markdownDocsRequests converts Markdown into Docs batch update requests that
+// insert the content at index at. When trailingNewline is false the last
+// paragraph is written without its newline, filling the paragraph at at.
+//
+// Blocks are emitted last-to-first, each inserted at the same index, so the
+// ranges of every block are computed against a document where nothing before
+// them has changed yet.
+func markdownDocsRequests(src string, at int64, trailingNewline bool) []*docs.Request {
+	blocks := parseMarkdownBlocks(src)
+
+	// Group consecutive non-table blocks into one text insertion.
+	type group struct {
+		blocks []mdBlock
+		table  *mdBlock
+	}
+	var groups []group
+	for i := range blocks {
+		b := blocks[i]
+		if b.kind == mdTable {
+			groups = append(groups, group{table: &blocks[i]})
+			continue
+		}
+		if n := len(groups); n > 0 && groups[n-1].table == nil {
+			groups[n-1].blocks = append(groups[n-1].blocks, b)
+			continue
+		}
+		groups = append(groups, group{blocks: []mdBlock{b}})
+	}
+
+	var requests []*docs.Request
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g.table != nil {
+			requests = append(requests, markdownTableRequests(g.table, at)...)
+			continue
+		}
+
+		// InsertTable adds its own newline before the table, so text that
+		// precedes a table drops its final newline to avoid an empty paragraph.
+		newline := trailingNewline
+		if i+1 < len(groups) {
+			newline = groups[i+1].table == nil
+		}
+		requests = append(requests, markdownTextRequests(g.blocks, at, newline)...)
+	}
+
+	return requests
+}
+
+// utf16Len returns the length of s in UTF-16 code units, the unit of Docs indexes.
+func utf16Len(s string) int64 {
+	n := int64(0)
+	for _, r := range s {
+		n += int64(utf16.RuneLen(r))
+	}
+
+	return n
+}
+
+func markdownTextRequests(blocks []mdBlock, at int64, trailingNewline bool) []*docs.Request {
+	type paragraph struct {
+		start, end int64
+		style      string
+	}
+	type listRun struct {
+		start, end int64
+		ordered    bool
+	}
+	type styled struct {
+		start, end int64
+		span       mdSpan
+	}
+
+	var (
+		sb     strings.Builder
+		pos    = at
+		paras  []paragraph
+		lists  []listRun
+		styles []styled
+	)
+
+	write := func(s string) {
+		sb.WriteString(s)
+		pos += utf16Len(s)
+	}
+
+	for i, b := range blocks {
+		start := pos
+		if b.kind == mdListItem {
+			// CreateParagraphBullets derives nesting from leading tabs.
+			write(strings.Repeat("\t", b.level))
+		}
+		for _, s := range b.spans {
+			spanStart := pos
+			write(s.text)
+			if s.bold || s.italic || s.strike || s.code || s.link != "" {
+				styles = append(styles, styled{start: spanStart, end: pos, span: s})
+			}
+		}
+		if b.kind == mdCode {
+			styles = append(styles, styled{start: start, end: pos, span: mdSpan{code: true}})
+		}
+		if i < len(blocks)-1 || trailingNewline {
+			write("\n")
+		}
+
+		style := "NORMAL_TEXT"
+		if b.kind == mdHeading {
+			style = "HEADING_" + strconv.Itoa(b.level)
+		}
+		if pos > start {
+			paras = append(paras, paragraph{start: start, end: pos, style: style})
+		}
+
+		if b.kind == mdListItem && pos > start {
+			if n := len(lists); n > 0 && lists[n-1].end == start && (b.level > 0 || lists[n-1].ordered == b.ordered) {
+				lists[n-1].end = pos
+			} else {
+				lists = append(lists, listRun{start: start, end: pos, ordered: b.ordered})
+			}
+		}
+	}
+
+	text := sb.String()
+	if text == "" {
+		return nil
+	}
+
+	requests := []*docs.Request{
+		{InsertText: &docs.InsertTextRequest{Text: text, Location: &docs.Location{Index: at}}},
+		// Inserted text inherits the style around the insertion point
+		// (including list membership); reset it.
+		{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     &docs.Range{StartIndex: at, EndIndex: pos},
+			TextStyle: &docs.TextStyle{},
+			Fields:    "bold,italic,strikethrough,link,weightedFontFamily",
+		}},
+		{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{
+			Range: &docs.Range{StartIndex: at, EndIndex: pos},
+		}},
+	}
+
+	for _, p := range paras {
+		requests = append(requests, &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range:          &docs.Range{StartIndex: p.start, EndIndex: p.end},
+			ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: p.style},
+			Fields:         "namedStyleType",
+		}})
+	}
+
+	for _, s := range styles {
+		if s.end > s.start {
+			requests = append(requests, mdTextStyleRequest(s.span, s.start, s.end))
+		}
+	}
+
+	// Bullets go last: removing the leading tabs shifts the indexes that
+	// the requests above rely on. Lists are bulleted last to first so the
+	// tabs removed from one list do not move the range of the next.
+	for i := len(lists) - 1; i >= 0; i-- {
+		l := lists[i]
+		preset := "BULLET_DISC_CIRCLE_SQUARE"
+		if l.ordered {
+			preset = "NUMBERED_DECIMAL_ALPHA_ROMAN"
+		}
+		requests = append(requests, &docs.Request{CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+			Range:        &docs.Range{StartIndex: l.start, EndIndex: l.end},
+			BulletPreset: preset,
+		}})
+	}
+
+	return requests
+}
+
+func mdTextStyleRequest(s mdSpan, start, end int64) *docs.Request {
+	style := &docs.TextStyle{}
+	var fields []string
+	if s.bold {
+		style.Bold = true
+		fields = append(fields, "bold")
+	}
+	if s.italic {
+		style.Italic = true
+		fields = append(fields, "italic")
+	}
+	if s.strike {
+		style.Strikethrough = true
+		fields = append(fields, "strikethrough")
+	}
+	if s.code {
+		style.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: "Courier New"}
+		fields = append(fields, "weightedFontFamily")
+	}
+	if s.link != "" {
+		style.Link = &docs.Link{Url: s.link}
+		fields = append(fields, "link")
+	}
+
+	return &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+		Range:     &docs.Range{StartIndex: start, EndIndex: end},
+		TextStyle: style,
+		Fields:    strings.Join(fields, ","),
+	}}
+}
+
+// markdownTableRequests inserts a table at index at and fills its cells.
+func markdownTableRequests(b *mdBlock, at int64) []*docs.Request {
+	rows := len(b.rows)
+	cols := 0
+	for _, r := range b.rows {
+		if len(r) > cols {
+			cols = len(r)
+		}
+	}
+	if rows == 0 || cols == 0 {
+		return nil
+	}
+
+	requests := []*docs.Request{{InsertTable: &docs.InsertTableRequest{
+		Rows:     int64(rows),
+		Columns:  int64(cols),
+		Location: &docs.Location{Index: at},
+	}}}
+
+	// The table starts after the newline InsertTable adds at at. Each row
+	// takes one index plus two per cell (cell start, empty paragraph), so the
+	// paragraph of cell (r, c) is at tableStart + 1 + r*(2*cols+1) + 2*c + 2.
+	// Cells are filled last-to-first so earlier cell indexes stay valid.
+	tableStart := at + 1
+	for r := rows - 1; r >= 0; r-- {
+		for c := cols - 1; c >= 0; c-- {
+			if c >= len(b.rows[r]) {
+				continue
+			}
+
+			spans := b.rows[r][c]
+			if r == 0 {
+				spans = boldSpans(spans)
+			}
+
+			idx := tableStart + 1 + int64(r)*int64(2*cols+1) + int64(2*c) + 2
+			cell := mdBlock{kind: mdParagraph, spans: spans}
+			requests = append(requests, markdownTextRequests([]mdBlock{cell}, idx, false)...)
+		}
+	}
+
+	return requests
+}
+
+func boldSpans(spans []mdSpan) []mdSpan {
+	out := make([]mdSpan, len(spans))
+	for i, s := range spans {
+		s.bold = true
+		out[i] = s
+	}
+
+	return out
+}
+
+// parseMarkdownBlocks parses the block structure Docs can represent:
+// headings, paragraphs, bullet/numbered lists, fenced code and GFM tables.
+func parseMarkdownBlocks(src string) []mdBlock {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var (
+		blocks  []mdBlock
+		para    []string
+		indents []int
+	)
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, mdBlock{kind: mdParagraph, spans: parseMarkdownInline(strings.Join(para, " "))})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if !mdListItemRe.MatchString(line) {
+			indents = nil
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			fence := trimmed[:3]
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				blocks = append(blocks, mdBlock{kind: mdCode, spans: []mdSpan{{text: lines[i]}}})
+			}
+		case mdHeadingRe.MatchString(trimmed):
+			flush()
+			m := mdHeadingRe.FindStringSubmatch(trimmed)
+			blocks = append(blocks, mdBlock{kind: mdHeading, level: len(m[1]), spans: parseMarkdownInline(m[2])})
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && mdTableSepRe.MatchString(lines[i+1]):
+			flush()
+			table := mdBlock{kind: mdTable}
+			table.rows = append(table.rows, parseMarkdownTableRow(trimmed))
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				table.rows = append(table.rows, parseMarkdownTableRow(strings.TrimSpace(lines[i])))
+			}
+			i--
+			blocks = append(blocks, table)
+		case mdRuleRe.MatchString(trimmed):
+			flush()
+		case mdListItemRe.MatchString(line):
+			flush()
+			m := mdListItemRe.FindStringSubmatch(line)
+			width := indentWidth(m[1])
+			for len(indents) > 0 && indents[len(indents)-1] > width {
+				indents = indents[:len(indents)-1]
+			}
+			if len(indents) == 0 || indents[len(indents)-1] < width {
+				indents = append(indents, width)
+			}
+			blocks = append(blocks, mdBlock{
+				kind:    mdListItem,
+				level:   len(indents) - 1,
+				ordered: m[2][0] >= '0' && m[2][0] <= '9',
+				spans:   parseMarkdownInline(m[3]),
+			})
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			blocks = append(blocks, mdBlock{kind: mdParagraph, spans: parseMarkdownInline(strings.TrimSpace(strings.TrimLeft(trimmed, ">")))})
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func indentWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		if r == '\t' {
+			w += 4
+		} else {
+			w++
+		}
+	}
+
+	return w
+}
+
+// parseMarkdownTableRow splits a GFM table row on unescaped pipes.
+func parseMarkdownTableRow(line string) [][]mdSpan {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells [][]mdSpan
+	var cur strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cur.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, parseMarkdownCell(cur.String()))
+			cur.Reset()
+			continue
+		}
+		cur.WriteByte(line[i])
+	}
+	cells = append(cells, parseMarkdownCell(cur.String()))
+
+	return cells
+}
+
+func parseMarkdownCell(s string) []mdSpan {
+	return parseMarkdownInline(mdBreakTagsRe.ReplaceAllString(strings.TrimSpace(s), "\n"))
+}
+
+// parseMarkdownInline parses emphasis, strikethrough, code spans, links and
+// backslash escapes. Unmatched delimiters are kept as literal text.
+func parseMarkdownInline(s string) []mdSpan {
+	var (
+		out   []mdSpan
+		cur   strings.Builder
+		state mdSpan
+	)
+
+	emit := func() {
+		if cur.Len() == 0 {
+			return
+		}
+		span := state
+		span.text = cur.String()
+		cur.Reset()
+		if n := len(out); n > 0 && sameMarkdownStyle(out[n-1], span) {
+			out[n-1].text += span.text
+			return
+		}
+		out = append(out, span)
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_{}[]()#+-.!|~>", rune(rest[1])):
+			cur.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.Index(rest[1:], "`"); end >= 0 {
+				emit()
+				prev := state
+				state.code = true
+				cur.WriteString(rest[1 : end+1])
+				emit()
+				state = prev
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[' || strings.HasPrefix(rest, "!["):
+			offset := 0
+			if rest[0] == '!' {
+				offset = 1
+			}
+			if label, url, n, ok := parseMarkdownLink(rest[offset:]); ok {
+				emit()
+				for _, sp := range parseMarkdownInline(label) {
+					sp.bold = sp.bold || state.bold
+					sp.italic = sp.italic || state.italic
+					sp.strike = sp.strike || state.strike
+					sp.link = url
+					out = append(out, sp)
+				}
+				i += offset + n
+				continue
+			}
+		}
+
+		if d, ok := mdDelimiter(s, i); ok {
+			toggle := func(open bool) bool {
+				// Only open when a matching closer follows.
+				return open || strings.Contains(s[i+len(d):], d)
+			}
+			switch d {
+			case "**", "__":
+				if toggle(state.bold) {
+					emit()
+					state.bold = !state.bold
+					i += len(d)
+					continue
+				}
+			case "*", "_":
+				if toggle(state.italic) {
+					emit()
+					state.italic = !state.italic
+					i += len(d)
+					continue
+				}
+			case "~~":
+				if toggle(state.strike) {
+					emit()
+					state.strike = !state.strike
+					i += len(d)
+					continue
+				}
+			}
+		}
+
+		cur.WriteByte(s[i])
+		i++
+	}
+	emit()
+
+	return out
+}
+
+func sameMarkdownStyle(a, b mdSpan) bool {
+	a.text, b.text = "", ""
+	return a == b
+}
+
+// mdDelimiter returns the emphasis delimiter at s[i], if any. Underscores
+// inside words (snake_case) are not delimiters.
+func mdDelimiter(s string, i int) (string, bool) {
+	for _, d := range []string{"**", "__", "~~", "*", "_"} {
+		if !strings.HasPrefix(s[i:], d) {
+			continue
+		}
+		if d[0] == '_' {
+			before := i > 0 && isWordByte(s[i-1])
+			after := i+len(d) < len(s) && isWordByte(s[i+len(d)])
+			if before && after {
+				return "", false
+			}
+		}
+		return d, true
+	}
+
+	return "", false
+}
+
+func isWordByte(b byte) bool {
+	return b >= 0x80 || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+// parseMarkdownLink parses "[label](url)" at the start of s and returns the
+// number of bytes consumed.
+func parseMarkdownLink(s string) (label, url string, n int, ok bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", "", 0, false
+	}
+
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if !strings.HasPrefix(s[i+1:], "(") {
+					return "", "", 0, false
+				}
+				end := strings.Index(s[i+2:], ")")
+				if end < 0 {
+					return "", "", 0, false
+				}
+				url = strings.TrimSpace(s[i+2 : i+2+end])
+				// Drop an optional title: [x](url "title").
+				if sp := strings.IndexAny(url, " \t"); sp >= 0 {
+					url = url[:sp]
+				}
+				return s[1:i], strings.Trim(url, "<>"), i + 3 + end, true
+			}
+		}
+	}
+
+	return "", "", 0, false
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestParseMarkdownInline(t *testing.T) {
+	got := parseMarkdownInline("plain **bold *both*** ~~gone~~ [site](https://example.com) `x_y` snake_case \\*lit\\* *open")
+	want := []mdSpan{
+		{text: "plain "},
+		{text: "bold ", bold: true},
+		{text: "both", bold: true, italic: true},
+		{text: " "},
+		{text: "gone", strike: true},
+		{text: " "},
+		{text: "site", link: "https://example.com"},
+		{text: " "},
+		{text: "x_y", code: true},
+		{text: " snake_case *lit* *open"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseMarkdownBlocks(t *testing.T) {
+	src := "# Title\n\nline one\nline two\n\n- a\n  - b\n1. c\n\n| H1 | H2 |\n|---|:-:|\n| x \\| y | z |\n\n```\ncode\n```\n---\n"
+	blocks := parseMarkdownBlocks(src)
+
+	kinds := make([]mdBlockKind, 0, len(blocks))
+	for _, b := range blocks {
+		kinds = append(kinds, b.kind)
+	}
+	wantKinds := []mdBlockKind{mdHeading, mdParagraph, mdListItem, mdListItem, mdListItem, mdTable, mdCode}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Fatalf("kinds = %v, want %v", kinds, wantKinds)
+	}
+
+	if blocks[1].spans[0].text != "line one line two" {
+		t.Errorf("paragraph lines not joined: %q", blocks[1].spans[0].text)
+	}
+	if blocks[3].level != 1 || blocks[4].level != 0 || !blocks[4].ordered {
+		t.Errorf("list levels: %+v %+v", blocks[3], blocks[4])
+	}
+	if got := blocks[5].rows[1][0][0].text; got != "x | y" {
+		t.Errorf("escaped pipe: got %q", got)
+	}
+}
+
+func TestMarkdownDocsRequests_TextIndexes(t *testing.T) {
+	// "😀" is two UTF-16 code units, which shifts every later index by one.
+	reqs := markdownDocsRequests("## 😀 Hi\n\nSee **this**.\n\n- one\n  - two\n", 1, true)
+
+	if reqs[0].InsertText == nil {
+		t.Fatalf("first request should insert text: %+v", reqs[0])
+	}
+	if got, want := reqs[0].InsertText.Text, "😀 Hi\nSee this.\none\n\ttwo\n"; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+
+	var heading, bold, bullets *docs.Request
+	for _, r := range reqs {
+		switch {
+		case r.UpdateParagraphStyle != nil && r.UpdateParagraphStyle.ParagraphStyle.NamedStyleType == "HEADING_2":
+			heading = r
+		case r.UpdateTextStyle != nil && r.UpdateTextStyle.Fields == "bold":
+			bold = r
+		case r.CreateParagraphBullets != nil:
+			bullets = r
+		}
+	}
+
+	if heading == nil || heading.UpdateParagraphStyle.Range.StartIndex != 1 || heading.UpdateParagraphStyle.Range.EndIndex != 7 {
+		t.Errorf("heading range: %+v", heading.UpdateParagraphStyle.Range)
+	}
+	// "😀 Hi\n" spans 1..7, "See " 7..11, "this" 11..15.
+	if bold == nil || bold.UpdateTextStyle.Range.StartIndex != 11 || bold.UpdateTextStyle.Range.EndIndex != 15 {
+		t.Errorf("bold range: %+v", bold.UpdateTextStyle.Range)
+	}
+	if bullets == nil || bullets.CreateParagraphBullets.Range.StartIndex != 17 || bullets.CreateParagraphBullets.Range.EndIndex != 26 {
+		t.Errorf("bullet range: %+v", bullets.CreateParagraphBullets.Range)
+	}
+	if reqs[len(reqs)-1].CreateParagraphBullets == nil {
+		t.Error("bullets must be created after all other styling")
+	}
+}
+
+func TestMarkdownDocsRequests_BulletsLastToFirst(t *testing.T) {
+	reqs := markdownDocsRequests("- a\n  - b\n\nText\n\n1. c\n", 1, true)
+
+	if got, want := reqs[0].InsertText.Text, "a\n\tb\nText\nc\n"; got != want {
+		t.Fatalf("text = %q, want %q", got, want)
+	}
+
+	var ranges []*docs.Range
+	for _, r := range reqs {
+		if r.CreateParagraphBullets != nil {
+			ranges = append(ranges, r.CreateParagraphBullets.Range)
+		}
+	}
+	// "a\n\tb\n" spans 1..6, "Text\n" 6..11, "c\n" 11..13. The ordered list
+	// must be bulleted before the nested list drops its tab.
+	if len(ranges) != 2 || ranges[0].StartIndex != 11 || ranges[0].EndIndex != 13 ||
+		ranges[1].StartIndex != 1 || ranges[1].EndIndex != 6 {
+		t.Errorf("bullet ranges = %+v, want [11,13) then [1,6)", ranges)
+	}
+}
+
+func TestMarkdownDocsRequests_TableCells(t *testing.T) {
+	reqs := markdownDocsRequests("Intro\n\n| A | B |\n|---|---|\n| c | d |\n", 1, true)
+
+	// The table is inserted first (last block), then cells last-to-first,
+	// then the preceding text without its final newline.
+	if reqs[0].InsertTable == nil || reqs[0].InsertTable.Rows != 2 || reqs[0].InsertTable.Columns != 2 {
+		t.Fatalf("expected 2x2 InsertTable first, got %+v", reqs[0])
+	}
+
+	var cells []int64
+	var cellText []string
+	var intro string
+	for _, r := range reqs[1:] {
+		if r.InsertText == nil {
+			continue
+		}
+		if r.InsertText.Location.Index == 1 {
+			intro = r.InsertText.Text
+			continue
+		}
+		cells = append(cells, r.InsertText.Location.Index)
+		cellText = append(cellText, r.InsertText.Text)
+	}
+
+	// Table at 2: row 0 cells at 5, 7; row 1 cells at 10, 12.
+	if want := []int64{12, 10, 7, 5}; !reflect.DeepEqual(cells, want) {
+		t.Errorf("cell indexes = %v, want %v", cells, want)
+	}
+	if want := []string{"d", "c", "B", "A"}; !reflect.DeepEqual(cellText, want) {
+		t.Errorf("cell text = %v, want %v", cellText, want)
+	}
+	if intro != "Intro" {
+		t.Errorf("text before a table should drop its newline, got %q", intro)
+	}
+}
+
+func TestDocsContentRequests_Text(t *testing.T) {
+	reqs := docsContentRequests("**raw**", "text", 1, true)
+	if len(reqs) != 1 || reqs[0].InsertText.Text != "**raw**" {
+		t.Errorf("text format should insert content verbatim: %+v", reqs)
+	}
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// docsIncludeParts are the values accepted by docs cat --include.
+var docsIncludeParts = []string{"headers", "footers", "footnotes", "tabs"}
+
+// parseDocsInclude validates --include values, which may be repeated or
+// comma-separated.
+func parseDocsInclude(values []string) (map[string]bool, error) {
+	include := make(map[string]bool)
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			if part == "" {
+				continue
+			}
+			if !slices.Contains(docsIncludeParts, part) {
+				return nil, fmt.Errorf("unsupported --include value %q; use %s", part, strings.Join(docsIncludeParts, ", "))
+			}
+			include[part] = true
+		}
+	}
+
+	return include, nil
+}
+
+// docsPart is the content of a whole document or of one of its tabs.
+type docsPart struct {
+	Body      *docs.Body
+	Headers   map[string]docs.Header
+	Footers   map[string]docs.Footer
+	Footnotes map[string]docs.Footnote
+	Resources docResources
+}
+
+func docsPartOf(doc *docs.Document) docsPart {
+	return docsPart{
+		Body:      doc.Body,
+		Headers:   doc.Headers,
+		Footers:   doc.Footers,
+		Footnotes: doc.Footnotes,
+		Resources: docResources{Lists: doc.Lists, Footnotes: doc.Footnotes, InlineObjects: doc.InlineObjects},
+	}
+}
+
+func docsTabPart(t *docs.DocumentTab) docsPart {
+	return docsPart{
+		Body:      t.Body,
+		Headers:   t.Headers,
+		Footers:   t.Footers,
+		Footnotes: t.Footnotes,
+		Resources: docResources{Lists: t.Lists, Footnotes: t.Footnotes, InlineObjects: t.InlineObjects},
+	}
+}
+
+// flattenDocsTabs returns tabs and their child tabs in document order.
+func flattenDocsTabs(tabs []*docs.Tab) []*docs.Tab {
+	var out []*docs.Tab
+	for _, t := range tabs {
+		out = append(out, t)
+		out = append(out, flattenDocsTabs(t.ChildTabs)...)
+	}
+
+	return out
+}
+
+// docsSegment is a header, footer or footnote in docs cat output.
+type docsSegment struct {
+	ID      string `json:"id"`
+	Number  string `json:"number,omitempty"`
+	Content string `json:"content"`
+}
+
+// docsSuggestion is a pending suggested insertion or deletion.
+type docsSuggestion struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// docsContentRenderer renders document content in one output format.
+type docsContentRenderer struct {
+	format      string
+	suggestions bool
+}
+
+func (r docsContentRenderer) render(content []*docs.StructuralElement, res docResources) string {
+	if r.format == "markdown" {
+		return renderMarkdown(content, res, r.suggestions)
+	}
+
+	return docsText(content, r.suggestions)
+}
+
+// renderSegment renders header, footer and footnote content as a single
+// trimmed string.
+func (r docsContentRenderer) renderSegment(content []*docs.StructuralElement, res docResources) string {
+	return strings.TrimSpace(r.render(content, res))
+}
+
+// partFields returns the content of part and the parts requested by include,
+// sharing one byte budget across all of them.
+func (r docsContentRenderer) partFields(part docsPart, include map[string]bool, budget *textBudget) map[string]any {
+	var body []*docs.StructuralElement
+	if part.Body != nil {
+		body = part.Body.Content
+	}
+
+	fields := map[string]any{
+		"content": budget.take(r.render(body, part.Resources)),
+	}
+	sources := [][]*docs.StructuralElement{body}
+
+	if include["headers"] {
+		headers := []docsSegment{}
+		for _, id := range sortedKeys(part.Headers) {
+			h := part.Headers[id]
+			headers = append(headers, docsSegment{ID: id, Content: budget.take(r.renderSegment(h.Content, part.Resources))})
+			sources = append(sources, h.Content)
+		}
+		fields["headers"] = headers
+	}
+
+	if include["footers"] {
+		footers := []docsSegment{}
+		for _, id := range sortedKeys(part.Footers) {
+			f := part.Footers[id]
+			footers = append(footers, docsSegment{ID: id, Content: budget.take(r.renderSegment(f.Content, part.Resources))})
+			sources = append(sources, f.Content)
+		}
+		fields["footers"] = footers
+	}
+
+	if include["footnotes"] {
+		footnotes := []docsSegment{}
+		for _, ref := range docsFootnoteOrder(body, part.Footnotes) {
+			f := part.Footnotes[ref.FootnoteId]
+			footnotes = append(footnotes, docsSegment{
+				ID:      ref.FootnoteId,
+				Number:  ref.FootnoteNumber,
+				Content: budget.take(r.renderSegment(f.Content, part.Resources)),
+			})
+			sources = append(sources, f.Content)
+		}
+		fields["footnotes"] = footnotes
+	}
+
+	if r.suggestions {
+		fields["suggestions"] = docsSuggestions(sources...)
+	}
+
+	return fields
+}
+
+// tabFields returns the output entry for one tab.
+func (r docsContentRenderer) tabFields(t *docs.Tab, include map[string]bool, budget *textBudget) map[string]any {
+	fields := r.partFields(docsTabPart(t.DocumentTab), include, budget)
+	if p := t.TabProperties; p != nil {
+		fields["tab_id"] = p.TabId
+		fields["title"] = p.Title
+		fields["nesting_level"] = p.NestingLevel
+		if p.ParentTabId != "" {
+			fields["parent_tab_id"] = p.ParentTabId
+		}
+	}
+
+	return fields
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// docsFootnoteOrder returns footnote references in the order they appear in
+// content. Footnotes without a reference follow, ordered by ID.
+func docsFootnoteOrder(content []*docs.StructuralElement, footnotes map[string]docs.Footnote) []*docs.FootnoteReference {
+	var refs []*docs.FootnoteReference
+	seen := make(map[string]bool)
+	walkDocsParagraphs(content, func(p *docs.Paragraph) {
+		for _, pe := range p.Elements {
+			fr := pe.FootnoteReference
+			if fr == nil || seen[fr.FootnoteId] {
+				continue
+			}
+			if _, ok := footnotes[fr.FootnoteId]; !ok {
+				continue
+			}
+			seen[fr.FootnoteId] = true
+			refs = append(refs, fr)
+		}
+	})
+
+	for _, id := range sortedKeys(footnotes) {
+		if !seen[id] {
+			refs = append(refs, &docs.FootnoteReference{FootnoteId: id})
+		}
+	}
+
+	return refs
+}
+
+// walkDocsParagraphs calls fn for every paragraph in content, including
+// paragraphs inside table cells.
+func walkDocsParagraphs(content []*docs.StructuralElement, fn func(*docs.Paragraph)) {
+	for _, elem := range content {
+		switch {
+		case elem.Paragraph != nil:
+			fn(elem.Paragraph)
+		case elem.Table != nil:
+			for _, row := range elem.Table.TableRows {
+				for _, cell := range row.TableCells {
+					walkDocsParagraphs(cell.Content, fn)
+				}
+			}
+		}
+	}
+}
+
+// docsSuggestionKind returns "insertion" or "deletion" for a text run that is
+// part of a pending suggestion, or "" otherwise.
+func docsSuggestionKind(tr *docs.TextRun) string {
+	switch {
+	case len(tr.SuggestedDeletionIds) > 0:
+		return "deletion"
+	case len(tr.SuggestedInsertionIds) > 0:
+		return "insertion"
+	}
+
+	return ""
+}
+
+// suggestionMarkup wraps text in CriticMarkup for the given suggestion kind.
+func suggestionMarkup(text, kind string) string {
+	switch kind {
+	case "insertion":
+		return "{++" + text + "++}"
+	case "deletion":
+		return "{--" + text + "--}"
+	}
+
+	return text
+}
+
+// docsSuggestions groups suggested text runs by suggestion ID, in order of
+// first appearance.
+func docsSuggestions(sources ...[]*docs.StructuralElement) []docsSuggestion {
+	out := []docsSuggestion{}
+	index := make(map[string]int)
+	add := func(id, kind, text string) {
+		key := kind + "\x00" + id
+		if i, ok := index[key]; ok {
+			out[i].Text += text
+			return
+		}
+		index[key] = len(out)
+		out = append(out, docsSuggestion{ID: id, Type: kind, Text: text})
+	}
+
+	for _, content := range sources {
+		walkDocsParagraphs(content, func(p *docs.Paragraph) {
+			for _, pe := range p.Elements {
+				tr := pe.TextRun
+				if tr == nil {
+					continue
+				}
+				for _, id := range tr.SuggestedInsertionIds {
+					add(id, "insertion", tr.Content)
+				}
+				for _, id := range tr.SuggestedDeletionIds {
+					add(id, "deletion", tr.Content)
+				}
+			}
+		})
+	}
+
+	return out
+}
+
+// textBudget applies one --max-bytes limit across several output strings.
+type textBudget struct {
+	// remaining is negative when there is no limit.
+	remaining int
+	truncated bool
+}
+
+func newTextBudget(maxBytes int) *textBudget {
+	if maxBytes <= 0 {
+		return &textBudget{remaining: -1}
+	}
+
+	return &textBudget{remaining: maxBytes}
+}
+
+// take returns as much of s as the budget allows.
+func (b *textBudget) take(s string) string {
+	if b.remaining < 0 || s == "" {
+		return s
+	}
+	if b.remaining == 0 {
+		b.truncated = true
+		return ""
+	}
+
+	text, truncated := truncateText(s, b.remaining)
+	b.remaining -= len(text)
+	b.truncated = b.truncated || truncated
+
+	return text
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func TestParseDocsInclude(t *testing.T) {
+	got, err := parseDocsInclude([]string{"headers, Footnotes", "tabs"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]bool{"headers": true, "footnotes": true, "tabs": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := parseDocsInclude([]string{"comments"}); err == nil {
+		t.Error("expected error for unsupported part")
+	}
+}
+
+func TestDocsPartFields_HeadersFootnotes(t *testing.T) {
+	doc := &docs.Document{
+		Headers: map[string]docs.Header{
+			"h.2": {Content: []*docs.StructuralElement{mdPara("", mdRun("Second\n", nil))}},
+			"h.1": {Content: []*docs.StructuralElement{mdPara("", mdRun("Confidential\n", nil))}},
+		},
+		Footnotes: map[string]docs.Footnote{
+			"fn.b": {Content: []*docs.StructuralElement{mdPara("", mdRun(" Later note\n", nil))}},
+			"fn.a": {Content: []*docs.StructuralElement{mdPara("", mdRun(" First note\n", nil))}},
+		},
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			mdPara("",
+				mdRun("Text", nil),
+				&docs.ParagraphElement{FootnoteReference: &docs.FootnoteReference{FootnoteId: "fn.b", FootnoteNumber: "1"}},
+				&docs.ParagraphElement{FootnoteReference: &docs.FootnoteReference{FootnoteId: "fn.a", FootnoteNumber: "2"}},
+				mdRun("\n", nil),
+			),
+		}},
+	}
+
+	r := docsContentRenderer{format: "text"}
+	fields := r.partFields(docsPartOf(doc), map[string]bool{"headers": true, "footnotes": true}, newTextBudget(0))
+
+	if fields["content"] != "Text\n" {
+		t.Errorf("content = %q", fields["content"])
+	}
+	wantHeaders := []docsSegment{{ID: "h.1", Content: "Confidential"}, {ID: "h.2", Content: "Second"}}
+	if !reflect.DeepEqual(fields["headers"], wantHeaders) {
+		t.Errorf("headers = %+v", fields["headers"])
+	}
+	// Footnotes follow reference order, not ID order.
+	wantNotes := []docsSegment{{ID: "fn.b", Number: "1", Content: "Later note"}, {ID: "fn.a", Number: "2", Content: "First note"}}
+	if !reflect.DeepEqual(fields["footnotes"], wantNotes) {
+		t.Errorf("footnotes = %+v", fields["footnotes"])
+	}
+	if _, ok := fields["footers"]; ok {
+		t.Error("footers were not requested")
+	}
+}
+
+func TestDocsTabFields(t *testing.T) {
+	tab := func(id, title string, children ...*docs.Tab) *docs.Tab {
+		return &docs.Tab{
+			TabProperties: &docs.TabProperties{TabId: id, Title: title},
+			DocumentTab: &docs.DocumentTab{Body: &docs.Body{Content: []*docs.StructuralElement{
+				mdPara("", mdRun(title+" body\n", nil)),
+			}}},
+			ChildTabs: children,
+		}
+	}
+	child := tab("t.child", "Child")
+	child.TabProperties.ParentTabId = "t.0"
+	child.TabProperties.NestingLevel = 1
+
+	tabs := flattenDocsTabs([]*docs.Tab{tab("t.0", "Main", child), tab("t.1", "Appendix")})
+	var ids []string
+	for _, tb := range tabs {
+		ids = append(ids, tb.TabProperties.TabId)
+	}
+	if want := []string{"t.0", "t.child", "t.1"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("tab order = %v, want %v", ids, want)
+	}
+
+	r := docsContentRenderer{format: "markdown"}
+	fields := r.tabFields(tabs[1], nil, newTextBudget(0))
+	if fields["tab_id"] != "t.child" || fields["parent_tab_id"] != "t.0" || fields["nesting_level"] != int64(1) {
+		t.Errorf("tab properties: %+v", fields)
+	}
+	if fields["content"] != "Child body\n" {
+		t.Errorf("content = %q", fields["content"])
+	}
+}
+
+func TestDocsSuggestions(t *testing.T) {
+	ins := &docs.TextRun{Content: "new ", SuggestedInsertionIds: []string{"s1"}}
+	del := &docs.TextRun{Content: "old", SuggestedDeletionIds: []string{"s2"}}
+	content := []*docs.StructuralElement{{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+		mdRun("Keep ", nil),
+		{TextRun: ins},
+		{TextRun: del},
+		mdRun(" text\n", nil),
+	}}}}
+
+	if got, want := docsText(content, true), "Keep {++new ++}{--old--} text\n"; got != want {
+		t.Errorf("text: got %q, want %q", got, want)
+	}
+	if got, want := docsText(content, false), "Keep new old text\n"; got != want {
+		t.Errorf("merged text: got %q, want %q", got, want)
+	}
+	if got, want := renderMarkdown(content, docResources{}, true), "Keep {++new++} {--old--} text\n"; got != want {
+		t.Errorf("markdown: got %q, want %q", got, want)
+	}
+
+	want := []docsSuggestion{{ID: "s1", Type: "insertion", Text: "new "}, {ID: "s2", Type: "deletion", Text: "old"}}
+	if got := docsSuggestions(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestions = %+v", got)
+	}
+}
+
+func TestTextBudget(t *testing.T) {
+	b := newTextBudget(6)
+	if got := b.take("abcd"); got != "abcd" || b.truncated {
+		t.Fatalf("first take: %q truncated=%v", got, b.truncated)
+	}
+	if got := b.take("efgh"); got != "ef" || !b.truncated {
+		t.Errorf("second take: %q truncated=%v", got, b.truncated)
+	}
+	if got := b.take("ij"); got != "" {
+		t.Errorf("exhausted budget should return empty, got %q", got)
+	}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+var errAmbiguousHeading = errors.New("heading appears more than once")
+
+// docsSection is a heading and the content under it, up to the next heading
+// of the same or a higher level.
+type docsSection struct {
+	// HeadingEnd is the index just after the heading paragraph.
+	HeadingEnd int64
+	// End is the exclusive end of the section content.
+	End int64
+}
+
+// docsHeadingLevel returns 0 for TITLE, 1-6 for HEADING_n, and -1 otherwise.
+func docsHeadingLevel(p *docs.Paragraph) int {
+	if p == nil || p.ParagraphStyle == nil {
+		return -1
+	}
+
+	style := p.ParagraphStyle.NamedStyleType
+	if style == "TITLE" {
+		return 0
+	}
+	if n, ok := strings.CutPrefix(style, "HEADING_"); ok && len(n) == 1 && n[0] >= '1' && n[0] <= '6' {
+		return int(n[0] - '0')
+	}
+
+	return -1
+}
+
+// docsParagraphText returns the text of a paragraph without its final newline.
+func docsParagraphText(p *docs.Paragraph) string {
+	var sb strings.Builder
+	for _, pe := range p.Elements {
+		if pe.TextRun != nil {
+			sb.WriteString(pe.TextRun.Content)
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// findDocsSection locates the section under the heading whose text equals
+// heading (surrounding whitespace ignored). The heading must be unique.
+func findDocsSection(doc *docs.Document, heading string) (docsSection, error) {
+	if doc.Body == nil {
+		return docsSection{}, fmt.Errorf("heading %q not found", heading)
+	}
+
+	want := strings.TrimSpace(heading)
+	content := doc.Body.Content
+
+	found := -1
+	for i, elem := range content {
+		if docsHeadingLevel(elem.Paragraph) < 0 {
+			continue
+		}
+		if strings.TrimSpace(docsParagraphText(elem.Paragraph)) != want {
+			continue
+		}
+		if found >= 0 {
+			return docsSection{}, fmt.Errorf("heading %q: %w", heading, errAmbiguousHeading)
+		}
+		found = i
+	}
+	if found < 0 {
+		return docsSection{}, fmt.Errorf("heading %q not found", heading)
+	}
+
+	level := docsHeadingLevel(content[found].Paragraph)
+	sec := docsSection{HeadingEnd: content[found].EndIndex, End: docBodyLength(doc)}
+	for _, elem := range content[found+1:] {
+		if l := docsHeadingLevel(elem.Paragraph); l >= 0 && l <= level {
+			sec.End = elem.StartIndex
+			break
+		}
+	}
+
+	return sec, nil
+}
+
+// docsLastParagraphEmpty reports whether the body ends with an empty paragraph.
+func docsLastParagraphEmpty(doc *docs.Document) bool {
+	if doc.Body == nil || len(doc.Body.Content) == 0 {
+		return true
+	}
+
+	last := doc.Body.Content[len(doc.Body.Content)-1]
+
+	return last.Paragraph != nil && docsParagraphText(last.Paragraph) == ""
+}
+
+// docsAppendRequests inserts content as new paragraphs at the end of the body.
+func docsAppendRequests(doc *docs.Document, content, format string) []*docs.Request {
+	end := docBodyLength(doc)
+	if docsLastParagraphEmpty(doc) {
+		return docsContentRequests(content, format, end-1, false)
+	}
+
+	// Break the last paragraph, then fill the new empty paragraph. The new
+	// paragraph inherits the last one's style, which may be a heading.
+	requests := []*docs.Request{
+		{InsertText: &docs.InsertTextRequest{Text: "\n", Location: &docs.Location{Index: end - 1}}},
+		normalParagraphRequest(end),
+	}
+
+	return append(requests, docsContentRequests(content, format, end, false)...)
+}
+
+// normalParagraphRequest resets the paragraph containing index at to normal text.
+func normalParagraphRequest(at int64) *docs.Request {
+	return &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+		Range:          &docs.Range{StartIndex: at, EndIndex: at + 1},
+		ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"},
+		Fields:         "namedStyleType",
+	}}
+}
+
+// docsInsertParagraphsRequests inserts content as whole paragraphs before the
+// paragraph starting at index at.
+func docsInsertParagraphsRequests(content, format string, at int64) []*docs.Request {
+	if format != "markdown" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	return docsContentRequests(content, format, at, true)
+}
+
+// docsAfterHeadingRequests inserts content directly below heading.
+func docsAfterHeadingRequests(doc *docs.Document, heading, content, format string) ([]*docs.Request, error) {
+	sec, err := findDocsSection(doc, heading)
+	if err != nil {
+		return nil, err
+	}
+
+	if sec.HeadingEnd >= docBodyLength(doc) {
+		// The heading is the last paragraph.
+		return docsAppendRequests(doc, content, format), nil
+	}
+
+	return docsInsertParagraphsRequests(content, format, sec.HeadingEnd), nil
+}
+
+// docsReplaceSectionRequests replaces the content under heading, keeping the
+// heading itself. Empty content clears the section.
+func docsReplaceSectionRequests(doc *docs.Document, heading, content, format string) ([]*docs.Request, error) {
+	sec, err := findDocsSection(doc, heading)
+	if err != nil {
+		return nil, err
+	}
+
+	end := docBodyLength(doc)
+	if sec.HeadingEnd >= end {
+		// Nothing under the heading yet.
+		if strings.TrimSpace(content) == "" {
+			return nil, nil
+		}
+		return docsAppendRequests(doc, content, format), nil
+	}
+
+	if sec.End < end {
+		var requests []*docs.Request
+		if sec.End > sec.HeadingEnd {
+			requests = append(requests, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: sec.HeadingEnd, EndIndex: sec.End},
+			}})
+		}
+		if strings.TrimSpace(content) == "" {
+			return requests, nil
+		}
+		return append(requests, docsInsertParagraphsRequests(content, format, sec.HeadingEnd)...), nil
+	}
+
+	// The section runs to the end of the body. The final newline cannot be
+	// deleted, so keep one empty paragraph and fill it.
+	var requests []*docs.Request
+	if end-1 > sec.HeadingEnd {
+		requests = append(requests, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: sec.HeadingEnd, EndIndex: end - 1},
+		}})
+	}
+	requests = append(requests, normalParagraphRequest(sec.HeadingEnd))
+	if strings.TrimSpace(content) == "" {
+		return requests, nil
+	}
+
+	return append(requests, docsContentRequests(content, format, sec.HeadingEnd, false)...), nil
+}
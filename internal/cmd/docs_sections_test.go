@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// sectionDoc builds a body from (style, text) pairs with consecutive indexes.
+func sectionDoc(paras ...[2]string) *docs.Document {
+	var content []*docs.StructuralElement
+	idx := int64(1)
+	for _, p := range paras {
+		text := p[1] + "\n"
+		end := idx + utf16Len(text)
+		content = append(content, &docs.StructuralElement{
+			StartIndex: idx,
+			EndIndex:   end,
+			Paragraph: &docs.Paragraph{
+				ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: p[0]},
+				Elements:       []*docs.ParagraphElement{{TextRun: &docs.TextRun{Content: text}}},
+			},
+		})
+		idx = end
+	}
+
+	return &docs.Document{Body: &docs.Body{Content: content}}
+}
+
+func TestFindDocsSection(t *testing.T) {
+	doc := sectionDoc(
+		[2]string{"HEADING_1", "Log"},     // 1..5
+		[2]string{"HEADING_2", "Weekly"},  // 5..12
+		[2]string{"NORMAL_TEXT", "old"},   // 12..16
+		[2]string{"HEADING_3", "Detail"},  // 16..23
+		[2]string{"NORMAL_TEXT", "more"},  // 23..28
+		[2]string{"HEADING_2", "Monthly"}, // 28..36
+		[2]string{"NORMAL_TEXT", "keep"},  // 36..41
+	)
+
+	sec, err := findDocsSection(doc, " Weekly ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Sub-headings belong to the section; the next HEADING_2 ends it.
+	if sec.HeadingEnd != 12 || sec.End != 28 {
+		t.Errorf("got %+v, want {12 28}", sec)
+	}
+
+	sec, err = findDocsSection(doc, "Monthly")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sec.HeadingEnd != 36 || sec.End != 41 {
+		t.Errorf("last section: got %+v, want {36 41}", sec)
+	}
+
+	if _, err := findDocsSection(doc, "old"); err == nil {
+		t.Error("normal paragraphs must not match as headings")
+	}
+
+	dup := sectionDoc([2]string{"HEADING_2", "A"}, [2]string{"HEADING_2", "A"})
+	if _, err := findDocsSection(dup, "A"); !errors.Is(err, errAmbiguousHeading) {
+		t.Errorf("expected errAmbiguousHeading, got %v", err)
+	}
+}
+
+func TestDocsAppendRequests(t *testing.T) {
+	doc := sectionDoc([2]string{"HEADING_1", "Log"}, [2]string{"NORMAL_TEXT", "entry"}) // ends at 11
+	reqs := docsAppendRequests(doc, "new entry\n", "text")
+
+	if len(reqs) != 3 {
+		t.Fatalf("expected break, style reset and insert, got %d requests", len(reqs))
+	}
+	if reqs[0].InsertText.Text != "\n" || reqs[0].InsertText.Location.Index != 10 {
+		t.Errorf("paragraph break: %+v", reqs[0].InsertText)
+	}
+	if got := reqs[2].InsertText; got.Text != "new entry" || got.Location.Index != 11 {
+		t.Errorf("content insert: %+v", got)
+	}
+
+	empty := sectionDoc([2]string{"NORMAL_TEXT", ""})
+	reqs = docsAppendRequests(empty, "first", "text")
+	if len(reqs) != 1 || reqs[0].InsertText.Location.Index != 1 {
+		t.Errorf("empty document should fill the only paragraph: %+v", reqs)
+	}
+}
+
+func TestDocsReplaceSectionRequests(t *testing.T) {
+	doc := sectionDoc(
+		[2]string{"HEADING_2", "Weekly"},  // 1..8
+		[2]string{"NORMAL_TEXT", "old"},   // 8..12
+		[2]string{"HEADING_2", "Monthly"}, // 12..20
+		[2]string{"NORMAL_TEXT", "keep"},  // 20..25
+	)
+
+	reqs, err := docsReplaceSectionRequests(doc, "Weekly", "new", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := reqs[0].DeleteContentRange.Range; r.StartIndex != 8 || r.EndIndex != 12 {
+		t.Errorf("delete range: %+v", r)
+	}
+	if got := reqs[1].InsertText; got.Text != "new\n" || got.Location.Index != 8 {
+		t.Errorf("insert: %+v", got)
+	}
+
+	// The last section cannot delete the body's final newline.
+	reqs, err = docsReplaceSectionRequests(doc, "Monthly", "new", "text")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := reqs[0].DeleteContentRange.Range; r.StartIndex != 20 || r.EndIndex != 24 {
+		t.Errorf("delete range at end: %+v", r)
+	}
+	if got := reqs[len(reqs)-1].InsertText; got.Text != "new" || got.Location.Index != 20 {
+		t.Errorf("insert at end: %+v", got)
+	}
+}
+
+func TestDocsWriteModesAreExclusive(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &DocsWriteCmd{
+		Account:      "a@example.com",
+		DocID:        "doc-123",
+		Content:      "hello",
+		Append:       true,
+		AfterHeading: "Log",
+	}
+	var err error
+	stderr := captureStderr(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err == nil {
+		t.Fatal("expected error when several write modes are set")
+	}
+	if output.ExitCode(err) != output.ExitCodeError {
+		t.Fatalf("expected ExitCodeError, got %d", output.ExitCode(err))
+	}
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err2 := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &payload); err2 != nil {
+		t.Fatalf("parse stderr JSON: %v (got %q)", err2, stderr)
+	}
+	if payload.Code != "invalid_write_mode" {
+		t.Errorf("code = %q, want %q", payload.Code, "invalid_write_mode")
+	}
+}
+
+func TestDocsWriteReplaceSectionRequiresConfirmation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &DocsWriteCmd{
+		Account:        "a@example.com",
+		DocID:          "doc-123",
+		Content:        "hello",
+		ReplaceSection: "Weekly",
+	}
+	var err error
+	stderr := captureStderr(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: false})
+	})
+	if err == nil {
+		t.Fatal("expected error when --replace-section is set without --confirm-replace")
+	}
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err2 := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &payload); err2 != nil {
+		t.Fatalf("parse stderr JSON: %v (got %q)", err2, stderr)
+	}
+	if payload.Code != "replace_requires_confirmation" {
+		t.Errorf("code = %q, want %q", payload.Code, "replace_requires_confirmation")
+	}
+}
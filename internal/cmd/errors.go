@@ -16,15 +16,20 @@ func isAuthErr(err error, target **googleapi.AuthRequiredError) bool {
 
 // writeGoogleAPIError maps common Google API HTTP errors to exit codes.
 func writeGoogleAPIError(defaultCode string, err error) error {
+	return writeGoogleAPIErrorDetails(defaultCode, err, nil)
+}
+
+// writeGoogleAPIErrorDetails is writeGoogleAPIError with error details.
+func writeGoogleAPIErrorDetails(defaultCode string, err error, details map[string]any) error {
 	var apiErr *gapi.Error
 	if errors.As(err, &apiErr) {
 		switch apiErr.Code {
 		case http.StatusNotFound:
-			return output.WriteError(output.ExitCodeNotFound, "not_found", err.Error())
+			return output.WriteErrorDetails(output.ExitCodeNotFound, "not_found", err.Error(), details)
 		case http.StatusForbidden:
-			return output.WriteError(output.ExitCodePermission, "permission_denied", err.Error())
+			return output.WriteErrorDetails(output.ExitCodePermission, "permission_denied", err.Error(), details)
 		}
 	}
 
-	return output.WriteError(output.ExitCodeError, defaultCode, err.Error())
+	return output.WriteErrorDetails(output.ExitCodeError, defaultCode, err.Error(), details)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -68,3 +69,24 @@ func withMutedStderr(t *testing.T, fn func() error) error {
 
 	return runErr
 }
+
+func TestWriteGoogleAPIErrorDetails(t *testing.T) {
+	var err error
+	stderr := captureStderr(t, func() {
+		err = writeGoogleAPIErrorDetails("import_error", &gapi.Error{Code: 404, Message: "gone"}, map[string]any{"rows_written": 500})
+	})
+	if got := output.ExitCode(err); got != output.ExitCodeNotFound {
+		t.Errorf("exit code = %d, want %d", got, output.ExitCodeNotFound)
+	}
+
+	var payload struct {
+		Code    string         `json:"code"`
+		Details map[string]any `json:"details"`
+	}
+	if jsonErr := json.Unmarshal([]byte(stderr), &payload); jsonErr != nil {
+		t.Fatalf("stderr is not JSON: %v\n%s", jsonErr, stderr)
+	}
+	if payload.Code != "not_found" || payload.Details["rows_written"] != float64(500) {
+		t.Errorf("payload = %+v, want not_found with rows_written 500", payload)
+	}
+}
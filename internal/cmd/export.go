@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// stdoutOutput is the --output value that streams an export to stdout.
+const stdoutOutput = "-"
+
+var errExportTooLarge = errors.New("export exceeds --max-bytes")
+
+// exportRequest is an export of the current content of a Google file.
+type exportRequest struct {
+	account   string
+	fileID    string
+	format    string
+	output    string
+	overwrite bool
+	maxBytes  int64
+	// sheet limits a spreadsheet export to the sheet with this title.
+	sheet string
+	// pageID limits a presentation image export to one slide.
+	pageID string
+	// rangeA1 exports the cell values of a spreadsheet range instead.
+	rangeA1 string
+}
+
+// export writes fileID in the requested format to a file or to stdout. When
+// streaming to stdout no JSON result is printed, so the output stays a clean
+// byte stream for pipelines.
+func (k fileKind) export(ctx context.Context, root *RootFlags, req exportRequest) error {
+	action := k.service + ".export"
+	if err := enforceActionPolicy(req.account, action); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+	if req.output != stdoutOutput {
+		if err := ensureWithinAllowedOutputDir(req.output, root.AllowedOutputDir); err != nil {
+			return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
+		}
+	}
+
+	format := strings.ToLower(req.format)
+	mimeType, ok := k.formats[format]
+	fromValues := req.rangeA1 != "" || (!ok && k.valueFormats[format])
+	switch {
+	case fromValues && !k.valueFormats[format]:
+		return output.WriteError(output.ExitCodeError, "invalid_arguments",
+			fmt.Sprintf("--range requires %s format", strings.Join(sortedKeys(k.valueFormats), ", ")))
+	case !fromValues && !ok:
+		return output.WriteError(output.ExitCodeError, "invalid_format",
+			fmt.Sprintf("unsupported format %q; use %s", req.format, k.formatNames()))
+	case fromValues && req.rangeA1 != "" && req.sheet != "":
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--range and --sheet cannot be combined")
+	case fromValues && req.rangeA1 == "" && req.sheet == "":
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", format+" export requires --range or --sheet")
+	case !fromValues && req.sheet != "" && format != "csv" && format != "tsv" && format != "pdf":
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--sheet requires csv, tsv or pdf format")
+	}
+	if fromValues && req.rangeA1 == "" {
+		req.rangeA1 = quoteSheetName(req.sheet)
+		req.sheet = ""
+	}
+	if req.pageID != "" && format != "png" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--page-id requires png format")
+	}
+	if req.maxBytes < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--max-bytes must not be negative")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  action,
+			Account: normalizeEmail(req.account),
+			Target:  req.output,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		params := map[string]any{
+			"account":   req.account,
+			k.idKey:     req.fileID,
+			"format":    format,
+			"output":    req.output,
+			"overwrite": req.overwrite,
+			"max_bytes": req.maxBytes,
+		}
+		if req.sheet != "" {
+			params["sheet"] = req.sheet
+		}
+		if req.pageID != "" {
+			params["page_id"] = req.pageID
+		}
+		if req.rangeA1 != "" {
+			params["range"] = req.rangeA1
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"params":  params,
+		})
+	}
+
+	var body io.ReadCloser
+	var size int64
+	var err error
+	if fromValues {
+		var b []byte
+		if b, err = exportRangeValues(ctx, req, format); err == nil {
+			body, size = io.NopCloser(bytes.NewReader(b)), int64(len(b))
+		}
+	} else {
+		var resp *http.Response
+		if resp, err = k.download(ctx, req, format, mimeType); err == nil {
+			body, size = resp.Body, resp.ContentLength
+		}
+	}
+	if err != nil {
+		var authErr *googleapi.AuthRequiredError
+		if isAuthErr(err, &authErr) {
+			return k.authError(err)
+		}
+		return writeGoogleAPIError(k.service+"_export_error", err)
+	}
+	defer body.Close()
+
+	if req.maxBytes > 0 && size > req.maxBytes {
+		return output.WriteError(output.ExitCodeError, "export_too_large",
+			fmt.Sprintf("export is %d bytes, over --max-bytes %d", size, req.maxBytes))
+	}
+
+	written, err := writeExportOutput(body, req)
+	if errors.Is(err, errExportTooLarge) {
+		return output.WriteError(output.ExitCodeError, "export_too_large",
+			fmt.Sprintf("export exceeds --max-bytes %d; nothing was written", req.maxBytes))
+	}
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_write_error", fmt.Sprintf("write output: %v", err))
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  action,
+		Account: normalizeEmail(req.account),
+		Target:  req.output,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	if req.output == stdoutOutput {
+		return nil
+	}
+
+	result := map[string]any{
+		"exported":      true,
+		k.idKey:         req.fileID,
+		"format":        format,
+		"output":        req.output,
+		"bytes_written": written,
+	}
+	if req.sheet != "" {
+		result["sheet"] = req.sheet
+	}
+	if req.pageID != "" {
+		result["page_id"] = req.pageID
+	}
+	if req.rangeA1 != "" {
+		result["range"] = req.rangeA1
+	}
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// download starts the export. Drive's export endpoint always covers the whole
+// file (or only the first sheet or slide for single-page formats), so a
+// single sheet or slide is fetched from the editor's export URL instead.
+func (k fileKind) download(ctx context.Context, req exportRequest, format, mimeType string) (*http.Response, error) {
+	if req.sheet == "" && req.pageID == "" {
+		svc, err := googleapi.NewDriveReadOnly(ctx, req.account)
+		if err != nil {
+			return nil, err
+		}
+		return svc.Files.Export(req.fileID, mimeType).Context(ctx).Download()
+	}
+
+	client, err := googleapi.NewDriveReadOnlyHTTPClient(ctx, req.account)
+	if err != nil {
+		return nil, err
+	}
+
+	var link string
+	if req.sheet != "" {
+		gid, err := sheetIDByTitle(ctx, req.account, req.fileID, req.sheet)
+		if err != nil {
+			return nil, err
+		}
+		link = fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/export?format=%s&gid=%d",
+			url.PathEscape(req.fileID), format, gid)
+	} else {
+		link = fmt.Sprintf("https://docs.google.com/presentation/d/%s/export/png?pageid=%s",
+			url.PathEscape(req.fileID), url.QueryEscape(req.pageID))
+	}
+
+	return downloadURL(ctx, client, link, "export")
+}
+
+// exportRangeValues reads the cells of req.rangeA1 as displayed and encodes
+// them as CSV, TSV or JSON Lines (one array per row).
+func exportRangeValues(ctx context.Context, req exportRequest, format string) ([]byte, error) {
+	svc, err := googleapi.NewSheetsReadOnly(ctx, req.account)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := svc.Spreadsheets.Values.Get(req.fileID, req.rangeA1).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeValues(resp.Values, format)
+}
+
+// encodeValues writes rows as csv, tsv or jsonl.
+func encodeValues(values [][]any, format string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if format == "jsonl" {
+		enc := json.NewEncoder(&buf)
+		for _, row := range values {
+			if row == nil {
+				row = []any{}
+			}
+			if err := enc.Encode(row); err != nil {
+				return nil, fmt.Errorf("encode row: %w", err)
+			}
+		}
+		return buf.Bytes(), nil
+	}
+
+	w := csv.NewWriter(&buf)
+	if format == "tsv" {
+		w.Comma = '\t'
+	}
+	for _, row := range values {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = cellString(v)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("encode row: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("encode rows: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sheetIDByTitle returns the numeric ID (gid) of the sheet named title.
+func sheetIDByTitle(ctx context.Context, account, spreadsheetID, title string) (int64, error) {
+	svc, err := googleapi.NewSheetsReadOnly(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+
+	props, err := readSheetProperties(svc, spreadsheetID)
+	if err != nil {
+		return 0, err
+	}
+	sheet, err := findSheet(props, title)
+	if err != nil {
+		return 0, err
+	}
+
+	return sheet.SheetId, nil
+}
+
+// writeExportOutput copies an export to req.output. With a size cap, stdout
+// output is buffered first so an oversize export writes nothing; a file is
+// written atomically and left untouched on failure.
+func writeExportOutput(body io.Reader, req exportRequest) (int64, error) {
+	src := body
+	if req.maxBytes > 0 {
+		src = &cappedReader{r: body, remaining: req.maxBytes}
+	}
+
+	if req.output != stdoutOutput {
+		return writeFileAtomically(req.output, src, req.overwrite)
+	}
+	if req.maxBytes <= 0 {
+		return io.Copy(os.Stdout, src)
+	}
+
+	b, err := io.ReadAll(src)
+	if err != nil {
+		return 0, err
+	}
+	n, err := os.Stdout.Write(b)
+
+	return int64(n), err
+}
+
+// cappedReader fails with errExportTooLarge once more than remaining bytes
+// have been read.
+type cappedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if c.remaining < 0 {
+		return n, errExportTooLarge
+	}
+
+	return n, err
+}
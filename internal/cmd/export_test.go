@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteExportOutput_MaxBytes(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "fits.txt")
+	n, err := writeExportOutput(strings.NewReader("12345"), exportRequest{output: path, maxBytes: 5})
+	if err != nil || n != 5 {
+		t.Fatalf("export at the limit: n=%d err=%v", n, err)
+	}
+
+	path = filepath.Join(dir, "too-big.txt")
+	_, err = writeExportOutput(strings.NewReader("123456"), exportRequest{output: path, maxBytes: 5})
+	if !errors.Is(err, errExportTooLarge) {
+		t.Fatalf("err = %v, want errExportTooLarge", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("oversize export left a file behind: %v", statErr)
+	}
+}
+
+func TestWriteExportOutput_Stdout(t *testing.T) {
+	var err error
+	stdout := captureStdout(t, func() {
+		_, err = writeExportOutput(strings.NewReader("a,b\n1,2\n"), exportRequest{output: stdoutOutput})
+	})
+	if err != nil || stdout != "a,b\n1,2\n" {
+		t.Fatalf("stdout = %q, err = %v", stdout, err)
+	}
+
+	// A capped stdout export is buffered, so an oversize one prints nothing.
+	stdout = captureStdout(t, func() {
+		_, err = writeExportOutput(strings.NewReader("0123456789"), exportRequest{output: stdoutOutput, maxBytes: 4})
+	})
+	if !errors.Is(err, errExportTooLarge) || stdout != "" {
+		t.Errorf("stdout = %q, err = %v", stdout, err)
+	}
+}
+
+func TestDocsExportCmd_StdoutDryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// Streaming to stdout writes no file, so --allowed-output-dir does not apply.
+	cmd := &DocsExportCmd{Account: "a@example.com", DocID: "doc-123", Format: "Markdown", Output: "-", MaxBytes: 1024}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true, AllowedOutputDir: t.TempDir()})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string         `json:"action"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "docs.export" || payload.Params["format"] != "markdown" || payload.Params["max_bytes"] != float64(1024) {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+}
+
+func TestExportCmds_ScopeFlagsRequireMatchingFormat(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{DryRun: true}
+	sheetsCmd := &SheetsExportCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Format: "xlsx", Sheet: "Data", Output: "-"}
+	if code, _ := runForCode(t, func() error { return sheetsCmd.Run(context.Background(), root) }); code != "invalid_arguments" {
+		t.Errorf("sheets --sheet with xlsx: code = %q", code)
+	}
+
+	slidesCmd := &SlidesExportCmd{Account: "a@example.com", PresentationID: "p-1", Format: "pdf", PageID: "g1", Output: "-"}
+	if code, _ := runForCode(t, func() error { return slidesCmd.Run(context.Background(), root) }); code != "invalid_arguments" {
+		t.Errorf("slides --page-id with pdf: code = %q", code)
+	}
+
+	slidesCmd = &SlidesExportCmd{Account: "a@example.com", PresentationID: "p-1", Format: "gif", Output: "-"}
+	if code, _ := runForCode(t, func() error { return slidesCmd.Run(context.Background(), root) }); code != "invalid_format" {
+		t.Errorf("slides gif: code = %q", code)
+	}
+}
+
+func TestSheetsExportCmd_RangeRequiresValueFormat(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{DryRun: true}
+	for _, bad := range []SheetsExportCmd{
+		{Format: "xlsx", Range: "A1:B2"},
+		{Format: "csv", Range: "A1:B2", Sheet: "Data"},
+		{Format: "jsonl"},
+	} {
+		cmd := bad
+		cmd.Account, cmd.SpreadsheetID, cmd.Output = "a@example.com", "ss-1", "-"
+		if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), root) }); code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q", bad, code)
+		}
+	}
+
+	cmd := &SheetsExportCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Format: "jsonl", Sheet: "Bob's data", Output: "-"}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), root)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var payload struct {
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	// A whole-sheet jsonl export reads the sheet as a range.
+	if payload.Params["range"] != "'Bob''s data'" || payload.Params["sheet"] != nil {
+		t.Errorf("unexpected dry-run params: %+v", payload.Params)
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	values := [][]any{{"name", "note"}, {"Bob", "says \"hi\", twice"}, nil}
+
+	got, err := encodeValues(values, "csv")
+	if err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if want := "name,note\nBob,\"says \"\"hi\"\", twice\"\n\n"; string(got) != want {
+		t.Errorf("csv = %q, want %q", got, want)
+	}
+
+	got, err = encodeValues(values, "jsonl")
+	if err != nil {
+		t.Fatalf("jsonl: %v", err)
+	}
+	if want := "[\"name\",\"note\"]\n[\"Bob\",\"says \\\"hi\\\", twice\"]\n[]\n"; string(got) != want {
+		t.Errorf("jsonl = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/slides/v1"
+)
+
+// replacePair is one find/replace instruction.
+type replacePair struct {
+	Find    string `json:"find"`
+	Replace string `json:"replace"`
+}
+
+// replacePairResult reports how many occurrences a pair changed.
+type replacePairResult struct {
+	Find        string `json:"find"`
+	Replace     string `json:"replace"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+// parseReplacePairs decodes pairs from either a JSON object mapping find text
+// to replacement text, or an array of {"find","replace"} objects. Pairs keep
+// the order they were written in, since literal pairs are applied in turn.
+func parseReplacePairs(data string) ([]replacePair, error) {
+	trimmed := strings.TrimSpace(data)
+
+	var pairs []replacePair
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &pairs); err != nil {
+			return nil, fmt.Errorf("parse pairs JSON: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+			return nil, fmt.Errorf("pairs must be a JSON object or array")
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("parse pairs JSON: %w", err)
+			}
+			var replace string
+			if err := dec.Decode(&replace); err != nil {
+				return nil, fmt.Errorf("replacement for %q must be a string", tok)
+			}
+			pairs = append(pairs, replacePair{Find: tok.(string), Replace: replace})
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("parse pairs JSON: %w", err)
+		}
+	}
+
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("pairs must contain at least one entry")
+	}
+	for _, p := range pairs {
+		if p.Find == "" {
+			return nil, fmt.Errorf("pairs contain an empty find text")
+		}
+	}
+
+	return pairs, nil
+}
+
+// replacePairsFrom returns the pairs given by --find/--replace or by a
+// --pairs file. Exactly one of the two forms must be used.
+func replacePairsFrom(find, replace, pairsPath string) ([]replacePair, string, error) {
+	switch {
+	case find != "" && pairsPath != "":
+		return nil, "invalid_arguments", fmt.Errorf("--find and --pairs cannot be combined")
+	case find != "":
+		return []replacePair{{Find: find, Replace: replace}}, "", nil
+	case pairsPath == "":
+		return nil, "invalid_arguments", fmt.Errorf("either --find or --pairs is required")
+	}
+
+	data, err := readInputFile(pairsPath)
+	if err != nil {
+		return nil, "file_read_error", err
+	}
+	pairs, err := parseReplacePairs(data)
+	if err != nil {
+		return nil, "invalid_values", err
+	}
+
+	return pairs, "", nil
+}
+
+// compileReplacePatterns compiles each find text as a regular expression.
+func compileReplacePatterns(pairs []replacePair, matchCase bool) ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(pairs))
+	for _, p := range pairs {
+		expr := p.Find
+		if !matchCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p.Find, err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	return patterns, nil
+}
+
+func replacePairResults(pairs []replacePair, counts []int64) []replacePairResult {
+	out := make([]replacePairResult, 0, len(pairs))
+	for i, p := range pairs {
+		out = append(out, replacePairResult{Find: p.Find, Replace: p.Replace, Occurrences: counts[i]})
+	}
+
+	return out
+}
+
+func sumOccurrences(counts []int64) int64 {
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	return total
+}
+
+// textSegment is a stretch of text whose indexes are contiguous. start is the
+// UTF-16 index of its first character within the owning object.
+type textSegment struct {
+	objectID string
+	cell     *slides.TableCellLocation
+	text     string
+	start    int64
+}
+
+// textMatch is a regex match converted to UTF-16 indexes.
+type textMatch struct {
+	segment     *textSegment
+	start, end  int64
+	replacement string
+}
+
+// regexMatches finds every pattern in segments. All pairs see the original
+// text; a match that overlaps one claimed by an earlier pair is skipped.
+// Empty matches are ignored. Matches come back last-first per object, the
+// order in which deleting and inserting them keeps earlier indexes valid.
+func regexMatches(segments []*textSegment, pairs []replacePair, patterns []*regexp.Regexp) ([]textMatch, []int64) {
+	counts := make([]int64, len(pairs))
+	var matches []textMatch
+
+	for i, re := range patterns {
+		for _, seg := range segments {
+			for _, m := range re.FindAllStringSubmatchIndex(seg.text, -1) {
+				if m[0] == m[1] {
+					continue
+				}
+				tm := textMatch{
+					segment:     seg,
+					start:       seg.start + utf16Len(seg.text[:m[0]]),
+					end:         seg.start + utf16Len(seg.text[:m[1]]),
+					replacement: string(re.ExpandString(nil, pairs[i].Replace, seg.text, m)),
+				}
+				if slices.ContainsFunc(matches, tm.overlaps) {
+					continue
+				}
+				matches = append(matches, tm)
+				counts[i]++
+			}
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b textMatch) int {
+		if a.segment.objectID != b.segment.objectID {
+			return strings.Compare(a.segment.objectID, b.segment.objectID)
+		}
+		return int(b.start - a.start)
+	})
+
+	return matches, counts
+}
+
+func (m textMatch) overlaps(o textMatch) bool {
+	return sameTextObject(m.segment, o.segment) && m.start < o.end && o.start < m.end
+}
+
+func sameTextObject(a, b *textSegment) bool {
+	if a.objectID != b.objectID {
+		return false
+	}
+	if a.cell == nil || b.cell == nil {
+		return a.cell == b.cell
+	}
+
+	return a.cell.RowIndex == b.cell.RowIndex && a.cell.ColumnIndex == b.cell.ColumnIndex
+}
+
+// docsDocumentSegments returns the text of the body, headers, footers and
+// footnotes of doc, the same parts ReplaceAllText reaches.
+func docsDocumentSegments(doc *docs.Document) []*textSegment {
+	var segments []*textSegment
+	if doc.Body != nil {
+		segments = append(segments, docsTextSegments("", doc.Body.Content)...)
+	}
+	for _, id := range sortedKeys(doc.Headers) {
+		segments = append(segments, docsTextSegments(id, doc.Headers[id].Content)...)
+	}
+	for _, id := range sortedKeys(doc.Footers) {
+		segments = append(segments, docsTextSegments(id, doc.Footers[id].Content)...)
+	}
+	for _, id := range sortedKeys(doc.Footnotes) {
+		segments = append(segments, docsTextSegments(id, doc.Footnotes[id].Content)...)
+	}
+
+	return segments
+}
+
+// docsTextSegments returns the text of content in contiguous runs. segmentID
+// names the header, footer or footnote that holds content ("" for the body).
+// Paragraph breaks and non-text elements end a segment so that a pattern
+// never deletes them.
+func docsTextSegments(segmentID string, content []*docs.StructuralElement) []*textSegment {
+	var segments []*textSegment
+	var cur *textSegment
+	flush := func() {
+		if cur != nil && cur.text != "" {
+			segments = append(segments, cur)
+		}
+		cur = nil
+	}
+
+	var walk func([]*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, elem := range content {
+			if elem.Table != nil {
+				for _, row := range elem.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+				continue
+			}
+			if elem.Paragraph == nil {
+				continue
+			}
+			for _, pe := range elem.Paragraph.Elements {
+				if pe.TextRun == nil {
+					flush()
+					continue
+				}
+				text := strings.TrimSuffix(pe.TextRun.Content, "\n")
+				if cur == nil || cur.start+utf16Len(cur.text) != pe.StartIndex {
+					flush()
+					cur = &textSegment{objectID: segmentID, start: pe.StartIndex}
+				}
+				cur.text += text
+				if len(text) != len(pe.TextRun.Content) {
+					flush()
+				}
+			}
+			flush()
+		}
+	}
+	walk(content)
+
+	return segments
+}
+
+// docsRegexRequests turns matches into delete-then-insert requests.
+func docsRegexRequests(matches []textMatch) []*docs.Request {
+	var requests []*docs.Request
+	for _, m := range matches {
+		requests = append(requests, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{SegmentId: m.segment.objectID, StartIndex: m.start, EndIndex: m.end},
+		}})
+		if m.replacement != "" {
+			requests = append(requests, &docs.Request{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{SegmentId: m.segment.objectID, Index: m.start},
+				Text:     m.replacement,
+			}})
+		}
+	}
+
+	return requests
+}
+
+// slidesTextSegments returns the shape and table cell text on the slides of a
+// presentation. Paragraph breaks and auto text such as slide numbers end a
+// segment.
+func slidesTextSegments(pages []*slides.Page) []*textSegment {
+	var segments []*textSegment
+
+	addText := func(objectID string, cell *slides.TableCellLocation, text *slides.TextContent) {
+		if text == nil {
+			return
+		}
+		var cur *textSegment
+		for _, te := range text.TextElements {
+			if te.TextRun == nil {
+				if te.AutoText != nil {
+					cur = nil
+				}
+				continue
+			}
+			content := strings.TrimSuffix(te.TextRun.Content, "\n")
+			if cur == nil || cur.start+utf16Len(cur.text) != te.StartIndex {
+				cur = &textSegment{objectID: objectID, cell: cell, start: te.StartIndex}
+				segments = append(segments, cur)
+			}
+			cur.text += content
+			if len(content) != len(te.TextRun.Content) {
+				cur = nil
+			}
+		}
+	}
+
+	var walk func([]*slides.PageElement)
+	walk = func(elements []*slides.PageElement) {
+		for _, elem := range elements {
+			switch {
+			case elem.Shape != nil:
+				addText(elem.ObjectId, nil, elem.Shape.Text)
+			case elem.Table != nil:
+				for r, row := range elem.Table.TableRows {
+					for col, cell := range row.TableCells {
+						addText(elem.ObjectId, &slides.TableCellLocation{RowIndex: int64(r), ColumnIndex: int64(col)}, cell.Text)
+					}
+				}
+			case elem.ElementGroup != nil:
+				walk(elem.ElementGroup.Children)
+			}
+		}
+	}
+	for _, page := range pages {
+		walk(page.PageElements)
+	}
+
+	return segments
+}
+
+// slidesRegexRequests turns matches into delete-then-insert requests.
+func slidesRegexRequests(matches []textMatch) []*slides.Request {
+	var requests []*slides.Request
+	for _, m := range matches {
+		start, end := m.start, m.end
+		requests = append(requests, &slides.Request{DeleteText: &slides.DeleteTextRequest{
+			ObjectId:     m.segment.objectID,
+			CellLocation: m.segment.cell,
+			TextRange:    &slides.Range{Type: "FIXED_RANGE", StartIndex: &start, EndIndex: &end},
+		}})
+		if m.replacement != "" {
+			requests = append(requests, &slides.Request{InsertText: &slides.InsertTextRequest{
+				ObjectId:       m.segment.objectID,
+				CellLocation:   m.segment.cell,
+				InsertionIndex: m.start,
+				Text:           m.replacement,
+			}})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/slides/v1"
+)
+
+func TestParseReplacePairs(t *testing.T) {
+	// Object form keeps the written order, not key order.
+	got, err := parseReplacePairs(`{"zeta": "Z", "alpha": "A"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []replacePair{{Find: "zeta", Replace: "Z"}, {Find: "alpha", Replace: "A"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("object: got %+v, want %+v", got, want)
+	}
+
+	got, err = parseReplacePairs(`[{"find": "a", "replace": "b"}, {"find": "a", "replace": "c"}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[1].Replace != "c" {
+		t.Errorf("array: got %+v", got)
+	}
+
+	for _, bad := range []string{`{}`, `[]`, `"x"`, `{"a": 1}`, `{"": "x"}`, `[{"replace": "x"}]`} {
+		if _, err := parseReplacePairs(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestReplacePairsFrom(t *testing.T) {
+	if _, code, err := replacePairsFrom("", "", ""); err == nil || code != "invalid_arguments" {
+		t.Errorf("no input: code=%q err=%v", code, err)
+	}
+	if _, code, err := replacePairsFrom("a", "b", "pairs.json"); err == nil || code != "invalid_arguments" {
+		t.Errorf("both inputs: code=%q err=%v", code, err)
+	}
+	pairs, _, err := replacePairsFrom("a", "", "")
+	if err != nil || !reflect.DeepEqual(pairs, []replacePair{{Find: "a"}}) {
+		t.Errorf("single pair: %+v err=%v", pairs, err)
+	}
+}
+
+func TestRegexMatches_Docs(t *testing.T) {
+	content := []*docs.StructuralElement{
+		{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: 1, TextRun: &docs.TextRun{Content: "日付 2024-01-05 "}},
+			{StartIndex: 15, InlineObjectElement: &docs.InlineObjectElement{}},
+			{StartIndex: 16, TextRun: &docs.TextRun{Content: "id-7\n"}},
+		}}},
+		{Table: &docs.Table{TableRows: []*docs.TableRow{{TableCells: []*docs.TableCell{{
+			Content: []*docs.StructuralElement{{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+				{StartIndex: 25, TextRun: &docs.TextRun{Content: "2023-12-31\n"}},
+			}}}},
+		}}}}}},
+	}
+
+	segments := docsTextSegments("", content)
+	var texts []string
+	for _, s := range segments {
+		texts = append(texts, s.text)
+	}
+	if want := []string{"日付 2024-01-05 ", "id-7", "2023-12-31"}; !reflect.DeepEqual(texts, want) {
+		t.Fatalf("segments = %q, want %q", texts, want)
+	}
+
+	pairs := []replacePair{
+		{Find: `(\d{4})-(\d{2})-(\d{2})`, Replace: "$3/$2/$1"},
+		{Find: `\d+`, Replace: "N"}, // overlaps the dates, which the first pair claimed
+	}
+	patterns, err := compileReplacePatterns(pairs, true)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	matches, counts := regexMatches(segments, pairs, patterns)
+	if !reflect.DeepEqual(counts, []int64{2, 1}) {
+		t.Errorf("counts = %v", counts)
+	}
+
+	requests := docsRegexRequests(matches)
+	// Last match first: the table date, then "7", then the body date. "日付 "
+	// is three UTF-16 units, so the body date starts at index 4.
+	got := []int64{
+		requests[0].DeleteContentRange.Range.StartIndex,
+		requests[2].DeleteContentRange.Range.StartIndex,
+		requests[4].DeleteContentRange.Range.StartIndex,
+	}
+	if want := []int64{25, 19, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("delete starts = %v, want %v", got, want)
+	}
+	if ins := requests[5].InsertText; ins.Location.Index != 4 || ins.Text != "05/01/2024" {
+		t.Errorf("insert = %+v", ins)
+	}
+}
+
+func TestDocsDocumentSegments_HeadersFootersFootnotes(t *testing.T) {
+	para := func(start int64, text string) []*docs.StructuralElement {
+		return []*docs.StructuralElement{{Paragraph: &docs.Paragraph{Elements: []*docs.ParagraphElement{
+			{StartIndex: start, TextRun: &docs.TextRun{Content: text + "\n"}},
+		}}}}
+	}
+	doc := &docs.Document{
+		Body:      &docs.Body{Content: para(1, "v1 body")},
+		Headers:   map[string]docs.Header{"h1": {Content: para(0, "v1 header")}},
+		Footers:   map[string]docs.Footer{"f1": {Content: para(0, "v1 footer")}},
+		Footnotes: map[string]docs.Footnote{"fn1": {Content: para(0, "v1 note")}},
+	}
+
+	pairs := []replacePair{{Find: `v1`, Replace: "v2"}}
+	patterns, err := compileReplacePatterns(pairs, true)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	matches, counts := regexMatches(docsDocumentSegments(doc), pairs, patterns)
+	if counts[0] != 4 {
+		t.Fatalf("counts = %v, want 4 matches across body, header, footer and footnote", counts)
+	}
+
+	segmentIDs := map[string]int64{}
+	for _, r := range docsRegexRequests(matches) {
+		if r.DeleteContentRange != nil {
+			segmentIDs[r.DeleteContentRange.Range.SegmentId] = r.DeleteContentRange.Range.StartIndex
+		}
+	}
+	if want := map[string]int64{"": 1, "h1": 0, "f1": 0, "fn1": 0}; !reflect.DeepEqual(segmentIDs, want) {
+		t.Errorf("delete segments = %v, want %v", segmentIDs, want)
+	}
+}
+
+func TestRegexMatches_SlidesTableCells(t *testing.T) {
+	cell := func(text string) *slides.TableCell {
+		return &slides.TableCell{Text: &slides.TextContent{TextElements: []*slides.TextElement{
+			{ParagraphMarker: &slides.ParagraphMarker{}},
+			{TextRun: &slides.TextRun{Content: text + "\n"}, EndIndex: int64(len(text) + 1)},
+		}}}
+	}
+	pages := []*slides.Page{{PageElements: []*slides.PageElement{
+		{ObjectId: "group", ElementGroup: &slides.Group{Children: []*slides.PageElement{
+			{ObjectId: "table", Table: &slides.Table{TableRows: []*slides.TableRow{
+				{TableCells: []*slides.TableCell{cell("Draft"), cell("draft v2")}},
+			}}},
+		}}},
+	}}}
+
+	pairs := []replacePair{{Find: "draft", Replace: ""}}
+	patterns, err := compileReplacePatterns(pairs, false)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	matches, counts := regexMatches(slidesTextSegments(pages), pairs, patterns)
+	if counts[0] != 2 {
+		t.Fatalf("counts = %v", counts)
+	}
+
+	// Both matches sit at index 0 of different cells, so neither is dropped
+	// as an overlap, and an empty replacement needs no insert.
+	requests := slidesRegexRequests(matches)
+	if len(requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(requests))
+	}
+	del := requests[1].DeleteText
+	if del.ObjectId != "table" || del.CellLocation.ColumnIndex != 1 || *del.TextRange.EndIndex != 5 {
+		t.Errorf("delete = %+v range=%d-%d", del, *del.TextRange.StartIndex, *del.TextRange.EndIndex)
+	}
+}
+
+func TestDocsFindReplaceCmd_PairsDryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	pairsFile := filepath.Join(t.TempDir(), "pairs.json")
+	if err := os.WriteFile(pairsFile, []byte(`{"Foo": "Bar", "v(\\d)": "version $1"}`), 0o600); err != nil {
+		t.Fatalf("write pairs: %v", err)
+	}
+
+	cmd := &DocsFindReplaceCmd{Account: "a@example.com", DocID: "doc-123", Pairs: pairsFile, Regex: true}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Params struct {
+			Pairs []replacePair `json:"pairs"`
+			Regex bool          `json:"regex"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if len(payload.Params.Pairs) != 2 || payload.Params.Pairs[1].Find != `v(\d)` || !payload.Params.Regex {
+		t.Errorf("unexpected params: %+v", payload.Params)
+	}
+}
+
+func TestSlidesWriteCmd_InvalidRegex(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SlidesWriteCmd{Account: "a@example.com", PresentationID: "pres-123", Find: "(unclosed", Regex: true}
+	code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{DryRun: true}) })
+	if code != "invalid_arguments" {
+		t.Errorf("code = %q, want invalid_arguments", code)
+	}
+}
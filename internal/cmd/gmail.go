@@ -110,13 +110,14 @@ func (c *GmailGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 
 // GmailSendCmd sends an email.
 type GmailSendCmd struct {
-	Account   string `name:"account" required:"" short:"a" help:"Google account email."`
-	To        string `name:"to" required:"" help:"Recipient email address."`
-	Subject   string `name:"subject" required:"" help:"Email subject."`
-	Body      string `name:"body" help:"Email body."`
-	BodyStdin bool   `name:"body-stdin" help:"Read email body from stdin."`
-	CC        string `name:"cc" help:"CC email addresses (comma-separated)."`
-	BCC       string `name:"bcc" help:"BCC email addresses (comma-separated)."`
+	Account           string `name:"account" required:"" short:"a" help:"Google account email."`
+	To                string `name:"to" required:"" help:"Recipient email address."`
+	Subject           string `name:"subject" required:"" help:"Email subject."`
+	Body              string `name:"body" help:"Email body."`
+	BodyStdin         bool   `name:"body-stdin" help:"Read email body from stdin."`
+	CC                string `name:"cc" help:"CC email addresses (comma-separated)."`
+	BCC               string `name:"bcc" help:"BCC email addresses (comma-separated)."`
+	ResolveRecipients bool   `name:"resolve-recipients" help:"Resolve recipients given as names (no @) to addresses via Google Contacts; fails if a name is ambiguous."`
 }
 
 func (c *GmailSendCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -135,14 +136,40 @@ func (c *GmailSendCmd) Run(ctx context.Context, root *RootFlags) error {
 		body = s
 	}
 
+	to, cc, bcc := c.To, c.CC, c.BCC
+	var resolved []resolvedRecipient
+	if c.ResolveRecipients && (needsResolution(to) || needsResolution(cc) || needsResolution(bcc)) {
+		if err := enforceActionPolicy(c.Account, "contacts.search"); err != nil {
+			return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+		}
+
+		contactsSvc, err := googleapi.NewContactsReadOnly(ctx, c.Account)
+		if err != nil {
+			return contactsAuthError(err)
+		}
+		search := newContactSearcher(ctx, contactsSvc, 10)
+
+		for _, list := range []*string{&to, &cc, &bcc} {
+			value, r, code, err := resolveRecipientList(*list, search)
+			if err != nil {
+				if code == "contacts_search_error" {
+					return writeGoogleAPIError(code, err)
+				}
+				return output.WriteError(output.ExitCodeError, code, err.Error())
+			}
+			*list = value
+			resolved = append(resolved, r...)
+		}
+	}
+
 	for _, hv := range []struct {
 		name  string
 		value string
 	}{
 		{name: "account", value: c.Account},
-		{name: "to", value: c.To},
-		{name: "cc", value: c.CC},
-		{name: "bcc", value: c.BCC},
+		{name: "to", value: to},
+		{name: "cc", value: cc},
+		{name: "bcc", value: bcc},
 		{name: "subject", value: c.Subject},
 	} {
 		if err := validateHeaderValue(hv.name, hv.value); err != nil {
@@ -153,9 +180,9 @@ func (c *GmailSendCmd) Run(ctx context.Context, root *RootFlags) error {
 		name  string
 		value string
 	}{
-		{name: "to", value: c.To},
-		{name: "cc", value: c.CC},
-		{name: "bcc", value: c.BCC},
+		{name: "to", value: to},
+		{name: "cc", value: cc},
+		{name: "bcc", value: bcc},
 	} {
 		if err := validateAddressList(av.name, av.value); err != nil {
 			return output.WriteError(output.ExitCodeError, "invalid_recipient", err.Error())
@@ -172,14 +199,14 @@ func (c *GmailSendCmd) Run(ctx context.Context, root *RootFlags) error {
 
 	var headers strings.Builder
 	headers.WriteString("From: " + c.Account + "\r\n")
-	headers.WriteString("To: " + c.To + "\r\n")
+	headers.WriteString("To: " + to + "\r\n")
 
-	if c.CC != "" {
-		headers.WriteString("Cc: " + c.CC + "\r\n")
+	if cc != "" {
+		headers.WriteString("Cc: " + cc + "\r\n")
 	}
 
-	if c.BCC != "" {
-		headers.WriteString("Bcc: " + c.BCC + "\r\n")
+	if bcc != "" {
+		headers.WriteString("Bcc: " + bcc + "\r\n")
 	}
 
 	headers.WriteString("Subject: " + c.Subject + "\r\n")
@@ -198,18 +225,23 @@ func (c *GmailSendCmd) Run(ctx context.Context, root *RootFlags) error {
 	if err := appendAuditLog(root.AuditLog, auditEntry{
 		Action:  "gmail.draft",
 		Account: normalizeEmail(c.Account),
-		Target:  c.To,
+		Target:  to,
 		DryRun:  false,
 	}); err != nil {
 		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
 	}
 
-	return output.WriteJSON(os.Stdout, map[string]any{
+	result := map[string]any{
 		"draft_id":   draft.Id,
 		"message_id": draft.Message.Id,
 		"thread_id":  draft.Message.ThreadId,
 		"saved":      true,
-	})
+	}
+	if resolved != nil {
+		result["resolved_recipients"] = resolved
+	}
+
+	return output.WriteJSON(os.Stdout, result)
 }
 
 func validateHeaderValue(name, value string) error {
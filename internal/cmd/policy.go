@@ -9,9 +9,19 @@ import (
 
 var defaultApprovalActions = []string{
 	"calendar.delete",
+	"calendar.delete.series",
 	"docs.write.replace",
+	"docs.write.replace_section",
 	"docs.find_replace",
 	"slides.write",
+	"docs.restore",
+	"sheets.restore",
+	"slides.restore",
+	"sheets.tab.delete",
+	"sheets.clear",
+	"sheets.rows.delete",
+	"slides.delete_slide",
+	"tasks.delete",
 }
 
 func enforceActionPolicy(account, action string) error {
@@ -54,6 +54,14 @@ func TestActionRequiresApproval_Default(t *testing.T) {
 		t.Fatal("calendar.delete should require approval by default")
 	}
 
+	required, err = actionRequiresApproval("docs.write.replace_section")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !required {
+		t.Fatal("docs.write.replace_section should require approval by default")
+	}
+
 	required, err = actionRequiresApproval("gmail.search")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// parseTemplateValues decodes a flat JSON object of placeholder values.
+// Numbers and booleans are inserted as written; null becomes an empty string.
+func parseTemplateValues(data string) (map[string]string, error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parse values JSON: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("values must be a JSON object with at least one key")
+	}
+
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("values contain an empty key")
+		}
+		switch tv := v.(type) {
+		case nil:
+			values[k] = ""
+		case string:
+			values[k] = tv
+		case json.Number:
+			values[k] = tv.String()
+		case bool:
+			values[k] = fmt.Sprintf("%t", tv)
+		default:
+			return nil, fmt.Errorf("value for %q must be a string, number, boolean or null", k)
+		}
+	}
+
+	return values, nil
+}
+
+// templatePlaceholder returns the text replaced for key.
+func templatePlaceholder(key string) string {
+	return "{{" + key + "}}"
+}
+
+const (
+	mimeGoogleDocs   = "application/vnd.google-apps.document"
+	mimeGoogleSlides = "application/vnd.google-apps.presentation"
+)
+
+// errTemplateType reports a template that is not the kind of file the render
+// command fills, e.g. a presentation passed to docs render.
+var errTemplateType = errors.New("template has the wrong file type")
+
+// copyTemplate copies templateID with Drive after checking that it is a
+// mimeType file, so a wrong ID does not leave an unusable copy behind. An
+// empty title keeps Drive's default "Copy of ..." name.
+func copyTemplate(ctx context.Context, account, templateID, mimeType, title string) (*drive.File, error) {
+	svc, err := googleapi.NewDriveWrite(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := svc.Files.Get(templateID).Fields("id,mimeType").Do()
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.MimeType != mimeType {
+		return nil, fmt.Errorf("%w: %s is %s, want %s", errTemplateType, templateID, tmpl.MimeType, mimeType)
+	}
+
+	return svc.Files.Copy(templateID, &drive.File{Name: title}).Fields("id,name").Do()
+}
+
+// renderCopyError maps a copyTemplate failure to a command error.
+func renderCopyError(code string, authError func(error) error, err error) error {
+	var authErr *googleapi.AuthRequiredError
+	if isAuthErr(err, &authErr) {
+		return authError(err)
+	}
+	if errors.Is(err, errTemplateType) {
+		return output.WriteError(output.ExitCodeError, "invalid_template", err.Error())
+	}
+
+	return writeGoogleAPIError(code, err)
+}
+
+// templateRenderParams are the dry-run params shared by docs and slides render.
+func templateRenderParams(account, templateID, title string, values map[string]string) map[string]any {
+	return map[string]any{
+		"account":      account,
+		"template_id":  templateID,
+		"title":        title,
+		"placeholders": placeholdersOf(values),
+	}
+}
+
+func placeholdersOf(values map[string]string) []string {
+	keys := sortedKeys(values)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, templatePlaceholder(k))
+	}
+
+	return out
+}
+
+// DocsRenderCmd copies a document template and fills its placeholders.
+type DocsRenderCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	TemplateID string `name:"template-id" required:"" help:"Template document ID."`
+	Values     string `name:"values" required:"" help:"JSON file of {{key}} values (- for stdin)."`
+	Title      string `name:"title" help:"Title of the new document (default: Drive's \"Copy of\" name)."`
+}
+
+func (c *DocsRenderCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "docs.render"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	data, err := readInputFile(c.Values)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+	}
+	values, err := parseTemplateValues(data)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "docs.render",
+			Account: normalizeEmail(c.Account),
+			Target:  c.TemplateID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "docs.render",
+			"params":  templateRenderParams(c.Account, c.TemplateID, c.Title, values),
+		})
+	}
+
+	docSvc, err := googleapi.NewDocsWrite(ctx, c.Account)
+	if err != nil {
+		return docsAuthError(err)
+	}
+
+	copied, err := copyTemplate(ctx, c.Account, c.TemplateID, mimeGoogleDocs, c.Title)
+	if err != nil {
+		return renderCopyError("docs_render_error", docsAuthError, err)
+	}
+	// The copy is recorded as soon as it exists, so it is audited even if
+	// filling the placeholders fails and the copy is kept.
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "docs.render",
+		Account: normalizeEmail(c.Account),
+		Target:  copied.Id,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	keys := sortedKeys(values)
+	requests := make([]*docs.Request, 0, len(keys))
+	for _, k := range keys {
+		requests = append(requests, &docs.Request{ReplaceAllText: &docs.ReplaceAllTextRequest{
+			ContainsText: &docs.SubstringMatchCriteria{Text: templatePlaceholder(k), MatchCase: true},
+			ReplaceText:  values[k],
+		}})
+	}
+
+	resp, err := docSvc.Documents.BatchUpdate(copied.Id, &docs.BatchUpdateDocumentRequest{Requests: requests}).Do()
+	if err != nil {
+		return writeGoogleAPIError("docs_render_error",
+			fmt.Errorf("fill placeholders in copy %s (the copy was kept): %w", copied.Id, err))
+	}
+
+	replacements := make(map[string]int64, len(keys))
+	for i, k := range keys {
+		if resp != nil && i < len(resp.Replies) && resp.Replies[i].ReplaceAllText != nil {
+			replacements[k] = resp.Replies[i].ReplaceAllText.OccurrencesChanged
+		} else {
+			replacements[k] = 0
+		}
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"rendered":     true,
+		"doc_id":       copied.Id,
+		"title":        copied.Name,
+		"template_id":  c.TemplateID,
+		"replacements": replacements,
+	})
+}
+
+// SlidesRenderCmd copies a presentation template and fills its placeholders.
+type SlidesRenderCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	TemplateID string `name:"template-id" required:"" help:"Template presentation ID."`
+	Values     string `name:"values" required:"" help:"JSON file of {{key}} values (- for stdin)."`
+	Title      string `name:"title" help:"Title of the new presentation (default: Drive's \"Copy of\" name)."`
+}
+
+func (c *SlidesRenderCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.render"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	data, err := readInputFile(c.Values)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+	}
+	values, err := parseTemplateValues(data)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.render",
+			Account: normalizeEmail(c.Account),
+			Target:  c.TemplateID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.render",
+			"params":  templateRenderParams(c.Account, c.TemplateID, c.Title, values),
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	copied, err := copyTemplate(ctx, c.Account, c.TemplateID, mimeGoogleSlides, c.Title)
+	if err != nil {
+		return renderCopyError("slides_render_error", slidesAuthError, err)
+	}
+	// The copy is recorded as soon as it exists, so it is audited even if
+	// filling the placeholders fails and the copy is kept.
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.render",
+		Account: normalizeEmail(c.Account),
+		Target:  copied.Id,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	keys := sortedKeys(values)
+	requests := make([]*slides.Request, 0, len(keys))
+	for _, k := range keys {
+		requests = append(requests, &slides.Request{ReplaceAllText: &slides.ReplaceAllTextRequest{
+			ContainsText: &slides.SubstringMatchCriteria{Text: templatePlaceholder(k), MatchCase: true},
+			ReplaceText:  values[k],
+		}})
+	}
+
+	resp, err := svc.Presentations.BatchUpdate(copied.Id, &slides.BatchUpdatePresentationRequest{Requests: requests}).Do()
+	if err != nil {
+		return writeGoogleAPIError("slides_render_error",
+			fmt.Errorf("fill placeholders in copy %s (the copy was kept): %w", copied.Id, err))
+	}
+
+	replacements := make(map[string]int64, len(keys))
+	for i, k := range keys {
+		if resp != nil && i < len(resp.Replies) && resp.Replies[i].ReplaceAllText != nil {
+			replacements[k] = resp.Replies[i].ReplaceAllText.OccurrencesChanged
+		} else {
+			replacements[k] = 0
+		}
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"rendered":        true,
+		"presentation_id": copied.Id,
+		"title":           copied.Name,
+		"template_id":     c.TemplateID,
+		"replacements":    replacements,
+	})
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestParseTemplateValues(t *testing.T) {
+	got, err := parseTemplateValues(`{"name":"Alice","total":1200.50,"paid":true,"note":null}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"name": "Alice", "total": "1200.50", "paid": "true", "note": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{`[]`, `{}`, `{"a":{"b":1}}`, `{"":"x"}`, `not json`} {
+		if _, err := parseTemplateValues(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestDocsRenderCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	valuesPath := filepath.Join(t.TempDir(), "values.json")
+	if err := os.WriteFile(valuesPath, []byte(`{"name":"Alice","date":"2026-04-01"}`), 0o600); err != nil {
+		t.Fatalf("write values: %v", err)
+	}
+
+	cmd := &DocsRenderCmd{Account: "a@example.com", TemplateID: "tmpl-1", Values: valuesPath, Title: "Invoice"}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			TemplateID   string   `json:"template_id"`
+			Placeholders []string `json:"placeholders"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "docs.render" || payload.Params.TemplateID != "tmpl-1" {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+	if want := []string{"{{date}}", "{{name}}"}; !reflect.DeepEqual(payload.Params.Placeholders, want) {
+		t.Errorf("placeholders = %v, want %v", payload.Params.Placeholders, want)
+	}
+}
+
+func TestSlidesRenderCmd_InvalidValues(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	valuesPath := filepath.Join(t.TempDir(), "values.json")
+	if err := os.WriteFile(valuesPath, []byte(`{"rows":[1,2]}`), 0o600); err != nil {
+		t.Fatalf("write values: %v", err)
+	}
+
+	code, _ := runForCode(t, func() error {
+		return (&SlidesRenderCmd{Account: "a@example.com", TemplateID: "tmpl-1", Values: valuesPath}).
+			Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "invalid_values" {
+		t.Errorf("code = %q, want invalid_values", code)
+	}
+}
+
+func TestRenderCopyError_WrongTemplateType(t *testing.T) {
+	err := fmt.Errorf("%w: p1 is %s, want %s", errTemplateType, mimeGoogleSlides, mimeGoogleDocs)
+	code, exit := runForCode(t, func() error {
+		return renderCopyError("docs_render_error", docsAuthError, err)
+	})
+	if code != "invalid_template" || exit != output.ExitCodeError {
+		t.Errorf("got %s (exit %d), want invalid_template", code, exit)
+	}
+}
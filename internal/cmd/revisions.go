@@ -0,0 +1,560 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+var sheetsExportMIMETypes = map[string]string{
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"ods":  "application/x-vnd.oasis.opendocument.spreadsheet",
+	"pdf":  "application/pdf",
+	"csv":  "text/csv",
+	"tsv":  "text/tab-separated-values",
+}
+
+var slidesExportMIMETypes = map[string]string{
+	"pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"odp":  "application/vnd.oasis.opendocument.presentation",
+	"pdf":  "application/pdf",
+	"txt":  "text/plain",
+	"png":  "image/png",
+}
+
+// fileKind describes how one Google file type is exported with Drive, both
+// in its current state and at earlier revisions, and how it is restored.
+type fileKind struct {
+	// service prefixes policy actions and error codes: docs, sheets or slides.
+	service string
+	// idKey is the output key for the file ID, e.g. doc_id.
+	idKey   string
+	formats map[string]string
+	// restoreFormat is the export format re-imported by restore. Drive cannot
+	// revert a Google file to a revision, so restore uploads that revision's
+	// export as the new content.
+	restoreFormat string
+	// valueFormats are written locally from cell values rather than exported
+	// by Drive; they are used when an export names a range.
+	valueFormats map[string]bool
+	authError    func(error) error
+}
+
+var (
+	docsFiles   = fileKind{service: "docs", idKey: "doc_id", formats: exportMIMETypes, restoreFormat: "docx", authError: docsAuthError}
+	sheetsFiles = fileKind{service: "sheets", idKey: "spreadsheet_id", formats: sheetsExportMIMETypes, restoreFormat: "xlsx",
+		valueFormats: map[string]bool{"csv": true, "tsv": true, "jsonl": true}, authError: sheetsAuthError}
+	slidesFiles = fileKind{service: "slides", idKey: "presentation_id", formats: slidesExportMIMETypes, restoreFormat: "pptx", authError: slidesAuthError}
+)
+
+func (k fileKind) formatNames() string {
+	names := make([]string, 0, len(k.formats))
+	for name := range k.formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// formatForMIME returns the export format name for mimeType, or "".
+func (k fileKind) formatForMIME(mimeType string) string {
+	for name, m := range k.formats {
+		if m == mimeType {
+			return name
+		}
+	}
+
+	return ""
+}
+
+type revisionRef struct {
+	ID            string   `json:"id"`
+	Modified      string   `json:"modified,omitempty"`
+	Author        string   `json:"author,omitempty"`
+	AuthorEmail   string   `json:"author_email,omitempty"`
+	KeepForever   bool     `json:"keep_forever"`
+	ExportFormats []string `json:"export_formats"`
+}
+
+func (k fileKind) toRevisionRef(r *drive.Revision) revisionRef {
+	ref := revisionRef{ID: r.Id, Modified: r.ModifiedTime, KeepForever: r.KeepForever, ExportFormats: []string{}}
+	if r.LastModifyingUser != nil {
+		ref.Author = r.LastModifyingUser.DisplayName
+		ref.AuthorEmail = r.LastModifyingUser.EmailAddress
+	}
+	for mimeType := range r.ExportLinks {
+		if name := k.formatForMIME(mimeType); name != "" {
+			ref.ExportFormats = append(ref.ExportFormats, name)
+		}
+	}
+	sort.Strings(ref.ExportFormats)
+
+	return ref
+}
+
+const driveRevisionFields = "id,modifiedTime,keepForever,lastModifyingUser(displayName,emailAddress),exportLinks"
+
+// list prints the revisions of fileID, oldest first.
+func (k fileKind) list(ctx context.Context, account, fileID string, max int64, allPages bool, page string) error {
+	action := k.service + ".revisions.list"
+	if err := enforceActionPolicy(account, action); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit(action, 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	svc, err := googleapi.NewDriveReadOnly(ctx, account)
+	if err != nil {
+		return k.authError(err)
+	}
+
+	revisions, nextPageToken, err := collectAllPages(allPages, func(pageToken string) (string, []revisionRef, error) {
+		req := svc.Revisions.List(fileID).
+			PageSize(max).
+			Fields(gapi.Field("nextPageToken,revisions(" + driveRevisionFields + ")"))
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		} else if page != "" {
+			req = req.PageToken(page)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("list revisions: %w", err)
+		}
+
+		refs := make([]revisionRef, 0, len(resp.Revisions))
+		for _, r := range resp.Revisions {
+			refs = append(refs, k.toRevisionRef(r))
+		}
+
+		return resp.NextPageToken, refs, nil
+	})
+	if err != nil {
+		return writeGoogleAPIError(k.service+"_revisions_list_error", err)
+	}
+	if revisions == nil {
+		revisions = []revisionRef{}
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		k.idKey:         fileID,
+		"revisions":     revisions,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// exportRevision writes one revision of fileID to outputPath.
+func (k fileKind) exportRevision(ctx context.Context, root *RootFlags, account, fileID, revisionID, format, outputPath string, overwrite bool) error {
+	action := k.service + ".revisions.export"
+	if err := enforceActionPolicy(account, action); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+	if err := ensureWithinAllowedOutputDir(outputPath, root.AllowedOutputDir); err != nil {
+		return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
+	}
+
+	format = strings.ToLower(format)
+	mimeType, ok := k.formats[format]
+	if !ok {
+		return output.WriteError(output.ExitCodeError, "invalid_format",
+			fmt.Sprintf("unsupported format %q; use %s", format, k.formatNames()))
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  action,
+			Account: normalizeEmail(account),
+			Target:  outputPath,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"params": map[string]any{
+				"account":     account,
+				k.idKey:       fileID,
+				"revision_id": revisionID,
+				"format":      format,
+				"output":      outputPath,
+				"overwrite":   overwrite,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewDriveReadOnly(ctx, account)
+	if err != nil {
+		return k.authError(err)
+	}
+	client, err := googleapi.NewDriveReadOnlyHTTPClient(ctx, account)
+	if err != nil {
+		return k.authError(err)
+	}
+
+	body, err := downloadRevisionExport(ctx, svc, client, fileID, revisionID, mimeType)
+	if err != nil {
+		return writeGoogleAPIError(k.service+"_revisions_export_error", err)
+	}
+	defer body.Close()
+
+	written, err := writeFileAtomically(outputPath, body, overwrite)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_write_error", fmt.Sprintf("write output file: %v", err))
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  action,
+		Account: normalizeEmail(account),
+		Target:  outputPath,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"exported":      true,
+		k.idKey:         fileID,
+		"revision_id":   revisionID,
+		"format":        format,
+		"output":        outputPath,
+		"bytes_written": written,
+	})
+}
+
+// restore replaces the content of fileID with the content of revisionID.
+func (k fileKind) restore(ctx context.Context, root *RootFlags, account, fileID, revisionID string, confirm bool, approvalToken string) error {
+	action := k.service + ".restore"
+	if err := enforceActionPolicy(account, action); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if !root.DryRun && !confirm {
+		return output.WriteError(output.ExitCodeError, "restore_requires_confirmation",
+			k.service+" restore replaces the current content and requires --confirm-restore")
+	}
+	if !root.DryRun {
+		required, err := actionRequiresApproval(action)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "policy_error", err.Error())
+		}
+		if required {
+			if err := consumeApprovalToken(account, action, approvalToken); err != nil {
+				return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
+			}
+		}
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  action,
+			Account: normalizeEmail(account),
+			Target:  fileID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"params": map[string]any{
+				"account":     account,
+				k.idKey:       fileID,
+				"revision_id": revisionID,
+				"via_format":  k.restoreFormat,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewDriveWrite(ctx, account)
+	if err != nil {
+		return k.authError(err)
+	}
+	client, err := googleapi.NewDriveReadOnlyHTTPClient(ctx, account)
+	if err != nil {
+		return k.authError(err)
+	}
+
+	previous, err := latestDriveRevisionID(svc, fileID)
+	if err != nil {
+		return writeGoogleAPIError(k.service+"_restore_error", err)
+	}
+
+	mimeType := k.formats[k.restoreFormat]
+	body, err := downloadRevisionExport(ctx, svc, client, fileID, revisionID, mimeType)
+	if err != nil {
+		return writeGoogleAPIError(k.service+"_restore_error", err)
+	}
+	defer body.Close()
+
+	if _, err := svc.Files.Update(fileID, &drive.File{}).
+		Media(body, gapi.ContentType(mimeType)).
+		Fields("id").
+		Do(); err != nil {
+		return writeGoogleAPIError(k.service+"_restore_error", err)
+	}
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:     action,
+		Account:    normalizeEmail(account),
+		Target:     fileID,
+		DryRun:     false,
+		RevisionID: previous,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"restored":             true,
+		k.idKey:                fileID,
+		"revision_id":          revisionID,
+		"previous_revision_id": previous,
+	})
+}
+
+// downloadRevisionExport fetches a revision in the given MIME type. Google
+// files have no binary content of their own, so revisions are only available
+// through the export links Drive returns for each revision.
+func downloadRevisionExport(ctx context.Context, svc *drive.Service, client *http.Client, fileID, revisionID, mimeType string) (io.ReadCloser, error) {
+	rev, err := svc.Revisions.Get(fileID, revisionID).Fields("id,exportLinks").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	link, ok := rev.ExportLinks[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("revision %s cannot be exported as %s", revisionID, mimeType)
+	}
+
+	resp, err := downloadURL(ctx, client, link, "export")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// downloadURL starts the download of link, such as a Drive export link or a
+// rendered slide. what names the download in errors; a status other than 200
+// is returned as a *googleapi.Error so that it maps to an exit code.
+func downloadURL(ctx context.Context, client *http.Client, link, what string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build %s request: %w", what, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download %s: %w", what, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &gapi.Error{Code: resp.StatusCode, Message: "download " + what + ": " + resp.Status}
+	}
+
+	return resp, nil
+}
+
+// latestDriveRevisionID returns the ID of the newest revision of fileID.
+func latestDriveRevisionID(svc *drive.Service, fileID string) (string, error) {
+	latest := ""
+	pageToken := ""
+	for {
+		req := svc.Revisions.List(fileID).PageSize(1000).Fields("nextPageToken,revisions(id)")
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", err
+		}
+		if n := len(resp.Revisions); n > 0 {
+			latest = resp.Revisions[n-1].Id
+		}
+		if resp.NextPageToken == "" {
+			return latest, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// preWriteRevisionID returns the current Drive revision of fileID, recorded
+// in the audit log before a write whose action is in the default
+// approval-gated set, whether or not the policy asks for a token. Other
+// actions get "". It is best-effort: a failed lookup does not stop the write,
+// and the error is reported with addRevisionResult instead.
+func preWriteRevisionID(ctx context.Context, account, action, fileID string) (string, error) {
+	if !slices.Contains(defaultApprovalActions, action) {
+		return "", nil
+	}
+
+	svc, err := googleapi.NewDriveReadOnly(ctx, account)
+	if err != nil {
+		return "", err
+	}
+
+	return latestDriveRevisionID(svc, fileID)
+}
+
+// addRevisionResult adds the pre-write revision, or why it could not be
+// looked up, to a command result.
+func addRevisionResult(result map[string]any, revisionID string, err error) {
+	if revisionID != "" {
+		result["previous_revision_id"] = revisionID
+	}
+	if err != nil {
+		result["revision_lookup_error"] = err.Error()
+	}
+}
+
+// DocsRevisionsCmd groups document revision subcommands.
+type DocsRevisionsCmd struct {
+	List   DocsRevisionsListCmd   `cmd:"" help:"List document revisions."`
+	Export DocsRevisionsExportCmd `cmd:"" help:"Export a document revision to a file."`
+}
+
+// DocsRevisionsListCmd lists document revisions.
+type DocsRevisionsListCmd struct {
+	Account  string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID    string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	Max      int64  `name:"max" default:"200" help:"Maximum revisions per page (1-1000)."`
+	AllPages bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page     string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *DocsRevisionsListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	return docsFiles.list(ctx, c.Account, c.DocID, c.Max, c.AllPages, c.Page)
+}
+
+// DocsRevisionsExportCmd exports a document revision.
+type DocsRevisionsExportCmd struct {
+	Account    string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID      string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	RevisionID string `name:"revision-id" required:"" help:"Revision ID from docs revisions list."`
+	Format     string `name:"format" default:"pdf" help:"Export format: pdf, docx, txt, odt, html."`
+	Output     string `name:"output" required:"" help:"Output file path."`
+	Overwrite  bool   `name:"overwrite" help:"Overwrite output file if it exists."`
+}
+
+func (c *DocsRevisionsExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	return docsFiles.exportRevision(ctx, root, c.Account, c.DocID, c.RevisionID, c.Format, c.Output, c.Overwrite)
+}
+
+// DocsRestoreCmd restores a document to an earlier revision.
+type DocsRestoreCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	DocID          string `name:"doc-id" required:"" help:"Google Docs document ID."`
+	RevisionID     string `name:"revision-id" required:"" help:"Revision ID to restore."`
+	ConfirmRestore bool   `name:"confirm-restore" help:"Required confirmation flag for restore."`
+	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *DocsRestoreCmd) Run(ctx context.Context, root *RootFlags) error {
+	return docsFiles.restore(ctx, root, c.Account, c.DocID, c.RevisionID, c.ConfirmRestore, c.ApprovalToken)
+}
+
+// SheetsRevisionsCmd groups spreadsheet revision subcommands.
+type SheetsRevisionsCmd struct {
+	List   SheetsRevisionsListCmd   `cmd:"" help:"List spreadsheet revisions."`
+	Export SheetsRevisionsExportCmd `cmd:"" help:"Export a spreadsheet revision to a file."`
+}
+
+// SheetsRevisionsListCmd lists spreadsheet revisions.
+type SheetsRevisionsListCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Max           int64  `name:"max" default:"200" help:"Maximum revisions per page (1-1000)."`
+	AllPages      bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page          string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *SheetsRevisionsListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	return sheetsFiles.list(ctx, c.Account, c.SpreadsheetID, c.Max, c.AllPages, c.Page)
+}
+
+// SheetsRevisionsExportCmd exports a spreadsheet revision.
+type SheetsRevisionsExportCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	RevisionID    string `name:"revision-id" required:"" help:"Revision ID from sheets revisions list."`
+	Format        string `name:"format" default:"xlsx" help:"Export format: xlsx, ods, pdf, csv, tsv (csv/tsv export the first sheet)."`
+	Output        string `name:"output" required:"" help:"Output file path."`
+	Overwrite     bool   `name:"overwrite" help:"Overwrite output file if it exists."`
+}
+
+func (c *SheetsRevisionsExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	return sheetsFiles.exportRevision(ctx, root, c.Account, c.SpreadsheetID, c.RevisionID, c.Format, c.Output, c.Overwrite)
+}
+
+// SheetsRestoreCmd restores a spreadsheet to an earlier revision.
+type SheetsRestoreCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID  string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	RevisionID     string `name:"revision-id" required:"" help:"Revision ID to restore."`
+	ConfirmRestore bool   `name:"confirm-restore" help:"Required confirmation flag for restore."`
+	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SheetsRestoreCmd) Run(ctx context.Context, root *RootFlags) error {
+	return sheetsFiles.restore(ctx, root, c.Account, c.SpreadsheetID, c.RevisionID, c.ConfirmRestore, c.ApprovalToken)
+}
+
+// SlidesRevisionsCmd groups presentation revision subcommands.
+type SlidesRevisionsCmd struct {
+	List   SlidesRevisionsListCmd   `cmd:"" help:"List presentation revisions."`
+	Export SlidesRevisionsExportCmd `cmd:"" help:"Export a presentation revision to a file."`
+}
+
+// SlidesRevisionsListCmd lists presentation revisions.
+type SlidesRevisionsListCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	Max            int64  `name:"max" default:"200" help:"Maximum revisions per page (1-1000)."`
+	AllPages       bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page           string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *SlidesRevisionsListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	return slidesFiles.list(ctx, c.Account, c.PresentationID, c.Max, c.AllPages, c.Page)
+}
+
+// SlidesRevisionsExportCmd exports a presentation revision.
+type SlidesRevisionsExportCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	RevisionID     string `name:"revision-id" required:"" help:"Revision ID from slides revisions list."`
+	Format         string `name:"format" default:"pdf" help:"Export format: pptx, odp, pdf, txt."`
+	Output         string `name:"output" required:"" help:"Output file path."`
+	Overwrite      bool   `name:"overwrite" help:"Overwrite output file if it exists."`
+}
+
+func (c *SlidesRevisionsExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	return slidesFiles.exportRevision(ctx, root, c.Account, c.PresentationID, c.RevisionID, c.Format, c.Output, c.Overwrite)
+}
+
+// SlidesRestoreCmd restores a presentation to an earlier revision.
+type SlidesRestoreCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	RevisionID     string `name:"revision-id" required:"" help:"Revision ID to restore."`
+	ConfirmRestore bool   `name:"confirm-restore" help:"Required confirmation flag for restore."`
+	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SlidesRestoreCmd) Run(ctx context.Context, root *RootFlags) error {
+	return slidesFiles.restore(ctx, root, c.Account, c.PresentationID, c.RevisionID, c.ConfirmRestore, c.ApprovalToken)
+}
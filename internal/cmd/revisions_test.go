@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestToRevisionRef(t *testing.T) {
+	ref := sheetsFiles.toRevisionRef(&drive.Revision{
+		Id:                "42",
+		ModifiedTime:      "2026-01-02T03:04:05Z",
+		LastModifyingUser: &drive.User{DisplayName: "Editor", EmailAddress: "e@example.com"},
+		ExportLinks: map[string]string{
+			"application/pdf": "https://example.com/pdf",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": "https://example.com/xlsx",
+			"application/zip": "https://example.com/zip",
+		},
+	})
+
+	if ref.ID != "42" || ref.AuthorEmail != "e@example.com" {
+		t.Errorf("unexpected ref: %+v", ref)
+	}
+	// Formats without a name in the service's format table are not listed.
+	if want := []string{"pdf", "xlsx"}; !reflect.DeepEqual(ref.ExportFormats, want) {
+		t.Errorf("export formats = %v, want %v", ref.ExportFormats, want)
+	}
+}
+
+func runForCode(t *testing.T, run func() error) (string, int) {
+	t.Helper()
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = run()
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var payload struct {
+		Code string `json:"code"`
+	}
+	if err2 := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &payload); err2 != nil {
+		t.Fatalf("parse stderr JSON: %v (got %q)", err2, stderr)
+	}
+
+	return payload.Code, output.ExitCode(err)
+}
+
+func TestRestoreCmds_RequireConfirmationAndApproval(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&DocsRestoreCmd{Account: "a@example.com", DocID: "doc-123", RevisionID: "7"}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "restore_requires_confirmation" {
+		t.Errorf("code = %q, want restore_requires_confirmation", code)
+	}
+
+	// Restores are approval-gated by default.
+	code, exit := runForCode(t, func() error {
+		return (&SheetsRestoreCmd{Account: "a@example.com", SpreadsheetID: "sp-123", RevisionID: "7", ConfirmRestore: true}).
+			Run(context.Background(), &RootFlags{})
+	})
+	if code != "approval_required" || exit != output.ExitCodePermission {
+		t.Errorf("code = %q (exit %d), want approval_required", code, exit)
+	}
+}
+
+func TestSlidesRestoreCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SlidesRestoreCmd{Account: "a@example.com", PresentationID: "pres-123", RevisionID: "7"}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string         `json:"action"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "slides.restore" || payload.Params["presentation_id"] != "pres-123" || payload.Params["via_format"] != "pptx" {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+}
+
+func TestDocsRevisionsExportCmd_InvalidFormat(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&DocsRevisionsExportCmd{
+			Account: "a@example.com", DocID: "doc-123", RevisionID: "7", Format: "xlsx", Output: "out.xlsx",
+		}).Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "invalid_format" {
+		t.Errorf("code = %q, want invalid_format", code)
+	}
+}
+
+func TestPreWriteRevisionID(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	// Actions outside the approval-gated set are not looked up.
+	if id, err := preWriteRevisionID(context.Background(), "a@example.com", "docs.write", "doc-1"); id != "" || err != nil {
+		t.Errorf("ungated action = (%q, %v), want no lookup", id, err)
+	}
+
+	// A gated action is looked up even when the policy asks for no token;
+	// without credentials the failure is returned rather than dropped.
+	if err := config.WritePolicy(config.PolicyFile{RequireApprovalActions: []string{"tasks.delete"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+	id, err := preWriteRevisionID(context.Background(), "a@example.com", "sheets.clear", "ss-1")
+	if id != "" || err == nil {
+		t.Fatalf("gated action = (%q, %v), want a lookup error", id, err)
+	}
+
+	result := map[string]any{}
+	addRevisionResult(result, id, err)
+	if _, ok := result["revision_lookup_error"]; !ok {
+		t.Errorf("result = %v, want revision_lookup_error", result)
+	}
+	if _, ok := result["previous_revision_id"]; ok {
+		t.Errorf("result = %v, want no previous_revision_id", result)
+	}
+
+	result = map[string]any{}
+	addRevisionResult(result, "rev-9", nil)
+	if !reflect.DeepEqual(result, map[string]any{"previous_revision_id": "rev-9"}) {
+		t.Errorf("result = %v, want previous_revision_id only", result)
+	}
+}
@@ -29,6 +29,8 @@ type CLI struct {
 	Docs      DocsCmd     `cmd:"" help:"Google Docs operations."`
 	Sheets    SheetsCmd   `cmd:"" help:"Google Sheets operations."`
 	Slides    SlidesCmd   `cmd:"" help:"Google Slides operations."`
+	Tasks     TasksCmd    `cmd:"" help:"Google Tasks operations."`
+	Contacts  ContactsCmd `cmd:"" help:"Google Contacts lookup."`
 }
 
 // Execute parses CLI arguments and runs the selected command.
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/api/sheets/v4"
@@ -15,10 +16,27 @@ import (
 
 // SheetsCmd groups Sheets subcommands.
 type SheetsCmd struct {
-	Info   SheetsInfoCmd   `cmd:"" help:"Get spreadsheet metadata."`
-	Get    SheetsGetCmd    `cmd:"" help:"Get cell values from a range."`
-	Update SheetsUpdateCmd `cmd:"" help:"Update cell values in a range."`
-	Append SheetsAppendCmd `cmd:"" help:"Append rows to a sheet."`
+	Info              SheetsInfoCmd              `cmd:"" help:"Get spreadsheet metadata."`
+	Create            SheetsCreateCmd            `cmd:"" help:"Create a spreadsheet."`
+	Get               SheetsGetCmd               `cmd:"" help:"Get cell values from a range."`
+	Update            SheetsUpdateCmd            `cmd:"" help:"Update cell values in a range."`
+	Append            SheetsAppendCmd            `cmd:"" help:"Append rows to a sheet."`
+	BatchGet          SheetsBatchGetCmd          `cmd:"" name:"batch-get" help:"Get cell values from several ranges."`
+	BatchUpdate       SheetsBatchUpdateCmd       `cmd:"" name:"batch-update" help:"Update cell values in several ranges."`
+	Records           SheetsRecordsCmd           `cmd:"" help:"Read and write rows as JSON objects keyed by the header row."`
+	Tab               SheetsTabCmd               `cmd:"" help:"Add, delete, rename and duplicate tabs."`
+	Clear             SheetsClearCmd             `cmd:"" help:"Clear the values in a range."`
+	Rows              SheetsRowsCmd              `cmd:"" help:"Insert and delete rows."`
+	Format            SheetsFormatCmd            `cmd:"" help:"Set bold, number format, colors, alignment and borders on a range."`
+	Validation        SheetsValidationCmd        `cmd:"" help:"Set or clear dropdown data validation."`
+	ConditionalFormat SheetsConditionalFormatCmd `cmd:"" name:"conditional-format" help:"Highlight cells that match a condition."`
+	Freeze            SheetsFreezeCmd            `cmd:"" help:"Freeze header rows and columns."`
+	Autosize          SheetsAutosizeCmd          `cmd:"" help:"Fit column widths to their contents."`
+	Layout            SheetsLayoutCmd            `cmd:"" help:"Apply formatting, validation, conditional formats, freezing and autosizing from a JSON spec in one request."`
+	Export            SheetsExportCmd            `cmd:"" help:"Export a spreadsheet, one sheet or a range to XLSX, CSV, PDF and other formats."`
+	Import            SheetsImportCmd            `cmd:"" help:"Import a CSV or TSV file into a range, in chunks."`
+	Revisions         SheetsRevisionsCmd         `cmd:"" help:"List and export spreadsheet revisions."`
+	Restore           SheetsRestoreCmd           `cmd:"" help:"Restore a spreadsheet to an earlier revision."`
 }
 
 // SheetsInfoCmd gets spreadsheet metadata.
@@ -78,9 +96,11 @@ func (c *SheetsInfoCmd) Run(ctx context.Context, _ *RootFlags) error {
 
 // SheetsGetCmd gets cell values from a range.
 type SheetsGetCmd struct {
-	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
-	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
-	Range         string `name:"range" required:"" help:"Cell range (e.g. Sheet1!A1:C10)."`
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID  string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range          string `name:"range" required:"" help:"Cell range (e.g. Sheet1!A1:C10)."`
+	ValueRender    string `name:"value-render" help:"How values are rendered: FORMATTED (default), UNFORMATTED or FORMULA."`
+	DateTimeRender string `name:"date-time-render" help:"How dates are rendered when not FORMATTED: SERIAL (default) or FORMATTED."`
 }
 
 func (c *SheetsGetCmd) Run(ctx context.Context, _ *RootFlags) error {
@@ -92,12 +112,25 @@ func (c *SheetsGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
 	}
 
+	valueRender, dateTimeRender, err := sheetsRenderOptions(c.ValueRender, c.DateTimeRender)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
 	svc, err := googleapi.NewSheetsReadOnly(ctx, c.Account)
 	if err != nil {
 		return sheetsAuthError(err)
 	}
 
-	resp, err := svc.Spreadsheets.Values.Get(c.SpreadsheetID, c.Range).Do()
+	call := svc.Spreadsheets.Values.Get(c.SpreadsheetID, c.Range)
+	if valueRender != "" {
+		call = call.ValueRenderOption(valueRender)
+	}
+	if dateTimeRender != "" {
+		call = call.DateTimeRenderOption(dateTimeRender)
+	}
+
+	resp, err := call.Do()
 	if err != nil {
 		return writeGoogleAPIError("sheets_get_error", err)
 	}
@@ -106,6 +139,7 @@ func (c *SheetsGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 		"spreadsheet_id": c.SpreadsheetID,
 		"range":          resp.Range,
 		"values":         resp.Values,
+		"hash":           contentHash(resp.Values, valueRender, dateTimeRender),
 	})
 }
 
@@ -116,6 +150,9 @@ type SheetsUpdateCmd struct {
 	Range         string `name:"range" required:"" help:"Cell range to update (e.g. Sheet1!A1:B2)."`
 	Values        string `name:"values" help:"JSON array of rows (e.g. [[\"Alice\",30]])."`
 	ValuesStdin   bool   `name:"values-stdin" help:"Read values JSON from stdin."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
+	ExpectValues  string `name:"expect-values" help:"JSON array of rows the range must still hold (as displayed); refuse with a conflict otherwise."`
+	ExpectHash    string `name:"expect-hash" help:"Hash from sheets get the range must still match; refuse with a conflict otherwise."`
 }
 
 func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -123,6 +160,11 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
 	valuesJSON := c.Values
 	if c.ValuesStdin {
 		s, err := readStdinWithLimit(maxStdinBytes)
@@ -137,6 +179,11 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodeError, "invalid_values", fmt.Sprintf("parse values JSON: %v", err))
 	}
 
+	expect, code, err := parseSheetsExpectation(c.ExpectValues, c.ExpectHash)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, code, err.Error())
+	}
+
 	if root.DryRun {
 		if err := appendAuditLog(root.AuditLog, auditEntry{
 			Action:  "sheets.update",
@@ -154,6 +201,8 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 				"spreadsheet_id": c.SpreadsheetID,
 				"range":          c.Range,
 				"row_count":      len(values),
+				"input_option":   inputOption,
+				"expect":         expect.kind(),
 			},
 		})
 	}
@@ -163,9 +212,15 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		return sheetsAuthError(err)
 	}
 
+	if expect != nil {
+		if err := expect.check(svc, c.SpreadsheetID, c.Range); err != nil {
+			return err
+		}
+	}
+
 	valueRange := &sheets.ValueRange{Values: toInterfaceSlice(values)}
 	resp, err := svc.Spreadsheets.Values.Update(c.SpreadsheetID, c.Range, valueRange).
-		ValueInputOption("USER_ENTERED").Do()
+		ValueInputOption(inputOption).Do()
 	if err != nil {
 		return writeGoogleAPIError("sheets_update_error", err)
 	}
@@ -185,6 +240,7 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
 		"updated_rows":    resp.UpdatedRows,
 		"updated_columns": resp.UpdatedColumns,
 		"updated_cells":   resp.UpdatedCells,
+		"input_option":    inputOption,
 	})
 }
 
@@ -194,7 +250,10 @@ type SheetsAppendCmd struct {
 	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
 	Range         string `name:"range" required:"" help:"Sheet or range to append to (e.g. Sheet1)."`
 	Values        string `name:"values" help:"JSON array of rows (e.g. [[\"Bob\",25]])."`
-	ValuesStdin   bool   `name:"values-stdin" help:"Read values JSON from stdin."`
+	ValuesStdin   bool   `name:"values-stdin" help:"Read values from stdin."`
+	ValuesFormat  string `name:"values-format" default:"json" help:"Format of the values: json, csv or tsv."`
+	Encoding      string `name:"encoding" default:"utf-8" help:"Encoding of csv or tsv values: utf-8 or shift_jis."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
 }
 
 func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
@@ -202,6 +261,11 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
 	valuesJSON := c.Values
 	if c.ValuesStdin {
 		s, err := readStdinWithLimit(maxStdinBytes)
@@ -211,8 +275,22 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
 		valuesJSON = s
 	}
 
+	format := strings.ToLower(c.ValuesFormat)
+	if format != "" && format != "json" && format != "csv" && format != "tsv" {
+		return output.WriteError(output.ExitCodeError, "invalid_format",
+			fmt.Sprintf("unsupported --values-format %q; use json, csv or tsv", c.ValuesFormat))
+	}
+
 	var values [][]any
-	if err := json.Unmarshal([]byte(valuesJSON), &values); err != nil {
+	if format == "csv" || format == "tsv" {
+		text, err := decodeText([]byte(valuesJSON), c.Encoding)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+		}
+		if values, err = parseDelimited(text, format); err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+		}
+	} else if err := json.Unmarshal([]byte(valuesJSON), &values); err != nil {
 		return output.WriteError(output.ExitCodeError, "invalid_values", fmt.Sprintf("parse values JSON: %v", err))
 	}
 
@@ -233,6 +311,7 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
 				"spreadsheet_id": c.SpreadsheetID,
 				"range":          c.Range,
 				"row_count":      len(values),
+				"input_option":   inputOption,
 			},
 		})
 	}
@@ -244,7 +323,7 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
 
 	valueRange := &sheets.ValueRange{Values: toInterfaceSlice(values)}
 	resp, err := svc.Spreadsheets.Values.Append(c.SpreadsheetID, c.Range, valueRange).
-		ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do()
+		ValueInputOption(inputOption).InsertDataOption("INSERT_ROWS").Do()
 	if err != nil {
 		return writeGoogleAPIError("sheets_append_error", err)
 	}
@@ -274,6 +353,7 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
 		"table_range":    tableRange,
 		"updated_range":  updatedRange,
 		"updated_rows":   updatedRows,
+		"input_option":   inputOption,
 	})
 }
 
@@ -297,3 +377,28 @@ func toInterfaceSlice(rows [][]any) [][]interface{} {
 	}
 	return result
 }
+
+// SheetsExportCmd exports a spreadsheet with Drive.
+type SheetsExportCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Format        string `name:"format" required:"" help:"Export format: xlsx, ods, pdf, csv, tsv, or jsonl (with --range or --sheet)."`
+	Sheet         string `name:"sheet" help:"Export only the sheet with this title (csv, tsv, pdf, jsonl). CSV and TSV default to the first sheet."`
+	Range         string `name:"range" help:"Export the displayed values of this range (e.g. Sheet1!A1:D50) as csv, tsv or jsonl."`
+	Output        string `name:"output" required:"" help:"Output file path, or - to stream to stdout."`
+	Overwrite     bool   `name:"overwrite" help:"Allow overwriting an existing output file (default: disabled)."`
+	MaxBytes      int64  `name:"max-bytes" help:"Abort when the export is larger than this many bytes (0: no limit)."`
+}
+
+func (c *SheetsExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	return sheetsFiles.export(ctx, root, exportRequest{
+		account:   c.Account,
+		fileID:    c.SpreadsheetID,
+		format:    c.Format,
+		output:    c.Output,
+		overwrite: c.Overwrite,
+		maxBytes:  c.MaxBytes,
+		sheet:     c.Sheet,
+		rangeA1:   c.Range,
+	})
+}
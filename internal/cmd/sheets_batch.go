@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+var sheetsValueRenderOptions = map[string]string{
+	"formatted":   "FORMATTED_VALUE",
+	"unformatted": "UNFORMATTED_VALUE",
+	"formula":     "FORMULA",
+}
+
+var sheetsDateTimeRenderOptions = map[string]string{
+	"serial":    "SERIAL_NUMBER",
+	"formatted": "FORMATTED_STRING",
+}
+
+var sheetsInputOptions = map[string]string{
+	"raw":          "RAW",
+	"user_entered": "USER_ENTERED",
+}
+
+// sheetsOption maps a flag value to its API enum. Both the short name and the
+// API name are accepted, in any case. An empty value stays empty so that the
+// API default applies.
+func sheetsOption(flag, value string, options map[string]string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	v := strings.ToLower(strings.TrimSpace(value))
+	if enum, ok := options[v]; ok {
+		return enum, nil
+	}
+	for _, enum := range options {
+		if strings.EqualFold(v, enum) {
+			return enum, nil
+		}
+	}
+
+	names := make([]string, 0, len(options))
+	for _, k := range sortedKeys(options) {
+		names = append(names, strings.ToUpper(k))
+	}
+
+	return "", fmt.Errorf("unsupported %s %q; use %s", flag, value, strings.Join(names, ", "))
+}
+
+// sheetsRenderOptions validates --value-render and --date-time-render.
+func sheetsRenderOptions(valueRender, dateTimeRender string) (string, string, error) {
+	vr, err := sheetsOption("--value-render", valueRender, sheetsValueRenderOptions)
+	if err != nil {
+		return "", "", err
+	}
+	dtr, err := sheetsOption("--date-time-render", dateTimeRender, sheetsDateTimeRenderOptions)
+	if err != nil {
+		return "", "", err
+	}
+
+	return vr, dtr, nil
+}
+
+// sheetsInputOption resolves --input-option against policy. Without a flag,
+// values are USER_ENTERED as before, or RAW when sheets_force_raw_input is
+// set; asking for USER_ENTERED under that policy is denied.
+func sheetsInputOption(value string) (string, string, error) {
+	option, err := sheetsOption("--input-option", value, sheetsInputOptions)
+	if err != nil {
+		return "", "invalid_arguments", err
+	}
+
+	p, err := config.ReadPolicy()
+	if err != nil {
+		return "", "policy_error", fmt.Errorf("read policy: %w", err)
+	}
+	switch {
+	case p.SheetsForceRawInput && option == "USER_ENTERED":
+		return "", "policy_denied", fmt.Errorf("USER_ENTERED input is disabled by sheets_force_raw_input")
+	case p.SheetsForceRawInput:
+		return "RAW", "", nil
+	case option == "":
+		return "USER_ENTERED", "", nil
+	}
+
+	return option, "", nil
+}
+
+// sheetsInputOptionError writes the error returned by sheetsInputOption.
+func sheetsInputOptionError(code string, err error) error {
+	if code == "policy_denied" {
+		return output.WriteError(output.ExitCodePermission, code, err.Error())
+	}
+
+	return output.WriteError(output.ExitCodeError, code, err.Error())
+}
+
+// SheetsBatchGetCmd gets cell values from several ranges in one request.
+type SheetsBatchGetCmd struct {
+	Account        string   `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID  string   `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Ranges         []string `name:"ranges" required:"" help:"Cell ranges, comma-separated (e.g. Sheet1!A1:C10,Summary!B2)."`
+	ValueRender    string   `name:"value-render" help:"How values are rendered: FORMATTED (default), UNFORMATTED or FORMULA."`
+	DateTimeRender string   `name:"date-time-render" help:"How dates are rendered when not FORMATTED: SERIAL (default) or FORMATTED."`
+}
+
+func (c *SheetsBatchGetCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.batch_get"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("sheets.batch_get", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	valueRender, dateTimeRender, err := sheetsRenderOptions(c.ValueRender, c.DateTimeRender)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	svc, err := googleapi.NewSheetsReadOnly(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	call := svc.Spreadsheets.Values.BatchGet(c.SpreadsheetID).Ranges(c.Ranges...)
+	if valueRender != "" {
+		call = call.ValueRenderOption(valueRender)
+	}
+	if dateTimeRender != "" {
+		call = call.DateTimeRenderOption(dateTimeRender)
+	}
+
+	resp, err := call.Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_batch_get_error", err)
+	}
+
+	valueRanges := make([]map[string]any, 0, len(resp.ValueRanges))
+	for _, vr := range resp.ValueRanges {
+		values := vr.Values
+		if values == nil {
+			values = [][]interface{}{}
+		}
+		valueRanges = append(valueRanges, map[string]any{
+			"range":  vr.Range,
+			"values": values,
+		})
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"value_ranges":   valueRanges,
+	})
+}
+
+// sheetsRangeValues is one entry of batch-update data.
+type sheetsRangeValues struct {
+	Range  string  `json:"range"`
+	Values [][]any `json:"values"`
+}
+
+// parseSheetsBatchData decodes [{"range": "...", "values": [[...]]}, ...].
+func parseSheetsBatchData(data string) ([]sheetsRangeValues, error) {
+	var entries []sheetsRangeValues
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("parse data JSON: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("data must contain at least one range")
+	}
+	for i, e := range entries {
+		if strings.TrimSpace(e.Range) == "" {
+			return nil, fmt.Errorf("data entry %d has no range", i)
+		}
+	}
+
+	return entries, nil
+}
+
+// SheetsBatchUpdateCmd updates several ranges in one request.
+type SheetsBatchUpdateCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Data          string `name:"data" help:"JSON array of {\"range\", \"values\"} objects."`
+	DataStdin     bool   `name:"data-stdin" help:"Read data JSON from stdin."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
+}
+
+func (c *SheetsBatchUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.batch_update"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
+	dataJSON := c.Data
+	if c.DataStdin {
+		s, err := readStdinWithLimit(maxStdinBytes)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "stdin_error", fmt.Sprintf("read stdin: %v", err))
+		}
+		dataJSON = s
+	}
+
+	entries, err := parseSheetsBatchData(dataJSON)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	ranges := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ranges = append(ranges, e.Range)
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.batch_update",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.batch_update",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"ranges":         ranges,
+				"input_option":   inputOption,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	req := &sheets.BatchUpdateValuesRequest{ValueInputOption: inputOption}
+	for _, e := range entries {
+		req.Data = append(req.Data, &sheets.ValueRange{Range: e.Range, Values: toInterfaceSlice(e.Values)})
+	}
+
+	resp, err := svc.Spreadsheets.Values.BatchUpdate(c.SpreadsheetID, req).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_batch_update_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.batch_update",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	responses := make([]map[string]any, 0, len(resp.Responses))
+	for _, r := range resp.Responses {
+		responses = append(responses, map[string]any{
+			"updated_range":   r.UpdatedRange,
+			"updated_rows":    r.UpdatedRows,
+			"updated_columns": r.UpdatedColumns,
+			"updated_cells":   r.UpdatedCells,
+		})
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id":        c.SpreadsheetID,
+		"input_option":          inputOption,
+		"total_updated_rows":    resp.TotalUpdatedRows,
+		"total_updated_columns": resp.TotalUpdatedColumns,
+		"total_updated_cells":   resp.TotalUpdatedCells,
+		"responses":             responses,
+	})
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestSheetsOption(t *testing.T) {
+	cases := map[string]string{
+		"":                  "",
+		"formula":           "FORMULA",
+		"Unformatted":       "UNFORMATTED_VALUE",
+		"unformatted_value": "UNFORMATTED_VALUE",
+	}
+	for in, want := range cases {
+		got, err := sheetsOption("--value-render", in, sheetsValueRenderOptions)
+		if err != nil || got != want {
+			t.Errorf("%q: got %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	if _, err := sheetsOption("--value-render", "pretty", sheetsValueRenderOptions); err == nil {
+		t.Error("expected error for unsupported value")
+	}
+}
+
+func TestSheetsInputOption_Policy(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if got, _, err := sheetsInputOption(""); err != nil || got != "USER_ENTERED" {
+		t.Fatalf("default without policy: %q, %v", got, err)
+	}
+
+	if err := config.WritePolicy(config.PolicyFile{SheetsForceRawInput: true}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+	if got, _, err := sheetsInputOption(""); err != nil || got != "RAW" {
+		t.Errorf("default under force policy: %q, %v", got, err)
+	}
+	if _, code, err := sheetsInputOption("user_entered"); err == nil || code != "policy_denied" {
+		t.Errorf("USER_ENTERED under force policy: code=%q err=%v", code, err)
+	}
+}
+
+func TestSheetsUpdateCmd_ForceRawDeniesUserEntered(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{SheetsForceRawInput: true}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	cmd := &SheetsUpdateCmd{
+		Account:       "a@example.com",
+		SpreadsheetID: "ss-1",
+		Range:         "A1",
+		Values:        `[["=IMPORTXML(\"https://example.com\",\"//a\")"]]`,
+		InputOption:   "USER_ENTERED",
+	}
+	code, exit := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{DryRun: true}) })
+	if code != "policy_denied" || exit != output.ExitCodePermission {
+		t.Errorf("code = %q, exit = %d", code, exit)
+	}
+}
+
+func TestSheetsBatchUpdateCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SheetsBatchUpdateCmd{
+		Account:       "a@example.com",
+		SpreadsheetID: "ss-1",
+		Data:          `[{"range":"Sheet1!A1","values":[["x"]]},{"range":"Summary!B2:C2","values":[[1,2]]}]`,
+		InputOption:   "raw",
+	}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			Ranges      []string `json:"ranges"`
+			InputOption string   `json:"input_option"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "sheets.batch_update" || len(payload.Params.Ranges) != 2 || payload.Params.InputOption != "RAW" {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+}
+
+func TestParseSheetsBatchData(t *testing.T) {
+	for _, bad := range []string{`[]`, `{"range":"A1"}`, `[{"values":[["x"]]}]`} {
+		if _, err := parseSheetsBatchData(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
@@ -0,0 +1,711 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// SheetsValidationCmd groups data validation commands.
+type SheetsValidationCmd struct {
+	Set   SheetsValidationSetCmd   `cmd:"" help:"Restrict a range to a dropdown list of values."`
+	Clear SheetsValidationClearCmd `cmd:"" help:"Remove data validation from a range."`
+}
+
+// layoutSpec is a set of formatting changes applied in one BatchUpdate. The
+// single-purpose commands (format, validation, freeze, autosize) build a
+// spec with one entry; sheets layout reads a full spec from JSON.
+type layoutSpec struct {
+	Format     []formatSpec     `json:"format,omitempty"`
+	Validation []validationSpec `json:"validation,omitempty"`
+	// ConditionalFormats are added ahead of the tab's existing rules.
+	ConditionalFormats []conditionalFormatSpec `json:"conditional_formats,omitempty"`
+	Freeze             []freezeSpec            `json:"freeze,omitempty"`
+	Autosize           []autosizeSpec          `json:"autosize,omitempty"`
+}
+
+type formatSpec struct {
+	Range        string `json:"range"`
+	Bold         *bool  `json:"bold,omitempty"`
+	Italic       *bool  `json:"italic,omitempty"`
+	NumberFormat string `json:"number_format,omitempty"`
+	Background   string `json:"background,omitempty"`
+	TextColor    string `json:"text_color,omitempty"`
+	Align        string `json:"align,omitempty"`
+	Borders      string `json:"borders,omitempty"`
+}
+
+type validationSpec struct {
+	Range        string   `json:"range"`
+	List         []string `json:"list,omitempty"`
+	AllowInvalid bool     `json:"allow_invalid,omitempty"`
+	// Clear removes validation from the range instead.
+	Clear bool `json:"clear,omitempty"`
+}
+
+type conditionalFormatSpec struct {
+	Range string `json:"range"`
+	// Condition is a Sheets condition type such as NUMBER_GREATER,
+	// TEXT_CONTAINS or CUSTOM_FORMULA; see conditionValueCounts.
+	Condition  string   `json:"condition"`
+	Values     []string `json:"values,omitempty"`
+	Background string   `json:"background,omitempty"`
+	TextColor  string   `json:"text_color,omitempty"`
+	Bold       *bool    `json:"bold,omitempty"`
+	Italic     *bool    `json:"italic,omitempty"`
+}
+
+type freezeSpec struct {
+	Sheet   string `json:"sheet"`
+	Rows    int    `json:"rows"`
+	Columns int    `json:"columns"`
+}
+
+type autosizeSpec struct {
+	Sheet string `json:"sheet"`
+	// Columns is a column span such as "A:F"; empty means every column.
+	Columns string `json:"columns,omitempty"`
+}
+
+// layoutOp builds the requests for one spec entry once sheet IDs are known.
+type layoutOp func(props []*sheets.SheetProperties) ([]*sheets.Request, error)
+
+// numberFormatPresets maps short names to Sheets number formats. Other
+// values of number_format are used as a NUMBER pattern.
+var numberFormatPresets = map[string]sheets.NumberFormat{
+	"text":       {Type: "TEXT"},
+	"number":     {Type: "NUMBER", Pattern: "#,##0.00"},
+	"integer":    {Type: "NUMBER", Pattern: "#,##0"},
+	"percent":    {Type: "PERCENT", Pattern: "0.00%"},
+	"currency":   {Type: "CURRENCY"},
+	"date":       {Type: "DATE", Pattern: "yyyy-mm-dd"},
+	"time":       {Type: "TIME", Pattern: "hh:mm:ss"},
+	"datetime":   {Type: "DATE_TIME", Pattern: "yyyy-mm-dd hh:mm:ss"},
+	"scientific": {Type: "SCIENTIFIC", Pattern: "0.00E+00"},
+}
+
+// conditionValueCounts lists the supported conditional format conditions and
+// how many values each takes.
+var conditionValueCounts = map[string]int{
+	"NUMBER_GREATER":         1,
+	"NUMBER_GREATER_THAN_EQ": 1,
+	"NUMBER_LESS":            1,
+	"NUMBER_LESS_THAN_EQ":    1,
+	"NUMBER_EQ":              1,
+	"NUMBER_NOT_EQ":          1,
+	"NUMBER_BETWEEN":         2,
+	"NUMBER_NOT_BETWEEN":     2,
+	"TEXT_CONTAINS":          1,
+	"TEXT_NOT_CONTAINS":      1,
+	"TEXT_STARTS_WITH":       1,
+	"TEXT_ENDS_WITH":         1,
+	"TEXT_EQ":                1,
+	"BLANK":                  0,
+	"NOT_BLANK":              0,
+	"CUSTOM_FORMULA":         1,
+}
+
+var horizontalAlignments = map[string]string{"left": "LEFT", "center": "CENTER", "right": "RIGHT"}
+
+var borderStyles = map[string]string{
+	"none":         "NONE",
+	"solid":        "SOLID",
+	"solid_medium": "SOLID_MEDIUM",
+	"solid_thick":  "SOLID_THICK",
+	"dashed":       "DASHED",
+	"dotted":       "DOTTED",
+	"double":       "DOUBLE",
+}
+
+// parseLayoutSpec decodes a JSON layout spec, rejecting unknown keys so a
+// misspelled option is not silently ignored.
+func parseLayoutSpec(data string) (layoutSpec, error) {
+	var spec layoutSpec
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return layoutSpec{}, fmt.Errorf("parse layout spec JSON: %w", err)
+	}
+
+	return spec, nil
+}
+
+// compile checks every entry and returns one op per entry, in the order
+// format, validation, conditional_formats, freeze, autosize.
+func (s layoutSpec) compile() ([]layoutOp, error) {
+	var ops []layoutOp
+	for i, f := range s.Format {
+		op, err := f.compile()
+		if err != nil {
+			return nil, fmt.Errorf("format[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	for i, v := range s.Validation {
+		op, err := v.compile()
+		if err != nil {
+			return nil, fmt.Errorf("validation[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	for i, cf := range s.ConditionalFormats {
+		op, err := cf.compile()
+		if err != nil {
+			return nil, fmt.Errorf("conditional_formats[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	for i, f := range s.Freeze {
+		op, err := f.compile()
+		if err != nil {
+			return nil, fmt.Errorf("freeze[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	for i, a := range s.Autosize {
+		op, err := a.compile()
+		if err != nil {
+			return nil, fmt.Errorf("autosize[%d]: %w", i, err)
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("layout spec has no entries")
+	}
+
+	return ops, nil
+}
+
+func (f formatSpec) compile() (layoutOp, error) {
+	r, err := parseA1Range(f.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	format := &sheets.CellFormat{}
+	var fields []string
+	if f.Bold != nil || f.Italic != nil || f.TextColor != "" {
+		format.TextFormat = &sheets.TextFormat{}
+	}
+	if f.Bold != nil {
+		format.TextFormat.Bold = *f.Bold
+		format.TextFormat.ForceSendFields = append(format.TextFormat.ForceSendFields, "Bold")
+		fields = append(fields, "userEnteredFormat.textFormat.bold")
+	}
+	if f.Italic != nil {
+		format.TextFormat.Italic = *f.Italic
+		format.TextFormat.ForceSendFields = append(format.TextFormat.ForceSendFields, "Italic")
+		fields = append(fields, "userEnteredFormat.textFormat.italic")
+	}
+	if f.TextColor != "" {
+		color, err := parseHexColor(f.TextColor)
+		if err != nil {
+			return nil, err
+		}
+		format.TextFormat.ForegroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+		fields = append(fields, "userEnteredFormat.textFormat.foregroundColorStyle")
+	}
+	if f.Background != "" {
+		color, err := parseHexColor(f.Background)
+		if err != nil {
+			return nil, err
+		}
+		format.BackgroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+		fields = append(fields, "userEnteredFormat.backgroundColorStyle")
+	}
+	if f.NumberFormat != "" {
+		nf, ok := numberFormatPresets[strings.ToLower(f.NumberFormat)]
+		if !ok {
+			nf = sheets.NumberFormat{Type: "NUMBER", Pattern: f.NumberFormat}
+		}
+		format.NumberFormat = &nf
+		fields = append(fields, "userEnteredFormat.numberFormat")
+	}
+	if f.Align != "" {
+		align, ok := horizontalAlignments[strings.ToLower(f.Align)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported align %q; use left, center or right", f.Align)
+		}
+		format.HorizontalAlignment = align
+		fields = append(fields, "userEnteredFormat.horizontalAlignment")
+	}
+
+	var border *sheets.Border
+	if f.Borders != "" {
+		style, ok := borderStyles[strings.ToLower(f.Borders)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported borders %q; use %s", f.Borders, strings.Join(sortedKeys(borderStyles), ", "))
+		}
+		border = &sheets.Border{Style: style}
+	}
+	if len(fields) == 0 && border == nil {
+		return nil, fmt.Errorf("no formatting options for %s", f.Range)
+	}
+
+	return func(props []*sheets.SheetProperties) ([]*sheets.Request, error) {
+		gr, err := gridRange(props, r)
+		if err != nil {
+			return nil, err
+		}
+		var reqs []*sheets.Request
+		if len(fields) > 0 {
+			reqs = append(reqs, &sheets.Request{RepeatCell: &sheets.RepeatCellRequest{
+				Range:  gr,
+				Cell:   &sheets.CellData{UserEnteredFormat: format},
+				Fields: strings.Join(fields, ","),
+			}})
+		}
+		if border != nil {
+			reqs = append(reqs, &sheets.Request{UpdateBorders: &sheets.UpdateBordersRequest{
+				Range:           gr,
+				Top:             border,
+				Bottom:          border,
+				Left:            border,
+				Right:           border,
+				InnerHorizontal: border,
+				InnerVertical:   border,
+			}})
+		}
+		return reqs, nil
+	}, nil
+}
+
+func (v validationSpec) compile() (layoutOp, error) {
+	r, err := parseA1Range(v.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule *sheets.DataValidationRule
+	switch {
+	case v.Clear && len(v.List) > 0:
+		return nil, fmt.Errorf("list and clear cannot be combined")
+	case !v.Clear && len(v.List) == 0:
+		return nil, fmt.Errorf("list must have at least one value")
+	case !v.Clear:
+		values := make([]*sheets.ConditionValue, 0, len(v.List))
+		for _, item := range v.List {
+			values = append(values, &sheets.ConditionValue{UserEnteredValue: item})
+		}
+		rule = &sheets.DataValidationRule{
+			Condition:    &sheets.BooleanCondition{Type: "ONE_OF_LIST", Values: values},
+			Strict:       !v.AllowInvalid,
+			ShowCustomUi: true,
+		}
+	}
+
+	return func(props []*sheets.SheetProperties) ([]*sheets.Request, error) {
+		gr, err := gridRange(props, r)
+		if err != nil {
+			return nil, err
+		}
+		return []*sheets.Request{{SetDataValidation: &sheets.SetDataValidationRequest{Range: gr, Rule: rule}}}, nil
+	}, nil
+}
+
+func (cf conditionalFormatSpec) compile() (layoutOp, error) {
+	r, err := parseA1Range(cf.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := strings.ToUpper(cf.Condition)
+	n, ok := conditionValueCounts[condition]
+	if !ok {
+		return nil, fmt.Errorf("unsupported condition %q; use %s", cf.Condition, strings.Join(sortedKeys(conditionValueCounts), ", "))
+	}
+	if len(cf.Values) != n {
+		return nil, fmt.Errorf("condition %s takes %d value(s), got %d", condition, n, len(cf.Values))
+	}
+	values := make([]*sheets.ConditionValue, 0, n)
+	for _, v := range cf.Values {
+		values = append(values, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+
+	// Conditional formats only support text style and colors.
+	format := &sheets.CellFormat{}
+	if cf.Bold != nil || cf.Italic != nil || cf.TextColor != "" {
+		format.TextFormat = &sheets.TextFormat{}
+	}
+	if cf.Bold != nil {
+		format.TextFormat.Bold = *cf.Bold
+		format.TextFormat.ForceSendFields = append(format.TextFormat.ForceSendFields, "Bold")
+	}
+	if cf.Italic != nil {
+		format.TextFormat.Italic = *cf.Italic
+		format.TextFormat.ForceSendFields = append(format.TextFormat.ForceSendFields, "Italic")
+	}
+	if cf.TextColor != "" {
+		color, err := parseHexColor(cf.TextColor)
+		if err != nil {
+			return nil, err
+		}
+		format.TextFormat.ForegroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+	}
+	if cf.Background != "" {
+		color, err := parseHexColor(cf.Background)
+		if err != nil {
+			return nil, err
+		}
+		format.BackgroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+	}
+	if format.TextFormat == nil && format.BackgroundColorStyle == nil {
+		return nil, fmt.Errorf("no formatting options for %s", cf.Range)
+	}
+
+	return func(props []*sheets.SheetProperties) ([]*sheets.Request, error) {
+		gr, err := gridRange(props, r)
+		if err != nil {
+			return nil, err
+		}
+		return []*sheets.Request{{AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule: &sheets.ConditionalFormatRule{
+				Ranges: []*sheets.GridRange{gr},
+				BooleanRule: &sheets.BooleanRule{
+					Condition: &sheets.BooleanCondition{Type: condition, Values: values},
+					Format:    format,
+				},
+			},
+			Index:           0,
+			ForceSendFields: []string{"Index"},
+		}}}, nil
+	}, nil
+}
+
+func (f freezeSpec) compile() (layoutOp, error) {
+	if f.Rows < 0 || f.Columns < 0 {
+		return nil, fmt.Errorf("rows and columns must not be negative")
+	}
+
+	return func(props []*sheets.SheetProperties) ([]*sheets.Request, error) {
+		sheet, err := findSheet(props, f.Sheet)
+		if err != nil {
+			return nil, err
+		}
+		return []*sheets.Request{{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: sheet.SheetId,
+				GridProperties: &sheets.GridProperties{
+					FrozenRowCount:    int64(f.Rows),
+					FrozenColumnCount: int64(f.Columns),
+					ForceSendFields:   []string{"FrozenRowCount", "FrozenColumnCount"},
+				},
+			},
+			Fields: "gridProperties.frozenRowCount,gridProperties.frozenColumnCount",
+		}}}, nil
+	}, nil
+}
+
+func (a autosizeSpec) compile() (layoutOp, error) {
+	var start, end int64
+	if a.Columns != "" {
+		from, to, _ := strings.Cut(a.Columns, ":")
+		if to == "" {
+			to = from
+		}
+		fromCol, fromRow, ok1 := parseA1Endpoint(from)
+		toCol, toRow, ok2 := parseA1Endpoint(to)
+		if !ok1 || !ok2 || fromCol == 0 || toCol < fromCol || fromRow != 0 || toRow != 0 {
+			return nil, fmt.Errorf("invalid columns %q; use letters such as A:F", a.Columns)
+		}
+		start, end = int64(fromCol-1), int64(toCol)
+	}
+
+	return func(props []*sheets.SheetProperties) ([]*sheets.Request, error) {
+		sheet, err := findSheet(props, a.Sheet)
+		if err != nil {
+			return nil, err
+		}
+		return []*sheets.Request{{AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{
+			Dimensions: &sheets.DimensionRange{SheetId: sheet.SheetId, Dimension: "COLUMNS", StartIndex: start, EndIndex: end},
+		}}}, nil
+	}, nil
+}
+
+// parseHexColor reads a #RRGGBB color.
+func parseHexColor(s string) (*sheets.Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("invalid color %q; use #RRGGBB", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q; use #RRGGBB", s)
+	}
+
+	return &sheets.Color{
+		Red:             float64(n>>16&0xff) / 255,
+		Green:           float64(n>>8&0xff) / 255,
+		Blue:            float64(n&0xff) / 255,
+		ForceSendFields: []string{"Red", "Green", "Blue"},
+	}, nil
+}
+
+// applyLayout sends the requests of ops in one BatchUpdate, so a layout is
+// applied entirely or not at all.
+func applyLayout(ctx context.Context, root *RootFlags, action, account, spreadsheetID string, ops []layoutOp, params map[string]any) error {
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  action,
+			Account: normalizeEmail(account),
+			Target:  spreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		params["account"] = account
+		params["spreadsheet_id"] = spreadsheetID
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  action,
+			"params":  params,
+		})
+	}
+
+	errCode := strings.ReplaceAll(action, ".", "_") + "_error"
+	svc, err := googleapi.NewSheetsWrite(ctx, account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, spreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError(errCode, err)
+	}
+
+	var reqs []*sheets.Request
+	for _, op := range ops {
+		r, err := op(props)
+		if err != nil {
+			return writeGoogleAPIError(errCode, err)
+		}
+		reqs = append(reqs, r...)
+	}
+	if _, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{Requests: reqs}).Do(); err != nil {
+		return writeGoogleAPIError(errCode, err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  action,
+		Account: normalizeEmail(account),
+		Target:  spreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"applied":        true,
+		"spreadsheet_id": spreadsheetID,
+		"requests":       len(reqs),
+	})
+}
+
+// SheetsFormatCmd formats the cells of a range.
+type SheetsFormatCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range         string `name:"range" required:"" help:"Range to format (e.g. Sheet1!A1:F1)."`
+	Bold          bool   `name:"bold" help:"Make text bold."`
+	Italic        bool   `name:"italic" help:"Make text italic."`
+	NumberFormat  string `name:"number-format" help:"text, number, integer, percent, currency, date, time, datetime, scientific, or a pattern such as 0.0."`
+	Background    string `name:"background" help:"Background color as #RRGGBB."`
+	TextColor     string `name:"text-color" help:"Text color as #RRGGBB."`
+	Align         string `name:"align" help:"Horizontal alignment: left, center or right."`
+	Borders       string `name:"borders" help:"Border style around and between cells: solid, solid_medium, solid_thick, dashed, dotted, double or none."`
+}
+
+func (c *SheetsFormatCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.format"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	f := formatSpec{
+		Range:        c.Range,
+		NumberFormat: c.NumberFormat,
+		Background:   c.Background,
+		TextColor:    c.TextColor,
+		Align:        c.Align,
+		Borders:      c.Borders,
+	}
+	if c.Bold {
+		f.Bold = &c.Bold
+	}
+	if c.Italic {
+		f.Italic = &c.Italic
+	}
+	spec := layoutSpec{Format: []formatSpec{f}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.format", c.Account, c.SpreadsheetID, ops, map[string]any{"format": spec.Format})
+}
+
+// SheetsValidationSetCmd adds a dropdown list to a range.
+type SheetsValidationSetCmd struct {
+	Account       string   `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string   `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range         string   `name:"range" required:"" help:"Range to validate (e.g. Tasks!C2:C)."`
+	List          []string `name:"list" required:"" help:"Comma-separated allowed values."`
+	AllowInvalid  bool     `name:"allow-invalid" help:"Show a warning for other values instead of rejecting them."`
+}
+
+func (c *SheetsValidationSetCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.validation.set"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	spec := layoutSpec{Validation: []validationSpec{{Range: c.Range, List: c.List, AllowInvalid: c.AllowInvalid}}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.validation.set", c.Account, c.SpreadsheetID, ops, map[string]any{"validation": spec.Validation})
+}
+
+// SheetsValidationClearCmd removes data validation from a range.
+type SheetsValidationClearCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range         string `name:"range" required:"" help:"Range to remove validation from."`
+}
+
+func (c *SheetsValidationClearCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.validation.clear"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	spec := layoutSpec{Validation: []validationSpec{{Range: c.Range, Clear: true}}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.validation.clear", c.Account, c.SpreadsheetID, ops, map[string]any{"validation": spec.Validation})
+}
+
+// SheetsConditionalFormatCmd adds a conditional format rule to a range.
+type SheetsConditionalFormatCmd struct {
+	Account       string   `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string   `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range         string   `name:"range" required:"" help:"Range the rule applies to (e.g. Data!C2:C)."`
+	Condition     string   `name:"condition" required:"" help:"Condition such as NUMBER_GREATER, NUMBER_BETWEEN, TEXT_CONTAINS, NOT_BLANK or CUSTOM_FORMULA."`
+	Values        []string `name:"value" sep:"none" help:"Condition value or formula (e.g. =$C2>100). Repeat for conditions that take two values."`
+	Background    string   `name:"background" help:"Background color as #RRGGBB for matching cells."`
+	TextColor     string   `name:"text-color" help:"Text color as #RRGGBB for matching cells."`
+	Bold          bool     `name:"bold" help:"Make matching text bold."`
+	Italic        bool     `name:"italic" help:"Make matching text italic."`
+}
+
+func (c *SheetsConditionalFormatCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.conditional_format"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	cf := conditionalFormatSpec{
+		Range:      c.Range,
+		Condition:  c.Condition,
+		Values:     c.Values,
+		Background: c.Background,
+		TextColor:  c.TextColor,
+	}
+	if c.Bold {
+		cf.Bold = &c.Bold
+	}
+	if c.Italic {
+		cf.Italic = &c.Italic
+	}
+	spec := layoutSpec{ConditionalFormats: []conditionalFormatSpec{cf}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.conditional_format", c.Account, c.SpreadsheetID, ops, map[string]any{"conditional_formats": spec.ConditionalFormats})
+}
+
+// SheetsFreezeCmd freezes the top rows and left columns of a tab.
+type SheetsFreezeCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Tab title."`
+	Rows          int    `name:"rows" help:"Number of rows to freeze (0 unfreezes)."`
+	Columns       int    `name:"columns" help:"Number of columns to freeze (0 unfreezes)."`
+}
+
+func (c *SheetsFreezeCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.freeze"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	spec := layoutSpec{Freeze: []freezeSpec{{Sheet: c.Sheet, Rows: c.Rows, Columns: c.Columns}}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.freeze", c.Account, c.SpreadsheetID, ops, map[string]any{"freeze": spec.Freeze})
+}
+
+// SheetsAutosizeCmd fits column widths to their contents.
+type SheetsAutosizeCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Tab title."`
+	Columns       string `name:"columns" help:"Columns to resize, such as A:F (default: all)."`
+}
+
+func (c *SheetsAutosizeCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.autosize"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	spec := layoutSpec{Autosize: []autosizeSpec{{Sheet: c.Sheet, Columns: c.Columns}}}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.autosize", c.Account, c.SpreadsheetID, ops, map[string]any{"autosize": spec.Autosize})
+}
+
+// SheetsLayoutCmd applies a JSON layout spec in one request.
+type SheetsLayoutCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Spec          string `name:"spec" required:"" help:"JSON file with format, validation, conditional_formats, freeze and autosize entries (- for stdin)."`
+}
+
+func (c *SheetsLayoutCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.layout"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	data, err := readInputFile(c.Spec)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+	}
+	spec, err := parseLayoutSpec(data)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+	ops, err := spec.compile()
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	return applyLayout(ctx, root, "sheets.layout", c.Account, c.SpreadsheetID, ops, map[string]any{
+		"spec":       spec,
+		"operations": len(ops),
+	})
+}
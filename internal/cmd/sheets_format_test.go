@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var layoutTestProps = []*sheets.SheetProperties{{SheetId: 0, Title: "Summary"}, {SheetId: 7, Title: "Data"}}
+
+func buildLayout(t *testing.T, spec layoutSpec) []*sheets.Request {
+	t.Helper()
+	ops, err := spec.compile()
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	var reqs []*sheets.Request
+	for _, op := range ops {
+		r, err := op(layoutTestProps)
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		reqs = append(reqs, r...)
+	}
+	return reqs
+}
+
+func TestFormatSpec_Requests(t *testing.T) {
+	bold := true
+	reqs := buildLayout(t, layoutSpec{Format: []formatSpec{{
+		Range:        "Data!A1:F1",
+		Bold:         &bold,
+		NumberFormat: "percent",
+		Background:   "#ff8000",
+		Borders:      "solid",
+	}}})
+	if len(reqs) != 2 || reqs[0].RepeatCell == nil || reqs[1].UpdateBorders == nil {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	rc := reqs[0].RepeatCell
+	if rc.Range.SheetId != 7 || rc.Range.EndColumnIndex != 6 || rc.Range.EndRowIndex != 1 {
+		t.Errorf("range = %+v", rc.Range)
+	}
+	if want := "userEnteredFormat.textFormat.bold,userEnteredFormat.backgroundColorStyle,userEnteredFormat.numberFormat"; rc.Fields != want {
+		t.Errorf("fields = %q, want %q", rc.Fields, want)
+	}
+	f := rc.Cell.UserEnteredFormat
+	if !f.TextFormat.Bold || f.NumberFormat.Type != "PERCENT" {
+		t.Errorf("format = %+v", f)
+	}
+	if c := f.BackgroundColorStyle.RgbColor; c.Red != 1 || c.Green != float64(0x80)/255 || c.Blue != 0 {
+		t.Errorf("background = %+v", c)
+	}
+	if reqs[1].UpdateBorders.InnerVertical.Style != "SOLID" {
+		t.Errorf("borders = %+v", reqs[1].UpdateBorders)
+	}
+
+	// A custom pattern is used as a NUMBER format.
+	reqs = buildLayout(t, layoutSpec{Format: []formatSpec{{Range: "B2:B", NumberFormat: "0.0"}}})
+	if nf := reqs[0].RepeatCell.Cell.UserEnteredFormat.NumberFormat; nf.Type != "NUMBER" || nf.Pattern != "0.0" {
+		t.Errorf("number format = %+v", nf)
+	}
+}
+
+func TestLayoutSpec_ValidationFreezeAutosize(t *testing.T) {
+	reqs := buildLayout(t, layoutSpec{
+		Validation: []validationSpec{{Range: "Data!C2:C", List: []string{"open", "done"}}, {Range: "Data!D2:D", Clear: true}},
+		Freeze:     []freezeSpec{{Sheet: "Data", Rows: 1}},
+		Autosize:   []autosizeSpec{{Sheet: "Data", Columns: "B:D"}, {Sheet: "Summary"}},
+	})
+	if len(reqs) != 5 {
+		t.Fatalf("got %d requests", len(reqs))
+	}
+
+	rule := reqs[0].SetDataValidation.Rule
+	if rule.Condition.Type != "ONE_OF_LIST" || len(rule.Condition.Values) != 2 || !rule.Strict || !rule.ShowCustomUi {
+		t.Errorf("rule = %+v", rule)
+	}
+	if reqs[1].SetDataValidation.Rule != nil {
+		t.Error("clear should send no rule")
+	}
+	if gp := reqs[2].UpdateSheetProperties.Properties.GridProperties; gp.FrozenRowCount != 1 || gp.FrozenColumnCount != 0 {
+		t.Errorf("freeze = %+v", gp)
+	}
+	if d := reqs[3].AutoResizeDimensions.Dimensions; d.SheetId != 7 || d.StartIndex != 1 || d.EndIndex != 4 {
+		t.Errorf("autosize = %+v", d)
+	}
+	if d := reqs[4].AutoResizeDimensions.Dimensions; d.SheetId != 0 || d.EndIndex != 0 {
+		t.Errorf("autosize all = %+v", d)
+	}
+}
+
+func TestLayoutSpec_ConditionalFormats(t *testing.T) {
+	bold := true
+	spec, err := parseLayoutSpec(`{"conditional_formats":[
+		{"range":"Data!C2:C","condition":"number_between","values":["10","20"],"background":"#ffff00"},
+		{"range":"Data!A2:F","condition":"CUSTOM_FORMULA","values":["=$F2=\"late\""],"text_color":"#ff0000"}
+	]}`)
+	if err != nil {
+		t.Fatalf("parseLayoutSpec: %v", err)
+	}
+	spec.ConditionalFormats[1].Bold = &bold
+	reqs := buildLayout(t, spec)
+	if len(reqs) != 2 || reqs[0].AddConditionalFormatRule == nil {
+		t.Fatalf("requests = %+v", reqs)
+	}
+
+	first := reqs[0].AddConditionalFormatRule
+	if first.Index != 0 || first.Rule.Ranges[0].SheetId != 7 || first.Rule.Ranges[0].StartColumnIndex != 2 {
+		t.Errorf("rule placement = %+v", first)
+	}
+	br := first.Rule.BooleanRule
+	if br.Condition.Type != "NUMBER_BETWEEN" || len(br.Condition.Values) != 2 || br.Condition.Values[1].UserEnteredValue != "20" {
+		t.Errorf("condition = %+v", br.Condition)
+	}
+	if br.Format.BackgroundColorStyle.RgbColor.Blue != 0 || br.Format.TextFormat != nil {
+		t.Errorf("format = %+v", br.Format)
+	}
+
+	second := reqs[1].AddConditionalFormatRule.Rule.BooleanRule
+	if second.Condition.Values[0].UserEnteredValue != `=$F2="late"` || !second.Format.TextFormat.Bold ||
+		second.Format.TextFormat.ForegroundColorStyle.RgbColor.Red != 1 {
+		t.Errorf("custom formula rule = %+v", second)
+	}
+}
+
+func TestLayoutSpec_Errors(t *testing.T) {
+	bad := []layoutSpec{
+		{},
+		{Format: []formatSpec{{Range: "A1"}}},
+		{Format: []formatSpec{{Range: "A1", Background: "red"}}},
+		{Format: []formatSpec{{Range: "A1", Borders: "wavy"}}},
+		{Validation: []validationSpec{{Range: "A1:A9"}}},
+		{Freeze: []freezeSpec{{Sheet: "Data", Rows: -1}}},
+		{Autosize: []autosizeSpec{{Sheet: "Data", Columns: "D:B"}}},
+		{Autosize: []autosizeSpec{{Sheet: "Data", Columns: "A1:B2"}}},
+		{ConditionalFormats: []conditionalFormatSpec{{Range: "A1:A9", Condition: "NUMBER_GREATER", Values: []string{"1"}}}},
+		{ConditionalFormats: []conditionalFormatSpec{{Range: "A1:A9", Condition: "NUMBER_BETWEEN", Values: []string{"1"}, Background: "#ffffff"}}},
+		{ConditionalFormats: []conditionalFormatSpec{{Range: "A1:A9", Condition: "DATE_SOON", Background: "#ffffff"}}},
+	}
+	for _, spec := range bad {
+		if _, err := spec.compile(); err == nil {
+			t.Errorf("expected error for %+v", spec)
+		}
+	}
+
+	if _, err := parseLayoutSpec(`{"format":[{"range":"A1","bolt":true}]}`); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestSheetsLayoutCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	path := filepath.Join(t.TempDir(), "layout.json")
+	spec := `{
+		"format": [{"range": "Data!A1:F1", "bold": true, "background": "#eeeeee"}],
+		"validation": [{"range": "Data!C2:C", "list": ["open", "done"]}],
+		"freeze": [{"sheet": "Data", "rows": 1}],
+		"autosize": [{"sheet": "Data"}]
+	}`
+	if err := os.WriteFile(path, []byte(spec), 0o600); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	cmd := &SheetsLayoutCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Spec: path}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			Operations int        `json:"operations"`
+			Spec       layoutSpec `json:"spec"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "sheets.layout" || payload.Params.Operations != 4 || len(payload.Params.Spec.Validation) != 1 {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+}
+
+func TestSheetsFormatCmd_RequiresAnOption(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SheetsFormatCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Range: "A1:B2"}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{DryRun: true}) }); code != "invalid_arguments" {
+		t.Errorf("code = %q", code)
+	}
+}
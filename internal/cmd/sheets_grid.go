@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+)
+
+// a1Range is an A1 range with zero-based, end-exclusive bounds as used by
+// Spreadsheets.BatchUpdate. A nil bound is open, as in "A:C" or "2:5".
+type a1Range struct {
+	// sheet is the unquoted sheet title; empty means the first sheet.
+	sheet    string
+	startRow *int64
+	endRow   *int64
+	startCol *int64
+	endCol   *int64
+}
+
+var a1EndpointPattern = regexp.MustCompile(`^\$?([A-Za-z]{0,3})\$?([0-9]*)$`)
+
+// parseA1Endpoint returns the 1-based column and row of one side of an A1
+// range; either may be 0 when the side leaves it open.
+func parseA1Endpoint(s string) (col, row int, ok bool) {
+	m := a1EndpointPattern.FindStringSubmatch(s)
+	if m == nil || (m[1] == "" && m[2] == "") {
+		return 0, 0, false
+	}
+	if m[2] != "" {
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n < 1 {
+			return 0, 0, false
+		}
+		row = n
+	}
+
+	return columnNumber(m[1]), row, true
+}
+
+// parseA1Range parses rangeA1. Without "!", a value that is not a range of
+// cells is a sheet name, so "Tasks" covers the whole Tasks sheet.
+func parseA1Range(rangeA1 string) (a1Range, error) {
+	sheet, cells := "", rangeA1
+	if i := strings.LastIndex(rangeA1, "!"); i >= 0 {
+		sheet, cells = rangeA1[:i], rangeA1[i+1:]
+	}
+
+	r, ok := parseA1Cells(cells)
+	if !ok {
+		if sheet == "" && rangeA1 != "" {
+			return a1Range{sheet: unquoteSheetName(rangeA1)}, nil
+		}
+		return a1Range{}, fmt.Errorf("invalid range %q", rangeA1)
+	}
+	r.sheet = unquoteSheetName(sheet)
+
+	return r, nil
+}
+
+func parseA1Cells(cells string) (a1Range, bool) {
+	from, to, isRange := strings.Cut(cells, ":")
+	startCol, startRow, ok := parseA1Endpoint(from)
+	if !ok {
+		return a1Range{}, false
+	}
+	endCol, endRow := startCol, startRow
+	if isRange {
+		if endCol, endRow, ok = parseA1Endpoint(to); !ok {
+			return a1Range{}, false
+		}
+	} else if startCol == 0 || startRow == 0 {
+		// A single cell needs both a column and a row.
+		return a1Range{}, false
+	}
+
+	var r a1Range
+	if startCol > 0 {
+		r.startCol = int64Ptr(int64(startCol - 1))
+	}
+	if endCol > 0 {
+		r.endCol = int64Ptr(int64(endCol))
+	}
+	if startRow > 0 {
+		r.startRow = int64Ptr(int64(startRow - 1))
+	}
+	if endRow > 0 {
+		r.endRow = int64Ptr(int64(endRow))
+	}
+	if (r.startCol != nil && r.endCol != nil && *r.endCol <= *r.startCol) ||
+		(r.startRow != nil && r.endRow != nil && *r.endRow <= *r.startRow) {
+		return a1Range{}, false
+	}
+
+	return r, true
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// unquoteSheetName reverses quoteSheetName.
+func unquoteSheetName(name string) string {
+	if len(name) >= 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		return strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+
+	return name
+}
+
+// readSheetProperties lists the properties of every sheet in a spreadsheet.
+func readSheetProperties(svc *sheets.Service, spreadsheetID string) ([]*sheets.SheetProperties, error) {
+	ss, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties(sheetId,title,index,gridProperties)").Do()
+	if err != nil {
+		return nil, err
+	}
+
+	props := make([]*sheets.SheetProperties, 0, len(ss.Sheets))
+	for _, s := range ss.Sheets {
+		if s.Properties != nil {
+			props = append(props, s.Properties)
+		}
+	}
+
+	return props, nil
+}
+
+// findSheet returns the sheet named title, or the first sheet when title is
+// empty. A missing sheet is reported as a not-found API error.
+func findSheet(props []*sheets.SheetProperties, title string) (*sheets.SheetProperties, error) {
+	for _, p := range props {
+		if title == "" || p.Title == title {
+			return p, nil
+		}
+	}
+	if title == "" {
+		return nil, &gapi.Error{Code: http.StatusNotFound, Message: "spreadsheet has no sheets"}
+	}
+
+	return nil, &gapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("sheet %q not found", title)}
+}
+
+// gridRange resolves r against the sheets of a spreadsheet.
+func gridRange(props []*sheets.SheetProperties, r a1Range) (*sheets.GridRange, error) {
+	sheet, err := findSheet(props, r.sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	gr := &sheets.GridRange{SheetId: sheet.SheetId}
+	if r.startRow != nil {
+		gr.StartRowIndex = *r.startRow
+	}
+	if r.endRow != nil {
+		gr.EndRowIndex = *r.endRow
+	}
+	if r.startCol != nil {
+		gr.StartColumnIndex = *r.startCol
+	}
+	if r.endCol != nil {
+		gr.EndColumnIndex = *r.endCol
+	}
+
+	return gr, nil
+}
+
+// countFilled counts the rows and cells of values that hold a value.
+func countFilled(values [][]any) (rows, cells int) {
+	for _, row := range values {
+		filled := 0
+		for _, v := range row {
+			if cellString(v) != "" {
+				filled++
+			}
+		}
+		if filled > 0 {
+			rows++
+			cells += filled
+		}
+	}
+
+	return rows, cells
+}
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// maxImportBytes caps sheets import input. Larger than the stdin limit for
+// JSON values, since imports are written in chunks.
+const maxImportBytes = 100 * 1024 * 1024
+
+// importPreviewRows is how many rows a dry-run import shows.
+const importPreviewRows = 5
+
+// readImportFile reads path, or stdin when path is "-".
+func readImportFile(path string) ([]byte, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r, maxImportBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if int64(len(b)) > maxImportBytes {
+		return nil, fmt.Errorf("%s exceeds %d bytes", path, maxImportBytes)
+	}
+
+	return b, nil
+}
+
+// decodeText converts b to UTF-8 and drops a leading byte order mark, which
+// spreadsheet applications often write to CSV files.
+func decodeText(b []byte, encoding string) (string, error) {
+	switch strings.ToLower(strings.ReplaceAll(encoding, "-", "_")) {
+	case "", "utf8", "utf_8":
+		b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+		if !utf8.Valid(b) {
+			return "", fmt.Errorf("input is not valid UTF-8; set --encoding shift_jis for Excel CSV files")
+		}
+		return string(b), nil
+	case "shift_jis", "sjis", "cp932":
+		out, err := japanese.ShiftJIS.NewDecoder().Bytes(b)
+		if err != nil {
+			return "", fmt.Errorf("decode Shift_JIS: %w", err)
+		}
+		return string(out), nil
+	}
+
+	return "", fmt.Errorf("unsupported encoding %q; use utf-8 or shift_jis", encoding)
+}
+
+// parseDelimited parses CSV or TSV text into rows. Quoted fields may contain
+// delimiters and line breaks; rows may have different lengths.
+func parseDelimited(text, format string) ([][]any, error) {
+	r := csv.NewReader(strings.NewReader(text))
+	r.FieldsPerRecord = -1
+	if format == "tsv" {
+		r.Comma = '\t'
+		// TSV files rarely quote fields, so a stray quote is kept as text.
+		r.LazyQuotes = true
+	}
+
+	var rows [][]any
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", format, err)
+		}
+		row := make([]any, len(record))
+		for i, v := range record {
+			row[i] = v
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("input has no rows")
+	}
+
+	return rows, nil
+}
+
+// delimitedFormat returns csv or tsv from flag, or from the file extension
+// when flag is empty.
+func delimitedFormat(flag, path string) (string, error) {
+	format := strings.ToLower(flag)
+	if format == "" {
+		format = "csv"
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".tsv" || ext == ".tab" {
+			format = "tsv"
+		}
+	}
+	if format != "csv" && format != "tsv" {
+		return "", fmt.Errorf("unsupported format %q; use csv or tsv", flag)
+	}
+
+	return format, nil
+}
+
+var a1CellPattern = regexp.MustCompile(`^\$?([A-Za-z]{1,3})\$?([0-9]*)$`)
+
+// a1Start is the top-left cell of an A1 range.
+type a1Start struct {
+	// sheet is the sheet part as written, quotes included; empty means the
+	// first sheet.
+	sheet string
+	col   int
+	row   int
+}
+
+// parseA1Start reads the first cell of rangeA1. A bare name without "!" is a
+// sheet unless it looks like a cell, so "Tasks" starts at Tasks!A1.
+func parseA1Start(rangeA1 string) (a1Start, error) {
+	sheet, cells := "", rangeA1
+	if i := strings.LastIndex(rangeA1, "!"); i >= 0 {
+		sheet, cells = rangeA1[:i], rangeA1[i+1:]
+	} else if m := a1CellPattern.FindStringSubmatch(strings.SplitN(rangeA1, ":", 2)[0]); m == nil || m[2] == "" {
+		return a1Start{sheet: rangeA1, col: 1, row: 1}, nil
+	}
+
+	first := strings.SplitN(cells, ":", 2)[0]
+	m := a1CellPattern.FindStringSubmatch(first)
+	if m == nil {
+		return a1Start{}, fmt.Errorf("invalid range %q", rangeA1)
+	}
+	start := a1Start{sheet: sheet, col: columnNumber(m[1]), row: 1}
+	if m[2] != "" {
+		row, err := strconv.Atoi(m[2])
+		if err != nil || row < 1 {
+			return a1Start{}, fmt.Errorf("invalid range %q", rangeA1)
+		}
+		start.row = row
+	}
+
+	return start, nil
+}
+
+// cell returns the A1 reference rowOffset rows and colOffset columns from s.
+func (s a1Start) cell(rowOffset, colOffset int) string {
+	ref := columnLetters(s.col+colOffset) + strconv.Itoa(s.row+rowOffset)
+	if s.sheet == "" {
+		return ref
+	}
+
+	return s.sheet + "!" + ref
+}
+
+// columnNumber converts column letters to a 1-based index: A is 1, AA is 27.
+func columnNumber(letters string) int {
+	n := 0
+	for _, r := range strings.ToUpper(letters) {
+		n = n*26 + int(r-'A'+1)
+	}
+
+	return n
+}
+
+// columnLetters converts a 1-based column index to letters.
+func columnLetters(n int) string {
+	var b []byte
+	for n > 0 {
+		n--
+		b = append([]byte{byte('A' + n%26)}, b...)
+		n /= 26
+	}
+
+	return string(b)
+}
+
+func maxRowWidth(rows [][]any) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	return width
+}
+
+// SheetsImportCmd imports a CSV or TSV file.
+type SheetsImportCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	File          string `name:"file" required:"" help:"CSV or TSV file to import (- for stdin)."`
+	Range         string `name:"range" required:"" help:"Top-left cell or sheet to write to (e.g. Sheet1!B2); with --append, the table to append to."`
+	Format        string `name:"format" help:"Input format: csv or tsv (default: from the file extension, else csv)."`
+	Encoding      string `name:"encoding" default:"utf-8" help:"Input encoding: utf-8 or shift_jis."`
+	Append        bool   `name:"append" help:"Append rows after the table in --range instead of writing from its first cell."`
+	ChunkRows     int    `name:"chunk-rows" default:"1000" help:"Rows written per request."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
+}
+
+func (c *SheetsImportCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.import"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
+	format, err := delimitedFormat(c.Format, c.File)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_format", err.Error())
+	}
+	if c.ChunkRows < 1 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--chunk-rows must be at least 1")
+	}
+	start, err := parseA1Start(c.Range)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	data, err := readImportFile(c.File)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+	}
+	text, err := decodeText(data, c.Encoding)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+	rows, err := parseDelimited(text, format)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	width := maxRowWidth(rows)
+	chunks := (len(rows) + c.ChunkRows - 1) / c.ChunkRows
+	target := c.Range
+	if !c.Append {
+		target = start.cell(0, 0) + ":" + columnLetters(start.col+max(width, 1)-1) + strconv.Itoa(start.row+len(rows)-1)
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.import",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.import",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"file":           c.File,
+				"format":         format,
+				"append":         c.Append,
+				"target_range":   target,
+				"row_count":      len(rows),
+				"column_count":   width,
+				"chunks":         chunks,
+				"input_option":   inputOption,
+				"preview":        rows[:min(len(rows), importPreviewRows)],
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	// Chunks are written in order; a failure audits and reports how far the
+	// import got so that it can be resumed from the next row.
+	auditImport := func() error {
+		return appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.import",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  false,
+		})
+	}
+	written := 0
+	for i := 0; i < chunks; i++ {
+		chunk := rows[written:min(len(rows), written+c.ChunkRows)]
+		vr := &sheets.ValueRange{Values: chunk}
+		if c.Append {
+			_, err = svc.Spreadsheets.Values.Append(c.SpreadsheetID, c.Range, vr).
+				ValueInputOption(inputOption).InsertDataOption("INSERT_ROWS").Do()
+		} else {
+			_, err = svc.Spreadsheets.Values.Update(c.SpreadsheetID, start.cell(written, 0), vr).
+				ValueInputOption(inputOption).Do()
+		}
+		if err != nil {
+			if written > 0 {
+				if auditErr := auditImport(); auditErr != nil {
+					return output.WriteError(output.ExitCodeError, "audit_error", auditErr.Error())
+				}
+			}
+			return writeGoogleAPIErrorDetails("sheets_import_error",
+				fmt.Errorf("chunk %d of %d failed after %d of %d rows were written: %w", i+1, chunks, written, len(rows), err),
+				map[string]any{"rows_written": written, "chunks_written": i, "row_count": len(rows)})
+		}
+		written += len(chunk)
+	}
+
+	if err := auditImport(); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"imported":       true,
+		"spreadsheet_id": c.SpreadsheetID,
+		"target_range":   target,
+		"rows_written":   written,
+		"column_count":   width,
+		"chunks":         chunks,
+		"input_option":   inputOption,
+	})
+}
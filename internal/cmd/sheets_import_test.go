@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestParseA1Start(t *testing.T) {
+	cases := map[string]struct {
+		start a1Start
+		cell  string
+	}{
+		"Sheet1!B3":      {a1Start{sheet: "Sheet1", col: 2, row: 3}, "Sheet1!C5"},
+		"'Q1 data'!A1:C": {a1Start{sheet: "'Q1 data'", col: 1, row: 1}, "'Q1 data'!B3"},
+		"AA10":           {a1Start{col: 27, row: 10}, "AB12"},
+		"Tasks":          {a1Start{sheet: "Tasks", col: 1, row: 1}, "Tasks!B3"},
+		"Sheet1!C:C":     {a1Start{sheet: "Sheet1", col: 3, row: 1}, "Sheet1!D3"},
+	}
+	for in, want := range cases {
+		got, err := parseA1Start(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if got != want.start {
+			t.Errorf("%q: got %+v, want %+v", in, got, want.start)
+		}
+		if cell := got.cell(2, 1); cell != want.cell {
+			t.Errorf("%q: cell(2, 1) = %q, want %q", in, cell, want.cell)
+		}
+	}
+
+	if _, err := parseA1Start("Sheet1!1A"); err == nil {
+		t.Error("expected error for invalid cell")
+	}
+}
+
+func TestColumnLetters(t *testing.T) {
+	for n, letters := range map[int]string{1: "A", 26: "Z", 27: "AA", 52: "AZ", 703: "AAA"} {
+		if got := columnLetters(n); got != letters {
+			t.Errorf("columnLetters(%d) = %q, want %q", n, got, letters)
+		}
+		if got := columnNumber(letters); got != n {
+			t.Errorf("columnNumber(%q) = %d, want %d", letters, got, n)
+		}
+	}
+}
+
+func TestParseDelimited(t *testing.T) {
+	rows, err := parseDelimited("name,note\n\"Smith, Bob\",\"line1\nline2\"\nAlice\n", "csv")
+	if err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	want := [][]any{{"name", "note"}, {"Smith, Bob", "line1\nline2"}, {"Alice"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("csv rows = %q, want %q", rows, want)
+	}
+
+	rows, err = parseDelimited("a\t5\" screen\nb\tc\n", "tsv")
+	if err != nil {
+		t.Fatalf("tsv: %v", err)
+	}
+	if want := [][]any{{"a", "5\" screen"}, {"b", "c"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("tsv rows = %q, want %q", rows, want)
+	}
+
+	if _, err := parseDelimited("", "csv"); err == nil {
+		t.Error("expected error for empty input")
+	}
+	if _, err := parseDelimited("a,\"b\n", "csv"); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	got, err := decodeText([]byte("\xef\xbb\xbf名前,年齢\n"), "utf-8")
+	if err != nil || got != "名前,年齢\n" {
+		t.Errorf("utf-8 with BOM: %q, %v", got, err)
+	}
+
+	sjis, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte("名前,年齢\n"))
+	if err != nil {
+		t.Fatalf("encode Shift_JIS: %v", err)
+	}
+	if _, err := decodeText(sjis, "utf-8"); err == nil || !strings.Contains(err.Error(), "shift_jis") {
+		t.Errorf("Shift_JIS read as utf-8: %v", err)
+	}
+	got, err = decodeText(sjis, "Shift_JIS")
+	if err != nil || got != "名前,年齢\n" {
+		t.Errorf("shift_jis: %q, %v", got, err)
+	}
+
+	if _, err := decodeText([]byte("x"), "latin1"); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
+
+func TestSheetsImportCmd_DryRunPreview(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	var b strings.Builder
+	b.WriteString("id\tname\n")
+	for i := range 2500 {
+		b.WriteString("r" + string(rune('a'+i%26)) + "\tvalue\n")
+	}
+	path := filepath.Join(t.TempDir(), "rows.tsv")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	cmd := &SheetsImportCmd{
+		Account:       "a@example.com",
+		SpreadsheetID: "ss-1",
+		File:          path,
+		Range:         "Data!B2",
+		Encoding:      "utf-8",
+		ChunkRows:     1000,
+	}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			Format      string  `json:"format"`
+			TargetRange string  `json:"target_range"`
+			RowCount    int     `json:"row_count"`
+			ColumnCount int     `json:"column_count"`
+			Chunks      int     `json:"chunks"`
+			InputOption string  `json:"input_option"`
+			Preview     [][]any `json:"preview"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	p := payload.Params
+	if payload.Action != "sheets.import" || p.Format != "tsv" || p.TargetRange != "Data!B2:C2502" ||
+		p.RowCount != 2501 || p.ColumnCount != 2 || p.Chunks != 3 || p.InputOption != "USER_ENTERED" {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+	if len(p.Preview) != importPreviewRows || p.Preview[0][1] != "name" {
+		t.Errorf("preview = %v", p.Preview)
+	}
+
+	entries := readAuditEntries(t)
+	if len(entries) != 1 || entries[0].Action != "sheets.import" || !entries[0].DryRun {
+		t.Errorf("audit entries = %+v", entries)
+	}
+}
+
+func TestSheetsImportCmd_RejectsBadArguments(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{DryRun: true}
+	cmd := &SheetsImportCmd{Account: "a@example.com", SpreadsheetID: "ss-1", File: "rows.xlsx", Format: "xlsx", Range: "A1", ChunkRows: 10}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), root) }); code != "invalid_format" {
+		t.Errorf("xlsx: code = %q", code)
+	}
+
+	cmd = &SheetsImportCmd{Account: "a@example.com", SpreadsheetID: "ss-1", File: "rows.csv", Range: "A1", ChunkRows: 0}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), root) }); code != "invalid_arguments" {
+		t.Errorf("chunk-rows 0: code = %q", code)
+	}
+}
+
+func TestSheetsAppendCmd_CSVValues(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &SheetsAppendCmd{
+		Account:       "a@example.com",
+		SpreadsheetID: "ss-1",
+		Range:         "Sheet1",
+		Values:        "\"Smith, Bob\",25\nAlice,30\n",
+		ValuesFormat:  "csv",
+		Encoding:      "utf-8",
+	}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var payload struct {
+		Params struct {
+			RowCount int `json:"row_count"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Params.RowCount != 2 {
+		t.Errorf("row_count = %d, want 2", payload.Params.RowCount)
+	}
+}
@@ -0,0 +1,559 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// SheetsRecordsCmd groups header-aware record subcommands.
+type SheetsRecordsCmd struct {
+	Get    SheetsRecordsGetCmd    `cmd:"" help:"Get rows as JSON objects keyed by the header row."`
+	Append SheetsRecordsAppendCmd `cmd:"" help:"Append JSON objects as rows, matching keys to header names."`
+	Upsert SheetsRecordsUpsertCmd `cmd:"" help:"Update rows by a key column, appending records whose key is new."`
+}
+
+// quoteSheetName returns name as an A1 sheet reference.
+func quoteSheetName(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// sheetTable is a sheet read as a header row followed by data rows.
+type sheetTable struct {
+	headers []string
+	rows    [][]any
+	// columns maps each header name to its column index.
+	columns map[string]int
+}
+
+// readSheetValues reads every cell of a sheet, starting at A1.
+func readSheetValues(svc *sheets.Service, spreadsheetID, sheet, valueRender string) ([][]any, error) {
+	call := svc.Spreadsheets.Values.Get(spreadsheetID, quoteSheetName(sheet))
+	if valueRender != "" {
+		call = call.ValueRenderOption(valueRender)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Values, nil
+}
+
+// newSheetTable splits values into the header row and data rows. Empty
+// header cells are ignored and duplicate names are an error, since they
+// would make records ambiguous.
+func newSheetTable(values [][]any) (*sheetTable, error) {
+	t := &sheetTable{headers: []string{}, columns: map[string]int{}}
+	if len(values) == 0 {
+		return t, nil
+	}
+
+	for i, v := range values[0] {
+		name := strings.TrimSpace(cellString(v))
+		t.headers = append(t.headers, name)
+		if name == "" {
+			continue
+		}
+		if _, dup := t.columns[name]; dup {
+			return nil, fmt.Errorf("header row has duplicate column %q", name)
+		}
+		t.columns[name] = i
+	}
+	t.rows = values[1:]
+
+	return t, nil
+}
+
+// records returns the data rows as objects. Every named column is present in
+// every record; fully empty rows are skipped.
+func (t *sheetTable) records() []map[string]any {
+	out := []map[string]any{}
+	for _, row := range t.rows {
+		if rowIsEmpty(row) {
+			continue
+		}
+		rec := make(map[string]any, len(t.columns))
+		for name, i := range t.columns {
+			if i < len(row) {
+				rec[name] = row[i]
+			} else {
+				rec[name] = ""
+			}
+		}
+		out = append(out, rec)
+	}
+
+	return out
+}
+
+func rowIsEmpty(row []any) bool {
+	for _, v := range row {
+		if cellString(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// row lays out rec in header order. Columns the record does not mention are
+// nil, which the Sheets API skips, so existing cells keep their values.
+func (t *sheetTable) row(rec map[string]any) ([]any, error) {
+	if len(t.columns) == 0 {
+		return nil, fmt.Errorf("sheet has no header row")
+	}
+
+	var unknown []string
+	for _, k := range sortedKeys(rec) {
+		if _, ok := t.columns[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("fields not in header row: %s", strings.Join(unknown, ", "))
+	}
+
+	row := make([]any, len(t.headers))
+	for k, v := range rec {
+		row[t.columns[k]] = v
+	}
+
+	return row, nil
+}
+
+// cellString renders a cell or record value for comparison.
+func cellString(v any) string {
+	switch tv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return tv
+	case json.Number:
+		return tv.String()
+	case float64:
+		return strconv.FormatFloat(tv, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(tv)
+	default:
+		return fmt.Sprint(tv)
+	}
+}
+
+// parseRecords decodes a JSON array of flat objects, keeping numbers as
+// written.
+func parseRecords(data string) ([]map[string]any, error) {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+
+	var records []map[string]any
+	if err := dec.Decode(&records); err != nil {
+		return nil, fmt.Errorf("parse records JSON: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("records must contain at least one object")
+	}
+	for i, rec := range records {
+		for k, v := range rec {
+			switch v.(type) {
+			case nil, string, json.Number, bool:
+			default:
+				return nil, fmt.Errorf("record %d: field %q must be a string, number, boolean or null", i, k)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// readRecordsInput reads --records or --records-stdin.
+func readRecordsInput(inline string, stdin bool) ([]map[string]any, string, error) {
+	data := inline
+	if stdin {
+		s, err := readStdinWithLimit(maxStdinBytes)
+		if err != nil {
+			return nil, "stdin_error", fmt.Errorf("read stdin: %w", err)
+		}
+		data = s
+	}
+
+	records, err := parseRecords(data)
+	if err != nil {
+		return nil, "invalid_values", err
+	}
+
+	return records, "", nil
+}
+
+type cellChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// recordUpdate is an existing row an upsert changes.
+type recordUpdate struct {
+	Row     int64                 `json:"row"`
+	Key     string                `json:"key"`
+	Changes map[string]cellChange `json:"changes"`
+	values  []any
+}
+
+// recordAppend is a record an upsert adds as a new row.
+type recordAppend struct {
+	Key    string         `json:"key"`
+	Record map[string]any `json:"record"`
+	values []any
+}
+
+type upsertPlan struct {
+	Updated   []recordUpdate `json:"updated"`
+	Appended  []recordAppend `json:"appended"`
+	Unchanged int            `json:"unchanged"`
+}
+
+// planUpsert matches records to rows by the key column. Values are compared
+// as rendered text; only fields that differ are written to existing rows.
+func planUpsert(t *sheetTable, key string, records []map[string]any) (upsertPlan, error) {
+	plan := upsertPlan{Updated: []recordUpdate{}, Appended: []recordAppend{}}
+
+	keyCol, ok := t.columns[key]
+	if !ok {
+		return plan, fmt.Errorf("key column %q is not in the header row", key)
+	}
+
+	rowByKey := map[string]int{}
+	for i, row := range t.rows {
+		if keyCol >= len(row) || cellString(row[keyCol]) == "" {
+			continue
+		}
+		k := cellString(row[keyCol])
+		if _, dup := rowByKey[k]; dup {
+			return plan, fmt.Errorf("key %q appears in more than one row", k)
+		}
+		rowByKey[k] = i
+	}
+
+	seen := map[string]bool{}
+	for i, rec := range records {
+		k := cellString(rec[key])
+		if k == "" {
+			return plan, fmt.Errorf("record %d has no value for key %q", i, key)
+		}
+		if seen[k] {
+			return plan, fmt.Errorf("key %q appears in more than one record", k)
+		}
+		seen[k] = true
+
+		values, err := t.row(rec)
+		if err != nil {
+			return plan, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		idx, exists := rowByKey[k]
+		if !exists {
+			plan.Appended = append(plan.Appended, recordAppend{Key: k, Record: rec, values: values})
+			continue
+		}
+
+		existing := t.rows[idx]
+		changes := map[string]cellChange{}
+		for col, v := range values {
+			if v == nil {
+				continue
+			}
+			from := ""
+			if col < len(existing) {
+				from = cellString(existing[col])
+			}
+			if to := cellString(v); to != from {
+				changes[t.headers[col]] = cellChange{From: from, To: to}
+			} else {
+				values[col] = nil
+			}
+		}
+		if len(changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		// Data rows start on sheet row 2, below the header.
+		plan.Updated = append(plan.Updated, recordUpdate{Row: int64(idx) + 2, Key: k, Changes: changes, values: values})
+	}
+
+	return plan, nil
+}
+
+// SheetsRecordsGetCmd gets a sheet as JSON objects.
+type SheetsRecordsGetCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Sheet title. Row 1 holds the field names."`
+	ValueRender   string `name:"value-render" help:"How values are rendered: FORMATTED (default), UNFORMATTED or FORMULA."`
+}
+
+func (c *SheetsRecordsGetCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.records.get"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("sheets.records.get", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	valueRender, err := sheetsOption("--value-render", c.ValueRender, sheetsValueRenderOptions)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	svc, err := googleapi.NewSheetsReadOnly(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	values, err := readSheetValues(svc, c.SpreadsheetID, c.Sheet, valueRender)
+	if err != nil {
+		return writeGoogleAPIError("sheets_records_get_error", err)
+	}
+	table, err := newSheetTable(values)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"sheet":          c.Sheet,
+		"headers":        table.headers,
+		"records":        table.records(),
+	})
+}
+
+// SheetsRecordsAppendCmd appends JSON objects as rows.
+type SheetsRecordsAppendCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Sheet title. Row 1 holds the field names."`
+	Records       string `name:"records" help:"JSON array of objects (e.g. [{\"Name\":\"Bob\",\"Due\":\"2026-05-01\"}])."`
+	RecordsStdin  bool   `name:"records-stdin" help:"Read records JSON from stdin."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
+}
+
+func (c *SheetsRecordsAppendCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.records.append"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
+	records, code, err := readRecordsInput(c.Records, c.RecordsStdin)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, code, err.Error())
+	}
+
+	// The header row is read even for --dry-run so that the preview shows
+	// the rows exactly as they would be written.
+	svc, err := sheetsService(ctx, c.Account, root.DryRun)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	values, err := readSheetValues(svc, c.SpreadsheetID, c.Sheet, "")
+	if err != nil {
+		return writeGoogleAPIError("sheets_records_append_error", err)
+	}
+	table, err := newSheetTable(values)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	rows := make([][]any, 0, len(records))
+	for i, rec := range records {
+		row, err := table.row(rec)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_values", fmt.Sprintf("record %d: %v", i, err))
+		}
+		rows = append(rows, row)
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.records.append",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.records.append",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"sheet":          c.Sheet,
+				"headers":        table.headers,
+				"rows":           rows,
+				"input_option":   inputOption,
+			},
+		})
+	}
+
+	resp, err := svc.Spreadsheets.Values.Append(c.SpreadsheetID, quoteSheetName(c.Sheet), &sheets.ValueRange{Values: rows}).
+		ValueInputOption(inputOption).InsertDataOption("INSERT_ROWS").Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_records_append_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.records.append",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	updatedRange := ""
+	if resp.Updates != nil {
+		updatedRange = resp.Updates.UpdatedRange
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"sheet":          c.Sheet,
+		"appended":       len(rows),
+		"updated_range":  updatedRange,
+		"input_option":   inputOption,
+	})
+}
+
+// SheetsRecordsUpsertCmd updates rows by key and appends new keys.
+type SheetsRecordsUpsertCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Sheet title. Row 1 holds the field names."`
+	Key           string `name:"key" required:"" help:"Header name of the column that identifies a row."`
+	Records       string `name:"records" help:"JSON array of objects, each with a value for --key."`
+	RecordsStdin  bool   `name:"records-stdin" help:"Read records JSON from stdin."`
+	InputOption   string `name:"input-option" help:"How input is interpreted: USER_ENTERED (default) or RAW."`
+}
+
+func (c *SheetsRecordsUpsertCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.records.upsert"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	inputOption, code, err := sheetsInputOption(c.InputOption)
+	if err != nil {
+		return sheetsInputOptionError(code, err)
+	}
+
+	records, code, err := readRecordsInput(c.Records, c.RecordsStdin)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, code, err.Error())
+	}
+
+	svc, err := sheetsService(ctx, c.Account, root.DryRun)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	values, err := readSheetValues(svc, c.SpreadsheetID, c.Sheet, "")
+	if err != nil {
+		return writeGoogleAPIError("sheets_records_upsert_error", err)
+	}
+	table, err := newSheetTable(values)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	plan, err := planUpsert(table, c.Key, records)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.records.upsert",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.records.upsert",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"sheet":          c.Sheet,
+				"key":            c.Key,
+				"input_option":   inputOption,
+			},
+			"plan": plan,
+		})
+	}
+
+	sheet := quoteSheetName(c.Sheet)
+	if len(plan.Updated) > 0 {
+		req := &sheets.BatchUpdateValuesRequest{ValueInputOption: inputOption}
+		for _, u := range plan.Updated {
+			req.Data = append(req.Data, &sheets.ValueRange{
+				Range:  fmt.Sprintf("%s!A%d", sheet, u.Row),
+				Values: [][]any{u.values},
+			})
+		}
+		if _, err := svc.Spreadsheets.Values.BatchUpdate(c.SpreadsheetID, req).Do(); err != nil {
+			return writeGoogleAPIError("sheets_records_upsert_error", err)
+		}
+	}
+	if len(plan.Appended) > 0 {
+		rows := make([][]any, 0, len(plan.Appended))
+		for _, a := range plan.Appended {
+			rows = append(rows, a.values)
+		}
+		if _, err := svc.Spreadsheets.Values.Append(c.SpreadsheetID, sheet, &sheets.ValueRange{Values: rows}).
+			ValueInputOption(inputOption).InsertDataOption("INSERT_ROWS").Do(); err != nil {
+			return writeGoogleAPIError("sheets_records_upsert_error",
+				fmt.Errorf("append new records (%d existing rows were already updated): %w", len(plan.Updated), err))
+		}
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.records.upsert",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"sheet":          c.Sheet,
+		"key":            c.Key,
+		"input_option":   inputOption,
+		"updated":        plan.Updated,
+		"appended":       plan.Appended,
+		"unchanged":      plan.Unchanged,
+	})
+}
+
+// sheetsService returns a read-only client for dry-runs, which only read
+// the spreadsheet to build their preview.
+func sheetsService(ctx context.Context, account string, dryRun bool) (*sheets.Service, error) {
+	if dryRun {
+		return googleapi.NewSheetsReadOnly(ctx, account)
+	}
+
+	return googleapi.NewSheetsWrite(ctx, account)
+}
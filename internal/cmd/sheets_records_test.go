@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func taskTable(t *testing.T) *sheetTable {
+	t.Helper()
+	table, err := newSheetTable([][]any{
+		{"ID", "Name", "Due", ""},
+		{"T-1", "Write spec", "2026-05-01"},
+		{},
+		{"T-2", "Review", "2026-05-03", "stray"},
+	})
+	if err != nil {
+		t.Fatalf("newSheetTable: %v", err)
+	}
+	return table
+}
+
+func TestSheetTableRecords(t *testing.T) {
+	got := taskTable(t).records()
+	want := []map[string]any{
+		{"ID": "T-1", "Name": "Write spec", "Due": "2026-05-01"},
+		{"ID": "T-2", "Name": "Review", "Due": "2026-05-03"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+
+	if _, err := newSheetTable([][]any{{"ID", "Name", "ID"}}); err == nil {
+		t.Error("expected error for duplicate header")
+	}
+}
+
+func TestSheetTableRow(t *testing.T) {
+	table := taskTable(t)
+
+	row, err := table.row(map[string]any{"Due": "2026-06-01", "ID": "T-3"})
+	if err != nil {
+		t.Fatalf("row: %v", err)
+	}
+	if want := []any{"T-3", nil, "2026-06-01", nil}; !reflect.DeepEqual(row, want) {
+		t.Errorf("row = %v, want %v", row, want)
+	}
+
+	if _, err := table.row(map[string]any{"ID": "T-3", "Owner": "x"}); err == nil {
+		t.Error("expected error for field missing from header")
+	}
+}
+
+func TestPlanUpsert(t *testing.T) {
+	records, err := parseRecords(`[
+		{"ID": "T-2", "Due": "2026-05-10", "Name": "Review"},
+		{"ID": "T-1", "Name": "Write spec"},
+		{"ID": "T-9", "Name": "New task", "Due": 20260601}
+	]`)
+	if err != nil {
+		t.Fatalf("parseRecords: %v", err)
+	}
+
+	plan, err := planUpsert(taskTable(t), "ID", records)
+	if err != nil {
+		t.Fatalf("planUpsert: %v", err)
+	}
+
+	if plan.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", plan.Unchanged)
+	}
+	if len(plan.Updated) != 1 {
+		t.Fatalf("updated = %+v", plan.Updated)
+	}
+	u := plan.Updated[0]
+	// T-2 is on sheet row 4: header, T-1, an empty row, then T-2.
+	if u.Row != 4 || !reflect.DeepEqual(u.Changes, map[string]cellChange{"Due": {From: "2026-05-03", To: "2026-05-10"}}) {
+		t.Errorf("update = %+v", u)
+	}
+	// Only the changed cell is written; unchanged fields are skipped.
+	if want := []any{nil, nil, "2026-05-10", nil}; !reflect.DeepEqual(u.values, want) {
+		t.Errorf("update values = %v, want %v", u.values, want)
+	}
+	if len(plan.Appended) != 1 || plan.Appended[0].Key != "T-9" || plan.Appended[0].values[2] != json.Number("20260601") {
+		t.Errorf("appended = %+v", plan.Appended)
+	}
+
+	for _, bad := range []string{
+		`[{"Name": "no key"}]`,
+		`[{"ID": "T-1"}, {"ID": "T-1"}]`,
+	} {
+		recs, err := parseRecords(bad)
+		if err != nil {
+			t.Fatalf("parseRecords(%s): %v", bad, err)
+		}
+		if _, err := planUpsert(taskTable(t), "ID", recs); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	if _, err := planUpsert(taskTable(t), "Owner", records); err == nil {
+		t.Error("expected error for unknown key column")
+	}
+}
+
+func TestParseRecords_RejectsNestedValues(t *testing.T) {
+	if _, err := parseRecords(`[{"ID": "T-1", "Tags": ["a"]}]`); err == nil {
+		t.Error("expected error for array field")
+	}
+	if _, err := parseRecords(`[]`); err == nil {
+		t.Error("expected error for empty records")
+	}
+}
+
+func TestQuoteSheetName(t *testing.T) {
+	if got := quoteSheetName("Bob's tasks"); got != "'Bob''s tasks'" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,807 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// SheetsTabCmd groups commands that change the tabs of a spreadsheet.
+type SheetsTabCmd struct {
+	Add       SheetsTabAddCmd       `cmd:"" help:"Add a tab."`
+	Delete    SheetsTabDeleteCmd    `cmd:"" help:"Delete a tab and all of its cells."`
+	Rename    SheetsTabRenameCmd    `cmd:"" help:"Rename a tab."`
+	Duplicate SheetsTabDuplicateCmd `cmd:"" help:"Copy a tab within the spreadsheet."`
+}
+
+// SheetsRowsCmd groups commands that insert and delete rows.
+type SheetsRowsCmd struct {
+	Insert SheetsRowsInsertCmd `cmd:"" help:"Insert empty rows."`
+	Delete SheetsRowsDeleteCmd `cmd:"" help:"Delete rows and shift the rows below up."`
+}
+
+// tabInfo describes a tab in command output.
+type tabInfo struct {
+	SheetID int64  `json:"sheet_id"`
+	Title   string `json:"title"`
+	Index   int64  `json:"index"`
+}
+
+func newTabInfo(p *sheets.SheetProperties) tabInfo {
+	if p == nil {
+		return tabInfo{}
+	}
+
+	return tabInfo{SheetID: p.SheetId, Title: p.Title, Index: p.Index}
+}
+
+// confirmSheetsDestructive checks the confirmation flag of a command that
+// removes cell data and, when the policy asks for approval, that a token was
+// given. It reports whether approval is required; the token itself is
+// consumed by approveSheetsDestructive once the target has been looked up,
+// so a mistyped sheet or range does not use it up. Dry-runs need neither.
+func confirmSheetsDestructive(root *RootFlags, action, flag string, confirmed bool, token string) (bool, error) {
+	if root.DryRun {
+		return false, nil
+	}
+	if !confirmed {
+		code := strings.TrimPrefix(flag, "confirm-") + "_requires_confirmation"
+		return false, output.WriteError(output.ExitCodeError, code,
+			fmt.Sprintf("%s requires --%s", strings.ReplaceAll(action, ".", " "), flag))
+	}
+
+	required, err := actionRequiresApproval(action)
+	if err != nil {
+		return false, output.WriteError(output.ExitCodeError, "policy_error", err.Error())
+	}
+	if required && strings.TrimSpace(token) == "" {
+		return false, output.WriteError(output.ExitCodePermission, "approval_required",
+			fmt.Sprintf("%s requires --approval-token", action))
+	}
+
+	return required, nil
+}
+
+// approveSheetsDestructive consumes the approval token right before the
+// write.
+func approveSheetsDestructive(account, action, token string, required bool) error {
+	if !required {
+		return nil
+	}
+	if err := consumeApprovalToken(account, action, token); err != nil {
+		return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
+	}
+
+	return nil
+}
+
+// rowRange returns count rows starting at the 1-based row.
+func rowRange(sheetID int64, row, count int) *sheets.DimensionRange {
+	return &sheets.DimensionRange{
+		SheetId:    sheetID,
+		Dimension:  "ROWS",
+		StartIndex: int64(row - 1),
+		EndIndex:   int64(row - 1 + count),
+	}
+}
+
+func validateRowSpan(row, count int) error {
+	if row < 1 {
+		return fmt.Errorf("--row must be at least 1")
+	}
+	if count < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+
+	return nil
+}
+
+// SheetsCreateCmd creates a spreadsheet.
+type SheetsCreateCmd struct {
+	Account string   `name:"account" required:"" short:"a" help:"Google account email."`
+	Title   string   `name:"title" required:"" help:"Spreadsheet title."`
+	Tabs    []string `name:"tabs" help:"Comma-separated tab titles, in order (default: one tab named by Sheets)."`
+}
+
+func (c *SheetsCreateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.create"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	seen := map[string]bool{}
+	for _, tab := range c.Tabs {
+		if strings.TrimSpace(tab) == "" || seen[tab] {
+			return output.WriteError(output.ExitCodeError, "invalid_arguments",
+				fmt.Sprintf("--tabs must be unique, non-empty titles (got %q)", tab))
+		}
+		seen[tab] = true
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.create",
+			Account: normalizeEmail(c.Account),
+			Target:  c.Title,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.create",
+			"params": map[string]any{
+				"account": c.Account,
+				"title":   c.Title,
+				"tabs":    c.Tabs,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	ss := &sheets.Spreadsheet{Properties: &sheets.SpreadsheetProperties{Title: c.Title}}
+	for _, tab := range c.Tabs {
+		ss.Sheets = append(ss.Sheets, &sheets.Sheet{Properties: &sheets.SheetProperties{Title: tab}})
+	}
+	created, err := svc.Spreadsheets.Create(ss).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_create_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.create",
+		Account: normalizeEmail(c.Account),
+		Target:  created.SpreadsheetId,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	tabs := make([]tabInfo, 0, len(created.Sheets))
+	for _, s := range created.Sheets {
+		tabs = append(tabs, newTabInfo(s.Properties))
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": created.SpreadsheetId,
+		"title":          c.Title,
+		"url":            fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit", created.SpreadsheetId),
+		"sheets":         tabs,
+	})
+}
+
+// SheetsTabAddCmd adds a tab.
+type SheetsTabAddCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Title         string `name:"title" required:"" help:"Title of the new tab."`
+	Position      int    `name:"position" help:"1-based position of the new tab (default: last)."`
+	Rows          int    `name:"rows" help:"Number of rows (default: Sheets default)."`
+	Columns       int    `name:"columns" help:"Number of columns (default: Sheets default)."`
+}
+
+func (c *SheetsTabAddCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.tab.add"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Position < 0 || c.Rows < 0 || c.Columns < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--position, --rows and --columns must not be negative")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.tab.add",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.tab.add",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"title":          c.Title,
+				"position":       c.Position,
+				"rows":           c.Rows,
+				"columns":        c.Columns,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+
+	props := &sheets.SheetProperties{Title: c.Title}
+	if c.Position > 0 {
+		props.Index = int64(c.Position - 1)
+		props.ForceSendFields = []string{"Index"}
+	}
+	if c.Rows > 0 || c.Columns > 0 {
+		props.GridProperties = &sheets.GridProperties{RowCount: int64(c.Rows), ColumnCount: int64(c.Columns)}
+	}
+	resp, err := svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: props}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.tab.add",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	var added *sheets.SheetProperties
+	if len(resp.Replies) > 0 && resp.Replies[0].AddSheet != nil {
+		added = resp.Replies[0].AddSheet.Properties
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"added":          newTabInfo(added),
+	})
+}
+
+// SheetsTabDeleteCmd deletes a tab.
+type SheetsTabDeleteCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Title of the tab to delete."`
+	ConfirmDelete bool   `name:"confirm-delete" help:"Required confirmation flag for delete operations."`
+	ApprovalToken string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SheetsTabDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.tab.delete"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+	approval, err := confirmSheetsDestructive(root, "sheets.tab.delete", "confirm-delete", c.ConfirmDelete, c.ApprovalToken)
+	if err != nil {
+		return err
+	}
+
+	svc, err := sheetsService(ctx, c.Account, root.DryRun)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+	sheet, err := findSheet(props, c.Sheet)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+	values, err := readSheetValues(svc, c.SpreadsheetID, c.Sheet, "")
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+	rows, cells := countFilled(values)
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.tab.delete",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.tab.delete",
+			"params": map[string]any{
+				"account":         c.Account,
+				"spreadsheet_id":  c.SpreadsheetID,
+				"sheet":           c.Sheet,
+				"sheet_id":        sheet.SheetId,
+				"rows_with_data":  rows,
+				"cells_with_data": cells,
+			},
+		})
+	}
+
+	if err := approveSheetsDestructive(c.Account, "sheets.tab.delete", c.ApprovalToken, approval); err != nil {
+		return err
+	}
+	previousRevision, revisionErr := preWriteRevisionID(ctx, c.Account, "sheets.tab.delete", c.SpreadsheetID)
+
+	_, err = svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{DeleteSheet: &sheets.DeleteSheetRequest{SheetId: sheet.SheetId}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:     "sheets.tab.delete",
+		Account:    normalizeEmail(c.Account),
+		Target:     c.SpreadsheetID,
+		DryRun:     false,
+		RevisionID: previousRevision,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"deleted":         true,
+		"spreadsheet_id":  c.SpreadsheetID,
+		"sheet":           c.Sheet,
+		"sheet_id":        sheet.SheetId,
+		"rows_with_data":  rows,
+		"cells_with_data": cells,
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// SheetsTabRenameCmd renames a tab.
+type SheetsTabRenameCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Current title of the tab."`
+	Title         string `name:"title" required:"" help:"New title."`
+}
+
+func (c *SheetsTabRenameCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.tab.rename"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if strings.TrimSpace(c.Title) == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--title must not be empty")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.tab.rename",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.tab.rename",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"sheet":          c.Sheet,
+				"title":          c.Title,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+	sheet, err := findSheet(props, c.Sheet)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: sheet.SheetId, Title: c.Title},
+			Fields:     "title",
+		}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.tab.rename",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"sheet_id":       sheet.SheetId,
+		"previous_title": c.Sheet,
+		"title":          c.Title,
+	})
+}
+
+// SheetsTabDuplicateCmd copies a tab within its spreadsheet.
+type SheetsTabDuplicateCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Title of the tab to copy."`
+	Title         string `name:"title" help:"Title of the copy (default: chosen by Sheets)."`
+	Position      int    `name:"position" help:"1-based position of the copy (default: after the source tab)."`
+}
+
+func (c *SheetsTabDuplicateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.tab.duplicate"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Position < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--position must not be negative")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.tab.duplicate",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.tab.duplicate",
+			"params": map[string]any{
+				"account":        c.Account,
+				"spreadsheet_id": c.SpreadsheetID,
+				"sheet":          c.Sheet,
+				"title":          c.Title,
+				"position":       c.Position,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+	sheet, err := findSheet(props, c.Sheet)
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	req := &sheets.DuplicateSheetRequest{SourceSheetId: sheet.SheetId, NewSheetName: c.Title}
+	if c.Position > 0 {
+		req.InsertSheetIndex = int64(c.Position - 1)
+		req.ForceSendFields = []string{"InsertSheetIndex"}
+	}
+	resp, err := svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{DuplicateSheet: req}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_tab_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.tab.duplicate",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	var copied *sheets.SheetProperties
+	if len(resp.Replies) > 0 && resp.Replies[0].DuplicateSheet != nil {
+		copied = resp.Replies[0].DuplicateSheet.Properties
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"source":         newTabInfo(sheet),
+		"added":          newTabInfo(copied),
+	})
+}
+
+// SheetsClearCmd clears the values in a range.
+type SheetsClearCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Range         string `name:"range" required:"" help:"Range or sheet to clear (e.g. Sheet1!A2:F)."`
+	ConfirmClear  bool   `name:"confirm-clear" help:"Required confirmation flag for clear operations."`
+	ApprovalToken string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SheetsClearCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.clear"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	r, err := parseA1Range(c.Range)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+	approval, err := confirmSheetsDestructive(root, "sheets.clear", "confirm-clear", c.ConfirmClear, c.ApprovalToken)
+	if err != nil {
+		return err
+	}
+
+	svc, err := sheetsService(ctx, c.Account, root.DryRun)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_clear_error", err)
+	}
+	gr, err := gridRange(props, r)
+	if err != nil {
+		return writeGoogleAPIError("sheets_clear_error", err)
+	}
+	current, err := svc.Spreadsheets.Values.Get(c.SpreadsheetID, c.Range).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_clear_error", err)
+	}
+	rows, cells := countFilled(current.Values)
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.clear",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.clear",
+			"params": map[string]any{
+				"account":         c.Account,
+				"spreadsheet_id":  c.SpreadsheetID,
+				"range":           current.Range,
+				"rows_with_data":  rows,
+				"cells_with_data": cells,
+			},
+		})
+	}
+
+	if err := approveSheetsDestructive(c.Account, "sheets.clear", c.ApprovalToken, approval); err != nil {
+		return err
+	}
+	previousRevision, revisionErr := preWriteRevisionID(ctx, c.Account, "sheets.clear", c.SpreadsheetID)
+
+	// Only values are cleared; formatting and validation stay in place.
+	_, err = svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{UpdateCells: &sheets.UpdateCellsRequest{Range: gr, Fields: "userEnteredValue"}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_clear_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:     "sheets.clear",
+		Account:    normalizeEmail(c.Account),
+		Target:     c.SpreadsheetID,
+		DryRun:     false,
+		RevisionID: previousRevision,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"cleared":         true,
+		"spreadsheet_id":  c.SpreadsheetID,
+		"range":           current.Range,
+		"rows_with_data":  rows,
+		"cells_with_data": cells,
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// SheetsRowsInsertCmd inserts empty rows.
+type SheetsRowsInsertCmd struct {
+	Account           string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID     string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet             string `name:"sheet" required:"" help:"Tab title."`
+	Row               int    `name:"row" required:"" help:"1-based row number; new rows are inserted above it."`
+	Count             int    `name:"count" default:"1" help:"Number of rows to insert."`
+	InheritFromBefore bool   `name:"inherit-from-before" help:"Copy formatting from the row above instead of the row below."`
+}
+
+func (c *SheetsRowsInsertCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.rows.insert"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := validateRowSpan(c.Row, c.Count); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+	if c.InheritFromBefore && c.Row == 1 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--inherit-from-before needs a row above --row")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.rows.insert",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.rows.insert",
+			"params": map[string]any{
+				"account":             c.Account,
+				"spreadsheet_id":      c.SpreadsheetID,
+				"sheet":               c.Sheet,
+				"row":                 c.Row,
+				"count":               c.Count,
+				"inherit_from_before": c.InheritFromBefore,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSheetsWrite(ctx, c.Account)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+	sheet, err := findSheet(props, c.Sheet)
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+
+	_, err = svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{InsertDimension: &sheets.InsertDimensionRequest{
+			Range:             rowRange(sheet.SheetId, c.Row, c.Count),
+			InheritFromBefore: c.InheritFromBefore,
+		}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "sheets.rows.insert",
+		Account: normalizeEmail(c.Account),
+		Target:  c.SpreadsheetID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"spreadsheet_id": c.SpreadsheetID,
+		"sheet":          c.Sheet,
+		"inserted":       c.Count,
+		"first_row":      c.Row,
+	})
+}
+
+// SheetsRowsDeleteCmd deletes rows.
+type SheetsRowsDeleteCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	SpreadsheetID string `name:"spreadsheet-id" required:"" help:"Google Sheets spreadsheet ID."`
+	Sheet         string `name:"sheet" required:"" help:"Tab title."`
+	Row           int    `name:"row" required:"" help:"1-based number of the first row to delete."`
+	Count         int    `name:"count" default:"1" help:"Number of rows to delete."`
+	ConfirmDelete bool   `name:"confirm-delete" help:"Required confirmation flag for delete operations."`
+	ApprovalToken string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SheetsRowsDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "sheets.rows.delete"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := validateRowSpan(c.Row, c.Count); err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+	approval, err := confirmSheetsDestructive(root, "sheets.rows.delete", "confirm-delete", c.ConfirmDelete, c.ApprovalToken)
+	if err != nil {
+		return err
+	}
+
+	svc, err := sheetsService(ctx, c.Account, root.DryRun)
+	if err != nil {
+		return sheetsAuthError(err)
+	}
+	props, err := readSheetProperties(svc, c.SpreadsheetID)
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+	sheet, err := findSheet(props, c.Sheet)
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+	rowsA1 := fmt.Sprintf("%s!%d:%d", quoteSheetName(c.Sheet), c.Row, c.Row+c.Count-1)
+	current, err := svc.Spreadsheets.Values.Get(c.SpreadsheetID, rowsA1).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+	rows, cells := countFilled(current.Values)
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "sheets.rows.delete",
+			Account: normalizeEmail(c.Account),
+			Target:  c.SpreadsheetID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "sheets.rows.delete",
+			"params": map[string]any{
+				"account":         c.Account,
+				"spreadsheet_id":  c.SpreadsheetID,
+				"sheet":           c.Sheet,
+				"row":             c.Row,
+				"count":           c.Count,
+				"rows_with_data":  rows,
+				"cells_with_data": cells,
+			},
+		})
+	}
+
+	if err := approveSheetsDestructive(c.Account, "sheets.rows.delete", c.ApprovalToken, approval); err != nil {
+		return err
+	}
+	previousRevision, revisionErr := preWriteRevisionID(ctx, c.Account, "sheets.rows.delete", c.SpreadsheetID)
+
+	_, err = svc.Spreadsheets.BatchUpdate(c.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{DeleteDimension: &sheets.DeleteDimensionRequest{
+			Range: rowRange(sheet.SheetId, c.Row, c.Count),
+		}}},
+	}).Do()
+	if err != nil {
+		return writeGoogleAPIError("sheets_rows_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:     "sheets.rows.delete",
+		Account:    normalizeEmail(c.Account),
+		Target:     c.SpreadsheetID,
+		DryRun:     false,
+		RevisionID: previousRevision,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"spreadsheet_id":  c.SpreadsheetID,
+		"sheet":           c.Sheet,
+		"deleted":         c.Count,
+		"first_row":       c.Row,
+		"rows_with_data":  rows,
+		"cells_with_data": cells,
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+
+	return output.WriteJSON(os.Stdout, result)
+}
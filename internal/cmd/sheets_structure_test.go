@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestParseA1Range(t *testing.T) {
+	i := int64Ptr
+	cases := map[string]a1Range{
+		"Sheet1!A1:C10":   {sheet: "Sheet1", startRow: i(0), endRow: i(10), startCol: i(0), endCol: i(3)},
+		"'Bob''s'!B2":     {sheet: "Bob's", startRow: i(1), endRow: i(2), startCol: i(1), endCol: i(2)},
+		"Data!A:C":        {sheet: "Data", startCol: i(0), endCol: i(3)},
+		"Data!2:5":        {sheet: "Data", startRow: i(1), endRow: i(5)},
+		"A2:F":            {startRow: i(1), startCol: i(0), endCol: i(6)},
+		"Tasks":           {sheet: "Tasks"},
+		"'Q1 2026'":       {sheet: "Q1 2026"},
+		"Sheet1!$A$1:$B2": {sheet: "Sheet1", startRow: i(0), endRow: i(2), startCol: i(0), endCol: i(2)},
+	}
+	for in, want := range cases {
+		got, err := parseA1Range(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %s, want %s", in, fmtA1Range(got), fmtA1Range(want))
+		}
+	}
+
+	for _, bad := range []string{"Sheet1!C3:A1", "Sheet1!A", "Sheet1!", "Sheet1!A0"} {
+		if _, err := parseA1Range(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func fmtA1Range(r a1Range) string {
+	v := func(p *int64) string {
+		if p == nil {
+			return "-"
+		}
+		return fmt.Sprint(*p)
+	}
+	return fmt.Sprintf("%s rows %s..%s cols %s..%s", r.sheet, v(r.startRow), v(r.endRow), v(r.startCol), v(r.endCol))
+}
+
+func TestGridRange(t *testing.T) {
+	props := []*sheets.SheetProperties{{SheetId: 0, Title: "Summary"}, {SheetId: 42, Title: "Data"}}
+
+	r, _ := parseA1Range("Data!B2:C")
+	gr, err := gridRange(props, r)
+	if err != nil {
+		t.Fatalf("gridRange: %v", err)
+	}
+	want := &sheets.GridRange{SheetId: 42, StartRowIndex: 1, StartColumnIndex: 1, EndColumnIndex: 3}
+	if !reflect.DeepEqual(gr, want) {
+		t.Errorf("got %+v, want %+v", gr, want)
+	}
+
+	r, _ = parseA1Range("A1")
+	if gr, err := gridRange(props, r); err != nil || gr.SheetId != 0 {
+		t.Errorf("first sheet: %+v, %v", gr, err)
+	}
+
+	r, _ = parseA1Range("Missing!A1")
+	if _, err := gridRange(props, r); err == nil {
+		t.Error("expected error for unknown sheet")
+	}
+}
+
+func TestCountFilled(t *testing.T) {
+	rows, cells := countFilled([][]any{{"a", "", "b"}, {}, {"", nil}, {float64(0)}})
+	if rows != 2 || cells != 3 {
+		t.Errorf("rows=%d cells=%d, want 2 and 3", rows, cells)
+	}
+}
+
+func TestSheetsDestructiveCmds_RequireConfirmationAndApproval(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{}
+	run := func(cmd interface {
+		Run(context.Context, *RootFlags) error
+	}) func() error {
+		return func() error { return cmd.Run(context.Background(), root) }
+	}
+	cases := []struct {
+		name        string
+		unconfirmed func() error
+		confirmed   func() error
+		code        string
+	}{
+		{
+			name:        "tab delete",
+			unconfirmed: run(&SheetsTabDeleteCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Sheet: "Old"}),
+			confirmed:   run(&SheetsTabDeleteCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Sheet: "Old", ConfirmDelete: true}),
+			code:        "delete_requires_confirmation",
+		},
+		{
+			name:        "clear",
+			unconfirmed: run(&SheetsClearCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Range: "Data!A2:F"}),
+			confirmed:   run(&SheetsClearCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Range: "Data!A2:F", ConfirmClear: true}),
+			code:        "clear_requires_confirmation",
+		},
+		{
+			name:        "rows delete",
+			unconfirmed: run(&SheetsRowsDeleteCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Sheet: "Data", Row: 2, Count: 3}),
+			confirmed:   run(&SheetsRowsDeleteCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Sheet: "Data", Row: 2, Count: 3, ConfirmDelete: true}),
+			code:        "delete_requires_confirmation",
+		},
+	}
+	for _, tc := range cases {
+		if code, _ := runForCode(t, tc.unconfirmed); code != tc.code {
+			t.Errorf("%s without confirmation: code = %q, want %q", tc.name, code, tc.code)
+		}
+		if code, exit := runForCode(t, tc.confirmed); code != "approval_required" || exit != output.ExitCodePermission {
+			t.Errorf("%s without approval token: code = %q, exit = %d", tc.name, code, exit)
+		}
+	}
+}
+
+func TestSheetsTabDeleteCmd_KeepsTokenWhenLookupFails(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	token, _, err := issueApprovalToken("a@example.com", "sheets.tab.delete", time.Minute)
+	if err != nil {
+		t.Fatalf("issueApprovalToken: %v", err)
+	}
+
+	// With no stored credentials the sheet cannot be looked up, so the
+	// command fails before the write and the token must stay usable.
+	cmd := &SheetsTabDeleteCmd{Account: "a@example.com", SpreadsheetID: "ss-1", Sheet: "Old", ConfirmDelete: true, ApprovalToken: token}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{}) }); code == "approval_required" {
+		t.Fatalf("code = %q, want a lookup failure", code)
+	}
+	if err := consumeApprovalToken("a@example.com", "sheets.tab.delete", token); err != nil {
+		t.Errorf("token was consumed by a failed lookup: %v", err)
+	}
+}
+
+func TestSheetsRowsInsertCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{DryRun: true}
+	for _, bad := range []SheetsRowsInsertCmd{
+		{Row: 0, Count: 1},
+		{Row: 3, Count: 0},
+		{Row: 1, Count: 2, InheritFromBefore: true},
+	} {
+		cmd := bad
+		cmd.Account, cmd.SpreadsheetID, cmd.Sheet = "a@example.com", "ss-1", "Data"
+		if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), root) }); code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q", bad, code)
+		}
+	}
+
+	if got := rowRange(7, 3, 2); got.StartIndex != 2 || got.EndIndex != 4 || got.SheetId != 7 || got.Dimension != "ROWS" {
+		t.Errorf("rowRange = %+v", got)
+	}
+}
+
+func TestSheetsCreateCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	root := &RootFlags{DryRun: true}
+	dup := &SheetsCreateCmd{Account: "a@example.com", Title: "Report", Tabs: []string{"Data", "Data"}}
+	if code, _ := runForCode(t, func() error { return dup.Run(context.Background(), root) }); code != "invalid_arguments" {
+		t.Errorf("duplicate tabs: code = %q", code)
+	}
+
+	cmd := &SheetsCreateCmd{Account: "a@example.com", Title: "Report", Tabs: []string{"Summary", "Data"}}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), root)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			Tabs []string `json:"tabs"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "sheets.create" || !reflect.DeepEqual(payload.Params.Tabs, []string{"Summary", "Data"}) {
+		t.Errorf("unexpected dry-run output: %+v", payload)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"time"
 
 	"google.golang.org/api/slides/v1"
@@ -14,9 +15,22 @@ import (
 
 // SlidesCmd groups Slides subcommands.
 type SlidesCmd struct {
-	Info  SlidesInfoCmd  `cmd:"" help:"Get presentation metadata."`
-	Get   SlidesGetCmd   `cmd:"" help:"Get slide content as text."`
-	Write SlidesWriteCmd `cmd:"" help:"Replace text in a presentation."`
+	Info        SlidesInfoCmd        `cmd:"" help:"Get presentation metadata."`
+	Get         SlidesGetCmd         `cmd:"" help:"Get slide text, speaker notes and element positions."`
+	Write       SlidesWriteCmd       `cmd:"" help:"Replace text in a presentation."`
+	Export      SlidesExportCmd      `cmd:"" help:"Export a presentation to PPTX or PDF, or one slide to PNG."`
+	Revisions   SlidesRevisionsCmd   `cmd:"" help:"List and export presentation revisions."`
+	Restore     SlidesRestoreCmd     `cmd:"" help:"Restore a presentation to an earlier revision."`
+	Render      SlidesRenderCmd      `cmd:"" help:"Copy a template presentation and fill {{key}} placeholders."`
+	Create      SlidesCreateCmd      `cmd:"" help:"Create a presentation, optionally from a JSON or Markdown outline."`
+	AddSlide    SlidesAddSlideCmd    `cmd:"" name:"add-slide" help:"Add a slide from a predefined layout."`
+	Duplicate   SlidesDuplicateCmd   `cmd:"" help:"Duplicate a slide."`
+	DeleteSlide SlidesDeleteSlideCmd `cmd:"" name:"delete-slide" help:"Delete a slide."`
+	Reorder     SlidesReorderCmd     `cmd:"" help:"Move slides to a new position."`
+	InsertImage SlidesInsertImageCmd `cmd:"" name:"insert-image" help:"Insert an image from a URL or Drive file on a slide."`
+	InsertTable SlidesInsertTableCmd `cmd:"" name:"insert-table" help:"Insert a table on a slide."`
+	Notes       SlidesNotesCmd       `cmd:"" help:"Edit speaker notes."`
+	Thumbnail   SlidesThumbnailCmd   `cmd:"" help:"Render slides to PNG files."`
 }
 
 // SlidesInfoCmd gets presentation metadata.
@@ -62,7 +76,7 @@ func (c *SlidesInfoCmd) Run(ctx context.Context, _ *RootFlags) error {
 	})
 }
 
-// SlidesGetCmd gets slide content as text.
+// SlidesGetCmd gets slide text, speaker notes and element positions.
 type SlidesGetCmd struct {
 	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
 	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
@@ -89,9 +103,12 @@ func (c *SlidesGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 			return writeGoogleAPIError("slides_get_error", err)
 		}
 
+		_, notes := speakerNotes(page)
 		return output.WriteJSON(os.Stdout, map[string]any{
 			"object_id": page.ObjectId,
 			"texts":     extractPageTexts(page.PageElements),
+			"notes":     notes,
+			"elements":  describeElements(page.PageElements, identityAffine),
 		})
 	}
 
@@ -101,17 +118,22 @@ func (c *SlidesGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 	}
 
 	type slideContent struct {
-		ObjectID    string   `json:"object_id"`
-		SlideNumber int      `json:"slide_number"`
-		Texts       []string `json:"texts"`
+		ObjectID    string         `json:"object_id"`
+		SlideNumber int            `json:"slide_number"`
+		Texts       []string       `json:"texts"`
+		Notes       string         `json:"notes"`
+		Elements    []slideElement `json:"elements"`
 	}
 
 	slideContents := make([]slideContent, 0, len(pres.Slides))
 	for i, s := range pres.Slides {
+		_, notes := speakerNotes(s)
 		slideContents = append(slideContents, slideContent{
 			ObjectID:    s.ObjectId,
 			SlideNumber: i + 1,
 			Texts:       extractPageTexts(s.PageElements),
+			Notes:       notes,
+			Elements:    describeElements(s.PageElements, identityAffine),
 		})
 	}
 
@@ -121,28 +143,14 @@ func (c *SlidesGetCmd) Run(ctx context.Context, _ *RootFlags) error {
 	})
 }
 
-// extractPageTexts extracts text content from slide page elements.
-func extractPageTexts(elements []*slides.PageElement) []string {
-	var texts []string
-	for _, elem := range elements {
-		if elem.Shape == nil || elem.Shape.Text == nil {
-			continue
-		}
-		for _, te := range elem.Shape.Text.TextElements {
-			if te.TextRun != nil && te.TextRun.Content != "" {
-				texts = append(texts, te.TextRun.Content)
-			}
-		}
-	}
-	return texts
-}
-
 // SlidesWriteCmd replaces text in a presentation.
 type SlidesWriteCmd struct {
 	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
 	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
-	Find           string `name:"find" required:"" help:"Text to find."`
-	Replace        string `name:"replace" required:"" help:"Replacement text."`
+	Find           string `name:"find" help:"Text to find."`
+	Replace        string `name:"replace" help:"Replacement text."`
+	Pairs          string `name:"pairs" help:"JSON file of find/replace pairs applied in one batch (- for stdin)."`
+	Regex          bool   `name:"regex" help:"Treat find texts as regular expressions; $1 in replacements expands groups."`
 	MatchCase      bool   `name:"match-case" help:"Case-sensitive matching (default: false)."`
 	ConfirmWrite   bool   `name:"confirm-write" help:"Required confirmation flag for write operations."`
 	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
@@ -153,6 +161,17 @@ func (c *SlidesWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
 	}
 
+	pairs, code, err := replacePairsFrom(c.Find, c.Replace, c.Pairs)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, code, err.Error())
+	}
+	var patterns []*regexp.Regexp
+	if c.Regex {
+		if patterns, err = compileReplacePatterns(pairs, c.MatchCase); err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+		}
+	}
+
 	if !root.DryRun && !c.ConfirmWrite {
 		return output.WriteError(output.ExitCodeError, "write_requires_confirmation",
 			"slides write requires --confirm-write")
@@ -185,8 +204,8 @@ func (c *SlidesWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 			"params": map[string]any{
 				"account":         c.Account,
 				"presentation_id": c.PresentationID,
-				"find":            c.Find,
-				"replace":         c.Replace,
+				"pairs":           pairs,
+				"regex":           c.Regex,
 				"match_case":      c.MatchCase,
 			},
 		})
@@ -197,42 +216,97 @@ func (c *SlidesWriteCmd) Run(ctx context.Context, root *RootFlags) error {
 		return slidesAuthError(err)
 	}
 
-	req := &slides.BatchUpdatePresentationRequest{
-		Requests: []*slides.Request{
-			{
+	var req *slides.BatchUpdatePresentationRequest
+	var counts []int64
+	if c.Regex {
+		// As with docs find-replace, regex matches are located client-side
+		// and the batch is guarded by the revision that was read.
+		pres, err := svc.Presentations.Get(c.PresentationID).Do()
+		if err != nil {
+			return writeGoogleAPIError("slides_write_error", err)
+		}
+		var matches []textMatch
+		matches, counts = regexMatches(slidesTextSegments(pres.Slides), pairs, patterns)
+		req = &slides.BatchUpdatePresentationRequest{
+			Requests:     slidesRegexRequests(matches),
+			WriteControl: &slides.WriteControl{RequiredRevisionId: pres.RevisionId},
+		}
+	} else {
+		req = &slides.BatchUpdatePresentationRequest{}
+		for _, p := range pairs {
+			req.Requests = append(req.Requests, &slides.Request{
 				ReplaceAllText: &slides.ReplaceAllTextRequest{
 					ContainsText: &slides.SubstringMatchCriteria{
-						Text:      c.Find,
+						Text:      p.Find,
 						MatchCase: c.MatchCase,
 					},
-					ReplaceText: c.Replace,
+					ReplaceText: p.Replace,
 				},
-			},
-		},
+			})
+		}
 	}
 
-	resp, err := svc.Presentations.BatchUpdate(c.PresentationID, req).Do()
-	if err != nil {
-		return writeGoogleAPIError("slides_write_error", err)
-	}
+	// A regex with no matches leaves nothing to write or audit.
+	replaced := len(req.Requests) > 0
+	previousRevision := ""
+	var revisionErr error
+	if replaced {
+		previousRevision, revisionErr = preWriteRevisionID(ctx, c.Account, "slides.write", c.PresentationID)
 
-	if err := appendAuditLog(root.AuditLog, auditEntry{
-		Action:  "slides.write",
-		Account: normalizeEmail(c.Account),
-		Target:  c.PresentationID,
-		DryRun:  false,
-	}); err != nil {
-		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		resp, err := svc.Presentations.BatchUpdate(c.PresentationID, req).Do()
+		if err != nil {
+			return writeGoogleAPIError("slides_write_error", err)
+		}
+		if !c.Regex {
+			counts = make([]int64, len(pairs))
+			for i := range pairs {
+				if resp != nil && i < len(resp.Replies) && resp.Replies[i].ReplaceAllText != nil {
+					counts[i] = resp.Replies[i].ReplaceAllText.OccurrencesChanged
+				}
+			}
+		}
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:     "slides.write",
+			Account:    normalizeEmail(c.Account),
+			Target:     c.PresentationID,
+			DryRun:     false,
+			RevisionID: previousRevision,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
 	}
 
-	occurrences := int64(0)
-	if resp != nil && len(resp.Replies) > 0 && resp.Replies[0].ReplaceAllText != nil {
-		occurrences = resp.Replies[0].ReplaceAllText.OccurrencesChanged
+	result := map[string]any{
+		"replaced":            replaced,
+		"presentation_id":     c.PresentationID,
+		"occurrences_changed": sumOccurrences(counts),
+		"pairs":               replacePairResults(pairs, counts),
 	}
+	addRevisionResult(result, previousRevision, revisionErr)
 
-	return output.WriteJSON(os.Stdout, map[string]any{
-		"presentation_id":     c.PresentationID,
-		"occurrences_changed": occurrences,
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// SlidesExportCmd exports a presentation with Drive.
+type SlidesExportCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	Format         string `name:"format" required:"" help:"Export format: pptx, odp, pdf, txt, png."`
+	PageID         string `name:"page-id" help:"Slide object ID to export as PNG (default: first slide)."`
+	Output         string `name:"output" required:"" help:"Output file path, or - to stream to stdout."`
+	Overwrite      bool   `name:"overwrite" help:"Allow overwriting an existing output file (default: disabled)."`
+	MaxBytes       int64  `name:"max-bytes" help:"Abort when the export is larger than this many bytes (0: no limit)."`
+}
+
+func (c *SlidesExportCmd) Run(ctx context.Context, root *RootFlags) error {
+	return slidesFiles.export(ctx, root, exportRequest{
+		account:   c.Account,
+		fileID:    c.PresentationID,
+		format:    c.Format,
+		output:    c.Output,
+		overwrite: c.Overwrite,
+		maxBytes:  c.MaxBytes,
+		pageID:    c.PageID,
 	})
 }
 
@@ -0,0 +1,638 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// slideLayout names the placeholders of a predefined layout that receive
+// the title and body text. An empty type means the layout has no such
+// placeholder.
+type slideLayout struct {
+	title string
+	body  string
+}
+
+var slideLayouts = map[string]slideLayout{
+	"TITLE":                 {title: "CENTERED_TITLE", body: "SUBTITLE"},
+	"TITLE_AND_BODY":        {title: "TITLE", body: "BODY"},
+	"TITLE_AND_TWO_COLUMNS": {title: "TITLE", body: "BODY"},
+	"TITLE_ONLY":            {title: "TITLE"},
+	"SECTION_HEADER":        {title: "TITLE"},
+	"ONE_COLUMN_TEXT":       {title: "TITLE", body: "BODY"},
+	"MAIN_POINT":            {title: "TITLE"},
+	"BIG_NUMBER":            {title: "TITLE", body: "BODY"},
+	"CAPTION_ONLY":          {body: "BODY"},
+	"BLANK":                 {},
+}
+
+// slideSpec is one slide to create, from flags or an outline entry.
+type slideSpec struct {
+	Layout string    `json:"layout,omitempty"`
+	Title  string    `json:"title,omitempty"`
+	Body   slideBody `json:"body,omitempty"`
+}
+
+// slideBody is body text given as one string or as a list of lines.
+type slideBody []string
+
+func (b *slideBody) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = slideBody{s}
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return fmt.Errorf("body must be a string or an array of strings")
+	}
+	*b = lines
+
+	return nil
+}
+
+// newObjectID returns a random ID for an object this command creates, so
+// that later requests in the same batch can refer to it.
+func newObjectID() string {
+	return "gog_" + strings.ToLower(rand.Text())
+}
+
+// slideRequests creates the slide described by s at index (-1: at the end)
+// and fills its placeholders.
+func slideRequests(s slideSpec, index int) ([]*slides.Request, string, error) {
+	name := strings.ToUpper(s.Layout)
+	if name == "" {
+		name = "TITLE_AND_BODY"
+	}
+	layout, ok := slideLayouts[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unsupported layout %q; use %s", s.Layout, strings.Join(sortedKeys(slideLayouts), ", "))
+	}
+	body := strings.Join(s.Body, "\n")
+	if s.Title != "" && layout.title == "" {
+		return nil, "", fmt.Errorf("layout %s has no title placeholder", name)
+	}
+	if body != "" && layout.body == "" {
+		return nil, "", fmt.Errorf("layout %s has no body placeholder", name)
+	}
+
+	slideID := newObjectID()
+	create := &slides.CreateSlideRequest{
+		ObjectId:             slideID,
+		SlideLayoutReference: &slides.LayoutReference{PredefinedLayout: name},
+	}
+	if index >= 0 {
+		create.InsertionIndex = int64(index)
+		create.ForceSendFields = []string{"InsertionIndex"}
+	}
+
+	var fill []*slides.Request
+	for _, p := range []struct{ placeholder, text string }{{layout.title, s.Title}, {layout.body, body}} {
+		if p.text == "" {
+			continue
+		}
+		id := newObjectID()
+		create.PlaceholderIdMappings = append(create.PlaceholderIdMappings, &slides.LayoutPlaceholderIdMapping{
+			LayoutPlaceholder: &slides.Placeholder{Type: p.placeholder},
+			ObjectId:          id,
+		})
+		fill = append(fill, &slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: id, Text: p.text}})
+	}
+
+	return append([]*slides.Request{{CreateSlide: create}}, fill...), slideID, nil
+}
+
+// parseOutline reads a deck outline: a JSON array of slides (or an object
+// with a "slides" array), or Markdown where "#" starts a title slide and
+// "##" a content slide whose following lines are the body.
+func parseOutline(data string) ([]slideSpec, error) {
+	trimmed := strings.TrimSpace(data)
+	var specs []slideSpec
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		if err := json.Unmarshal([]byte(trimmed), &specs); err != nil {
+			return nil, fmt.Errorf("parse outline JSON: %w", err)
+		}
+	case strings.HasPrefix(trimmed, "{"):
+		var deck struct {
+			Slides []slideSpec `json:"slides"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &deck); err != nil {
+			return nil, fmt.Errorf("parse outline JSON: %w", err)
+		}
+		specs = deck.Slides
+	default:
+		var err error
+		if specs, err = parseMarkdownOutline(trimmed); err != nil {
+			return nil, err
+		}
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("outline has no slides")
+	}
+
+	return specs, nil
+}
+
+func parseMarkdownOutline(text string) ([]slideSpec, error) {
+	var specs []slideSpec
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || line == "---":
+			continue
+		case strings.HasPrefix(line, "# "):
+			specs = append(specs, slideSpec{Layout: "TITLE", Title: strings.TrimSpace(line[2:])})
+			continue
+		case strings.HasPrefix(line, "## "):
+			specs = append(specs, slideSpec{Layout: "TITLE_ONLY", Title: strings.TrimSpace(line[3:])})
+			continue
+		case len(specs) == 0:
+			return nil, fmt.Errorf("outline line %d: text before the first # or ## heading", n+1)
+		}
+
+		for _, marker := range []string{"- ", "* ", "+ "} {
+			if strings.HasPrefix(line, marker) {
+				line = strings.TrimSpace(line[len(marker):])
+				break
+			}
+		}
+		last := &specs[len(specs)-1]
+		if last.Layout == "TITLE_ONLY" {
+			last.Layout = "TITLE_AND_BODY"
+		}
+		last.Body = append(last.Body, line)
+	}
+
+	return specs, nil
+}
+
+// slideSummary describes a created slide in command output.
+type slideSummary struct {
+	ObjectID string `json:"object_id"`
+	Layout   string `json:"layout"`
+	Title    string `json:"title,omitempty"`
+}
+
+// findSlide returns the 0-based position of the slide pageID.
+func findSlide(pres *slides.Presentation, pageID string) (int, *slides.Page, error) {
+	for i, s := range pres.Slides {
+		if s.ObjectId == pageID {
+			return i, s, nil
+		}
+	}
+
+	return 0, nil, &gapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("slide %q not found", pageID)}
+}
+
+// SlidesCreateCmd creates a presentation, optionally from an outline.
+type SlidesCreateCmd struct {
+	Account string `name:"account" required:"" short:"a" help:"Google account email."`
+	Title   string `name:"title" required:"" help:"Presentation title."`
+	Outline string `name:"outline" help:"JSON or Markdown outline file that generates the slides (- for stdin)."`
+}
+
+func (c *SlidesCreateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.create"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	var specs []slideSpec
+	var requests []*slides.Request
+	var summaries []slideSummary
+	if c.Outline != "" {
+		data, err := readInputFile(c.Outline)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+		}
+		if specs, err = parseOutline(data); err != nil {
+			return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+		}
+		for i, s := range specs {
+			reqs, id, err := slideRequests(s, -1)
+			if err != nil {
+				return output.WriteError(output.ExitCodeError, "invalid_values", fmt.Sprintf("slide %d: %v", i+1, err))
+			}
+			requests = append(requests, reqs...)
+			summaries = append(summaries, slideSummary{ObjectID: id, Layout: reqs[0].CreateSlide.SlideLayoutReference.PredefinedLayout, Title: s.Title})
+		}
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.create",
+			Account: normalizeEmail(c.Account),
+			Target:  c.Title,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.create",
+			"params": map[string]any{
+				"account": c.Account,
+				"title":   c.Title,
+				"outline": c.Outline,
+				"slides":  specs,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	pres, err := svc.Presentations.Create(&slides.Presentation{Title: c.Title}).Do()
+	if err != nil {
+		return writeGoogleAPIError("slides_create_error", err)
+	}
+	// The presentation is recorded as soon as it exists, so it is audited
+	// even if applying the outline fails and it is left behind.
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.create",
+		Account: normalizeEmail(c.Account),
+		Target:  pres.PresentationId,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	// A new presentation starts with one empty title slide; an outline
+	// replaces it.
+	if len(requests) > 0 {
+		for _, s := range pres.Slides {
+			requests = append(requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: s.ObjectId}})
+		}
+		if _, err := svc.Presentations.BatchUpdate(pres.PresentationId, &slides.BatchUpdatePresentationRequest{
+			Requests: requests,
+		}).Do(); err != nil {
+			return writeGoogleAPIErrorDetails("slides_create_error",
+				fmt.Errorf("presentation %s was created but the outline could not be applied: %w", pres.PresentationId, err),
+				map[string]any{"presentation_id": pres.PresentationId})
+		}
+	} else {
+		for _, s := range pres.Slides {
+			summaries = append(summaries, slideSummary{ObjectID: s.ObjectId, Layout: "TITLE"})
+		}
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": pres.PresentationId,
+		"title":           pres.Title,
+		"url":             fmt.Sprintf("https://docs.google.com/presentation/d/%s/edit", pres.PresentationId),
+		"slides":          summaries,
+	})
+}
+
+// SlidesAddSlideCmd adds a slide from a predefined layout.
+type SlidesAddSlideCmd struct {
+	Account        string   `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string   `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	Layout         string   `name:"layout" default:"TITLE_AND_BODY" help:"Predefined layout: TITLE, TITLE_AND_BODY, TITLE_AND_TWO_COLUMNS, TITLE_ONLY, SECTION_HEADER, ONE_COLUMN_TEXT, MAIN_POINT, BIG_NUMBER, CAPTION_ONLY or BLANK."`
+	Title          string   `name:"title" help:"Text for the title placeholder."`
+	Body           []string `name:"body" sep:"none" help:"Text for the body placeholder; repeat for several lines."`
+	Position       int      `name:"position" help:"1-based position of the new slide (default: last)."`
+}
+
+func (c *SlidesAddSlideCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.add_slide"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Position < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--position must not be negative")
+	}
+	spec := slideSpec{Layout: c.Layout, Title: c.Title, Body: c.Body}
+	requests, slideID, err := slideRequests(spec, c.Position-1)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.add_slide",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.add_slide",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"layout":          strings.ToUpper(c.Layout),
+				"title":           c.Title,
+				"body":            c.Body,
+				"position":        c.Position,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do(); err != nil {
+		return writeGoogleAPIError("slides_add_slide_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.add_slide",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"page_id":         slideID,
+		"layout":          strings.ToUpper(c.Layout),
+	})
+}
+
+// SlidesDuplicateCmd copies a slide.
+type SlidesDuplicateCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageID         string `name:"page-id" required:"" help:"Object ID of the slide to copy."`
+	Position       int    `name:"position" help:"1-based position of the copy (default: after the original)."`
+}
+
+func (c *SlidesDuplicateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.duplicate"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Position < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--position must not be negative")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.duplicate",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.duplicate",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_id":         c.PageID,
+				"position":        c.Position,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	copyID := newObjectID()
+	requests := []*slides.Request{{DuplicateObject: &slides.DuplicateObjectRequest{
+		ObjectId:  c.PageID,
+		ObjectIds: map[string]string{c.PageID: copyID},
+	}}}
+	if c.Position > 0 {
+		requests = append(requests, &slides.Request{UpdateSlidesPosition: &slides.UpdateSlidesPositionRequest{
+			SlideObjectIds:  []string{copyID},
+			InsertionIndex:  int64(c.Position - 1),
+			ForceSendFields: []string{"InsertionIndex"},
+		}})
+	}
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do(); err != nil {
+		return writeGoogleAPIError("slides_duplicate_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.duplicate",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"source_page_id":  c.PageID,
+		"page_id":         copyID,
+	})
+}
+
+// SlidesDeleteSlideCmd deletes a slide.
+type SlidesDeleteSlideCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageID         string `name:"page-id" required:"" help:"Object ID of the slide to delete."`
+	ConfirmDelete  bool   `name:"confirm-delete" help:"Required confirmation flag for delete operations."`
+	ApprovalToken  string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *SlidesDeleteSlideCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.delete_slide"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	dryRun := root.DryRun
+	if !dryRun && !c.ConfirmDelete {
+		return output.WriteError(output.ExitCodeError, "delete_requires_confirmation",
+			"slides delete-slide requires --confirm-delete")
+	}
+	// The token is only checked here and consumed right before the write,
+	// so a mistyped page ID does not use it up.
+	approvalRequired := false
+	if !dryRun {
+		required, err := actionRequiresApproval("slides.delete_slide")
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "policy_error", err.Error())
+		}
+		if required && strings.TrimSpace(c.ApprovalToken) == "" {
+			return output.WriteError(output.ExitCodePermission, "approval_required",
+				"slides.delete_slide requires --approval-token")
+		}
+		approvalRequired = required
+	}
+
+	var svc *slides.Service
+	var err error
+	if dryRun {
+		svc, err = googleapi.NewSlidesReadOnly(ctx, c.Account)
+	} else {
+		svc, err = googleapi.NewSlidesWrite(ctx, c.Account)
+	}
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	// The slide is read first so that the dry-run and the result show what
+	// is being removed.
+	pres, err := svc.Presentations.Get(c.PresentationID).Do()
+	if err != nil {
+		return writeGoogleAPIError("slides_delete_slide_error", err)
+	}
+	index, slide, err := findSlide(pres, c.PageID)
+	if err != nil {
+		return writeGoogleAPIError("slides_delete_slide_error", err)
+	}
+	texts := extractPageTexts(slide.PageElements)
+
+	if dryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.delete_slide",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.delete_slide",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_id":         c.PageID,
+				"slide_number":    index + 1,
+				"slide_count":     len(pres.Slides),
+				"texts":           texts,
+			},
+		})
+	}
+
+	if approvalRequired {
+		if err := consumeApprovalToken(c.Account, "slides.delete_slide", c.ApprovalToken); err != nil {
+			return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
+		}
+	}
+
+	previousRevision, revisionErr := preWriteRevisionID(ctx, c.Account, "slides.delete_slide", c.PresentationID)
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests:     []*slides.Request{{DeleteObject: &slides.DeleteObjectRequest{ObjectId: c.PageID}}},
+		WriteControl: &slides.WriteControl{RequiredRevisionId: pres.RevisionId},
+	}).Do(); err != nil {
+		if isRevisionConflict(err) {
+			return writeConflictError("presentation changed while the slide was being deleted; read it again and retry")
+		}
+		return writeGoogleAPIError("slides_delete_slide_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:     "slides.delete_slide",
+		Account:    normalizeEmail(c.Account),
+		Target:     c.PresentationID,
+		DryRun:     false,
+		RevisionID: previousRevision,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	result := map[string]any{
+		"deleted":         true,
+		"presentation_id": c.PresentationID,
+		"page_id":         c.PageID,
+		"slide_number":    index + 1,
+		"texts":           texts,
+	}
+	addRevisionResult(result, previousRevision, revisionErr)
+
+	return output.WriteJSON(os.Stdout, result)
+}
+
+// SlidesReorderCmd moves slides to a new position.
+type SlidesReorderCmd struct {
+	Account        string   `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string   `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageIDs        []string `name:"page-ids" required:"" help:"Comma-separated slide object IDs, in the order they should appear."`
+	Position       int      `name:"position" required:"" help:"1-based position, in the current order, to move the slides to."`
+}
+
+func (c *SlidesReorderCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.reorder"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Position < 1 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--position must be at least 1")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.reorder",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.reorder",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_ids":        c.PageIDs,
+				"position":        c.Position,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{UpdateSlidesPosition: &slides.UpdateSlidesPositionRequest{
+			SlideObjectIds:  c.PageIDs,
+			InsertionIndex:  int64(c.Position - 1),
+			ForceSendFields: []string{"InsertionIndex"},
+		}}},
+	}).Do(); err != nil {
+		return writeGoogleAPIError("slides_reorder_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.reorder",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"page_ids":        c.PageIDs,
+		"position":        c.Position,
+	})
+}
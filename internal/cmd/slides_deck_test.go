@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestParseOutline_Markdown(t *testing.T) {
+	specs, err := parseOutline(`# Weekly status
+Week 42
+
+## Done
+- Shipped import
+* Fixed export
+
+## Next
+`)
+	if err != nil {
+		t.Fatalf("parseOutline: %v", err)
+	}
+
+	want := []slideSpec{
+		{Layout: "TITLE", Title: "Weekly status", Body: slideBody{"Week 42"}},
+		{Layout: "TITLE_AND_BODY", Title: "Done", Body: slideBody{"Shipped import", "Fixed export"}},
+		{Layout: "TITLE_ONLY", Title: "Next"},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("got %d slides, want %d: %+v", len(specs), len(want), specs)
+	}
+	for i := range want {
+		if specs[i].Layout != want[i].Layout || specs[i].Title != want[i].Title || !slices.Equal(specs[i].Body, want[i].Body) {
+			t.Errorf("slide %d = %+v, want %+v", i+1, specs[i], want[i])
+		}
+	}
+}
+
+func TestParseOutline_JSON(t *testing.T) {
+	for _, input := range []string{
+		`[{"layout":"title","title":"Deck"},{"title":"Agenda","body":["a","b"]}]`,
+		`{"slides":[{"layout":"title","title":"Deck"},{"title":"Agenda","body":["a","b"]}]}`,
+	} {
+		specs, err := parseOutline(input)
+		if err != nil {
+			t.Fatalf("parseOutline(%s): %v", input, err)
+		}
+		if len(specs) != 2 || specs[1].Title != "Agenda" || !slices.Equal(specs[1].Body, slideBody{"a", "b"}) {
+			t.Errorf("parseOutline(%s) = %+v", input, specs)
+		}
+	}
+
+	specs, err := parseOutline(`[{"title":"One","body":"single line"}]`)
+	if err != nil {
+		t.Fatalf("parseOutline: %v", err)
+	}
+	if !slices.Equal(specs[0].Body, slideBody{"single line"}) {
+		t.Errorf("body = %v, want a single line", specs[0].Body)
+	}
+}
+
+func TestParseOutline_Errors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"[]",
+		"intro before any heading\n## Slide",
+		`[{"title":"x","body":3}]`,
+	} {
+		if _, err := parseOutline(input); err == nil {
+			t.Errorf("parseOutline(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestSlideRequests_MapsPlaceholders(t *testing.T) {
+	reqs, slideID, err := slideRequests(slideSpec{Title: "Agenda", Body: slideBody{"a", "b"}}, 2)
+	if err != nil {
+		t.Fatalf("slideRequests: %v", err)
+	}
+	if len(reqs) != 3 {
+		t.Fatalf("got %d requests, want create + 2 inserts", len(reqs))
+	}
+
+	create := reqs[0].CreateSlide
+	if create.ObjectId != slideID || create.SlideLayoutReference.PredefinedLayout != "TITLE_AND_BODY" || create.InsertionIndex != 2 {
+		t.Errorf("create = %+v", create)
+	}
+	if len(create.PlaceholderIdMappings) != 2 {
+		t.Fatalf("mappings = %+v", create.PlaceholderIdMappings)
+	}
+	for i, want := range []struct{ placeholder, text string }{{"TITLE", "Agenda"}, {"BODY", "a\nb"}} {
+		m := create.PlaceholderIdMappings[i]
+		insert := reqs[i+1].InsertText
+		if m.LayoutPlaceholder.Type != want.placeholder || insert.ObjectId != m.ObjectId || insert.Text != want.text {
+			t.Errorf("placeholder %d: mapping %+v, insert %+v", i, m, insert)
+		}
+	}
+}
+
+func TestSlideRequests_AppendsWithoutIndex(t *testing.T) {
+	reqs, _, err := slideRequests(slideSpec{Layout: "blank"}, -1)
+	if err != nil {
+		t.Fatalf("slideRequests: %v", err)
+	}
+	create := reqs[0].CreateSlide
+	if len(reqs) != 1 || create.ForceSendFields != nil || create.PlaceholderIdMappings != nil {
+		t.Errorf("requests = %+v", create)
+	}
+}
+
+func TestSlideRequests_RejectsMissingPlaceholder(t *testing.T) {
+	for _, s := range []slideSpec{
+		{Layout: "TITLE_ONLY", Title: "x", Body: slideBody{"y"}},
+		{Layout: "CAPTION_ONLY", Title: "x"},
+		{Layout: "NOT_A_LAYOUT"},
+	} {
+		if _, _, err := slideRequests(s, -1); err == nil {
+			t.Errorf("slideRequests(%+v) succeeded, want error", s)
+		}
+	}
+}
+
+func TestSlidesCreateCmd_DryRunWithOutline(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	path := filepath.Join(t.TempDir(), "outline.md")
+	if err := os.WriteFile(path, []byte("# Status\n## Done\n- a\n"), 0o600); err != nil {
+		t.Fatalf("write outline: %v", err)
+	}
+
+	cmd := &SlidesCreateCmd{Account: "a@example.com", Title: "Weekly", Outline: path}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		Action string `json:"action"`
+		Params struct {
+			Slides []slideSpec `json:"slides"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if payload.Action != "slides.create" || len(payload.Params.Slides) != 2 {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestSlidesCreateCmd_InvalidOutline(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	path := filepath.Join(t.TempDir(), "outline.json")
+	if err := os.WriteFile(path, []byte(`[{"layout":"BLANK","title":"x"}]`), 0o600); err != nil {
+		t.Fatalf("write outline: %v", err)
+	}
+
+	code, exit := runForCode(t, func() error {
+		return (&SlidesCreateCmd{Account: "a@example.com", Title: "Weekly", Outline: path}).Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "invalid_values" || exit != output.ExitCodeError {
+		t.Errorf("got %s (exit %d), want invalid_values", code, exit)
+	}
+}
+
+func TestSlidesAddSlideCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	for _, cmd := range []*SlidesAddSlideCmd{
+		{Account: "a@example.com", PresentationID: "p", Layout: "SECTION_HEADER", Body: []string{"x"}},
+		{Account: "a@example.com", PresentationID: "p", Layout: "TITLE_AND_BODY", Position: -1},
+	} {
+		code, _ := runForCode(t, func() error {
+			return cmd.Run(context.Background(), &RootFlags{DryRun: true})
+		})
+		if code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q, want invalid_arguments", cmd, code)
+		}
+	}
+}
+
+func TestSlidesDeleteSlideCmd_RequiresConfirmation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&SlidesDeleteSlideCmd{Account: "a@example.com", PresentationID: "p", PageID: "s1"}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "delete_requires_confirmation" {
+		t.Errorf("code = %q, want delete_requires_confirmation", code)
+	}
+}
+
+func TestSlidesDeleteSlideCmd_RequiresApproval(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, exit := runForCode(t, func() error {
+		return (&SlidesDeleteSlideCmd{Account: "a@example.com", PresentationID: "p", PageID: "s1", ConfirmDelete: true}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "approval_required" || exit != output.ExitCodePermission {
+		t.Errorf("got %s (exit %d), want approval_required", code, exit)
+	}
+}
+
+func TestSlidesDeleteSlideCmd_KeepsTokenWhenLookupFails(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	token, _, err := issueApprovalToken("a@example.com", "slides.delete_slide", time.Minute)
+	if err != nil {
+		t.Fatalf("issueApprovalToken: %v", err)
+	}
+
+	// With no stored credentials the slide cannot be looked up, so the
+	// command fails before the write and the token must stay usable.
+	cmd := &SlidesDeleteSlideCmd{Account: "a@example.com", PresentationID: "p", PageID: "s1", ConfirmDelete: true, ApprovalToken: token}
+	if code, _ := runForCode(t, func() error { return cmd.Run(context.Background(), &RootFlags{}) }); code == "approval_required" {
+		t.Fatalf("code = %q, want a lookup failure", code)
+	}
+	if err := consumeApprovalToken("a@example.com", "slides.delete_slide", token); err != nil {
+		t.Errorf("token was consumed by a failed lookup: %v", err)
+	}
+}
+
+func TestSlidesReorderCmd_RequiresPosition(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&SlidesReorderCmd{Account: "a@example.com", PresentationID: "p", PageIDs: []string{"s1"}}).Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "invalid_arguments" {
+		t.Errorf("code = %q, want invalid_arguments", code)
+	}
+}
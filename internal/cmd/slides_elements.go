@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"math"
+	"strings"
+
+	"google.golang.org/api/slides/v1"
+)
+
+// emuPerPoint converts Slides dimensions, which are in EMU unless their unit
+// says PT.
+const emuPerPoint = 12700
+
+func toPoints(v float64, unit string) float64 {
+	if unit == "PT" {
+		return v
+	}
+
+	return v / emuPerPoint
+}
+
+// affine is a Slides AffineTransform with its translation in points.
+type affine struct {
+	scaleX, shearX, shearY, scaleY, translateX, translateY float64
+}
+
+var identityAffine = affine{scaleX: 1, scaleY: 1}
+
+func newAffine(t *slides.AffineTransform) affine {
+	if t == nil {
+		return identityAffine
+	}
+
+	return affine{
+		scaleX: t.ScaleX, shearX: t.ShearX,
+		shearY: t.ShearY, scaleY: t.ScaleY,
+		translateX: toPoints(t.TranslateX, t.Unit),
+		translateY: toPoints(t.TranslateY, t.Unit),
+	}
+}
+
+// then returns the transform of an element placed with a inside a group
+// placed with outer, i.e. outer × a.
+func (a affine) then(outer affine) affine {
+	return affine{
+		scaleX:     outer.scaleX*a.scaleX + outer.shearX*a.shearY,
+		shearX:     outer.scaleX*a.shearX + outer.shearX*a.scaleY,
+		shearY:     outer.shearY*a.scaleX + outer.scaleY*a.shearY,
+		scaleY:     outer.shearY*a.shearX + outer.scaleY*a.scaleY,
+		translateX: outer.scaleX*a.translateX + outer.shearX*a.translateY + outer.translateX,
+		translateY: outer.shearY*a.translateX + outer.scaleY*a.translateY + outer.translateY,
+	}
+}
+
+func (a affine) apply(x, y float64) (float64, float64) {
+	return a.scaleX*x + a.shearX*y + a.translateX, a.shearY*x + a.scaleY*y + a.translateY
+}
+
+// boundingBox is the axis-aligned box an element covers on the page, in
+// points from the top-left corner.
+type boundingBox struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// box returns the page area covered by a w×h element placed with a.
+func (a affine) box(w, h float64) *boundingBox {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, corner := range [][2]float64{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := a.apply(corner[0], corner[1])
+		minX, maxX = min(minX, x), max(maxX, x)
+		minY, maxY = min(minY, y), max(maxY, y)
+	}
+
+	return &boundingBox{X: roundPoints(minX), Y: roundPoints(minY), Width: roundPoints(maxX - minX), Height: roundPoints(maxY - minY)}
+}
+
+func (b *boundingBox) union(o *boundingBox) *boundingBox {
+	if b == nil {
+		return o
+	}
+	if o == nil {
+		return b
+	}
+	x, y := min(b.X, o.X), min(b.Y, o.Y)
+
+	return &boundingBox{
+		X: x, Y: y,
+		Width:  roundPoints(max(b.X+b.Width, o.X+o.Width) - x),
+		Height: roundPoints(max(b.Y+b.Height, o.Y+o.Height) - y),
+	}
+}
+
+func roundPoints(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// slideElement describes a page element in slides get output.
+type slideElement struct {
+	ObjectID    string         `json:"object_id"`
+	Type        string         `json:"type"`
+	Placeholder string         `json:"placeholder,omitempty"`
+	Box         *boundingBox   `json:"box,omitempty"`
+	Text        string         `json:"text,omitempty"`
+	Cells       [][]string     `json:"cells,omitempty"`
+	Children    []slideElement `json:"children,omitempty"`
+}
+
+// describeElements lists elements with their position on the page, recursing
+// into groups. A child's transform is relative to its group.
+func describeElements(elements []*slides.PageElement, outer affine) []slideElement {
+	out := make([]slideElement, 0, len(elements))
+	for _, elem := range elements {
+		t := newAffine(elem.Transform).then(outer)
+		e := slideElement{ObjectID: elem.ObjectId, Type: elementType(elem)}
+		if elem.Size != nil && elem.Size.Width != nil && elem.Size.Height != nil {
+			e.Box = t.box(toPoints(elem.Size.Width.Magnitude, elem.Size.Width.Unit), toPoints(elem.Size.Height.Magnitude, elem.Size.Height.Unit))
+		}
+
+		switch {
+		case elem.Shape != nil:
+			e.Text = textContent(elem.Shape.Text)
+			if elem.Shape.Placeholder != nil {
+				e.Placeholder = elem.Shape.Placeholder.Type
+			}
+		case elem.Table != nil:
+			for _, row := range elem.Table.TableRows {
+				cells := make([]string, len(row.TableCells))
+				for i, cell := range row.TableCells {
+					cells[i] = textContent(cell.Text)
+				}
+				e.Cells = append(e.Cells, cells)
+			}
+		case elem.ElementGroup != nil:
+			e.Children = describeElements(elem.ElementGroup.Children, t)
+			if e.Box == nil {
+				for _, child := range e.Children {
+					e.Box = e.Box.union(child.Box)
+				}
+			}
+		}
+		out = append(out, e)
+	}
+
+	return out
+}
+
+func elementType(elem *slides.PageElement) string {
+	switch {
+	case elem.Shape != nil:
+		return "shape"
+	case elem.Table != nil:
+		return "table"
+	case elem.Image != nil:
+		return "image"
+	case elem.ElementGroup != nil:
+		return "group"
+	case elem.Line != nil:
+		return "line"
+	case elem.Video != nil:
+		return "video"
+	case elem.SheetsChart != nil:
+		return "sheets_chart"
+	case elem.WordArt != nil:
+		return "word_art"
+	}
+
+	return "other"
+}
+
+// textContent joins the text runs of t without the final paragraph break.
+func textContent(t *slides.TextContent) string {
+	var b strings.Builder
+	for _, run := range textRuns(t) {
+		b.WriteString(run)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func textRuns(t *slides.TextContent) []string {
+	if t == nil {
+		return nil
+	}
+	var runs []string
+	for _, te := range t.TextElements {
+		if te.TextRun != nil && te.TextRun.Content != "" {
+			runs = append(runs, te.TextRun.Content)
+		}
+	}
+
+	return runs
+}
+
+// extractPageTexts extracts the text runs of shapes and table cells on a
+// page, including those inside groups.
+func extractPageTexts(elements []*slides.PageElement) []string {
+	var texts []string
+	for _, elem := range elements {
+		switch {
+		case elem.Shape != nil:
+			texts = append(texts, textRuns(elem.Shape.Text)...)
+		case elem.Table != nil:
+			for _, row := range elem.Table.TableRows {
+				for _, cell := range row.TableCells {
+					texts = append(texts, textRuns(cell.Text)...)
+				}
+			}
+		case elem.ElementGroup != nil:
+			texts = append(texts, extractPageTexts(elem.ElementGroup.Children)...)
+		}
+	}
+	return texts
+}
+
+// speakerNotes returns the object ID of the speaker notes shape of a slide
+// and its text. The shape may not exist yet; inserting text into the ID
+// creates it.
+func speakerNotes(page *slides.Page) (string, string) {
+	if page.SlideProperties == nil || page.SlideProperties.NotesPage == nil {
+		return "", ""
+	}
+	notes := page.SlideProperties.NotesPage
+	if notes.NotesProperties == nil {
+		return "", ""
+	}
+	id := notes.NotesProperties.SpeakerNotesObjectId
+	for _, elem := range notes.PageElements {
+		if elem.ObjectId == id && elem.Shape != nil {
+			return id, textContent(elem.Shape.Text)
+		}
+	}
+
+	return id, ""
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/api/slides/v1"
+)
+
+func shapeWithText(id string, runs ...string) *slides.PageElement {
+	text := &slides.TextContent{}
+	for _, r := range runs {
+		text.TextElements = append(text.TextElements, &slides.TextElement{TextRun: &slides.TextRun{Content: r}})
+	}
+	return &slides.PageElement{ObjectId: id, Shape: &slides.Shape{Text: text}}
+}
+
+func TestExtractPageTexts_RecursesIntoGroupsAndTables(t *testing.T) {
+	elems := []*slides.PageElement{
+		shapeWithText("title", "Title\n"),
+		{ObjectId: "group", ElementGroup: &slides.Group{Children: []*slides.PageElement{
+			shapeWithText("inner", "Grouped\n"),
+		}}},
+		{ObjectId: "table", Table: &slides.Table{TableRows: []*slides.TableRow{
+			{TableCells: []*slides.TableCell{
+				{Text: &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: "A1\n"}}}}},
+				{},
+			}},
+		}}},
+	}
+
+	want := []string{"Title\n", "Grouped\n", "A1\n"}
+	if got := extractPageTexts(elems); !slices.Equal(got, want) {
+		t.Errorf("extractPageTexts = %q, want %q", got, want)
+	}
+}
+
+func TestDescribeElements_BoxesInPoints(t *testing.T) {
+	size := &slides.Size{
+		Width:  &slides.Dimension{Magnitude: 100 * emuPerPoint, Unit: "EMU"},
+		Height: &slides.Dimension{Magnitude: 50 * emuPerPoint, Unit: "EMU"},
+	}
+	child := shapeWithText("child", "Hi\n")
+	child.Size = size
+	child.Transform = &slides.AffineTransform{ScaleX: 2, ScaleY: 1, TranslateX: 10, TranslateY: 20, Unit: "PT"}
+	group := &slides.PageElement{
+		ObjectId:     "group",
+		Transform:    &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 100 * emuPerPoint, TranslateY: 0, Unit: "EMU"},
+		ElementGroup: &slides.Group{Children: []*slides.PageElement{child}},
+	}
+
+	got := describeElements([]*slides.PageElement{group}, identityAffine)
+	if len(got) != 1 || len(got[0].Children) != 1 {
+		t.Fatalf("describeElements = %+v", got)
+	}
+
+	want := boundingBox{X: 110, Y: 20, Width: 200, Height: 50}
+	if c := got[0].Children[0]; c.Box == nil || *c.Box != want || c.Text != "Hi" || c.Type != "shape" {
+		t.Errorf("child = %+v (box %+v), want box %+v and text Hi", c, c.Box, want)
+	}
+	if g := got[0]; g.Type != "group" || g.Box == nil || *g.Box != want {
+		t.Errorf("group box = %+v, want the union of its children %+v", g.Box, want)
+	}
+}
+
+func TestDescribeElements_TableCells(t *testing.T) {
+	table := &slides.PageElement{ObjectId: "t", Table: &slides.Table{TableRows: []*slides.TableRow{
+		{TableCells: []*slides.TableCell{
+			{Text: &slides.TextContent{TextElements: []*slides.TextElement{{TextRun: &slides.TextRun{Content: "Task\n"}}}}},
+			{},
+		}},
+	}}}
+
+	got := describeElements([]*slides.PageElement{table}, identityAffine)
+	if len(got) != 1 || got[0].Type != "table" || len(got[0].Cells) != 1 || !slices.Equal(got[0].Cells[0], []string{"Task", ""}) {
+		t.Errorf("describeElements = %+v", got)
+	}
+}
+
+func TestSpeakerNotes(t *testing.T) {
+	page := &slides.Page{SlideProperties: &slides.SlideProperties{NotesPage: &slides.Page{
+		NotesProperties: &slides.NotesProperties{SpeakerNotesObjectId: "notes"},
+		PageElements: []*slides.PageElement{
+			shapeWithText("other", "slide image\n"),
+			shapeWithText("notes", "Talk about ", "growth\n"),
+		},
+	}}}
+
+	id, text := speakerNotes(page)
+	if id != "notes" || text != "Talk about growth" {
+		t.Errorf("speakerNotes = %q, %q", id, text)
+	}
+
+	if id, text := speakerNotes(&slides.Page{}); id != "" || text != "" {
+		t.Errorf("speakerNotes of a non-slide page = %q, %q", id, text)
+	}
+}
@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// slidesImageMimeTypes are the image types Slides can insert.
+var slidesImageMimeTypes = map[string]bool{"image/png": true, "image/jpeg": true, "image/gif": true}
+
+// maxSlidesImageBytes is the largest image Slides accepts.
+const maxSlidesImageBytes = 50 << 20
+
+// elementProperties places a new element on pageID. Positions and sizes are
+// in points; a zero size leaves the choice to Slides.
+func elementProperties(pageID string, x, y, width, height float64) (*slides.PageElementProperties, error) {
+	if x < 0 || y < 0 || width < 0 || height < 0 {
+		return nil, fmt.Errorf("--x, --y, --width and --height must not be negative")
+	}
+	if (width == 0) != (height == 0) {
+		return nil, fmt.Errorf("--width and --height must be set together")
+	}
+
+	props := &slides.PageElementProperties{PageObjectId: pageID}
+	if width > 0 {
+		props.Size = &slides.Size{
+			Width:  &slides.Dimension{Magnitude: width, Unit: "PT"},
+			Height: &slides.Dimension{Magnitude: height, Unit: "PT"},
+		}
+	}
+	if x > 0 || y > 0 {
+		props.Transform = &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: x, TranslateY: y, Unit: "PT"}
+	}
+
+	return props, nil
+}
+
+// errImageNotPublic reports a Drive image that Slides could not fetch.
+var errImageNotPublic = errors.New("image is not shared publicly")
+
+// sharedWithAnyone reports whether perms let anyone with the link view the
+// file.
+func sharedWithAnyone(perms []*drive.Permission) bool {
+	for _, p := range perms {
+		if p.Type == "anyone" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// driveImageURL checks that a Drive file is an image Slides can insert and
+// returns its download URL. Slides fetches the URL without the caller's
+// credentials, so the file must already be viewable by anyone with the
+// link; sharing is never changed here, and other files fail with
+// errImageNotPublic instead of an image Slides cannot load.
+func driveImageURL(ctx context.Context, account, fileID string) (string, error) {
+	svc, err := googleapi.NewDriveReadOnly(ctx, account)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := svc.Files.Get(fileID).Fields("id,name,mimeType,size").SupportsAllDrives(true).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if !slidesImageMimeTypes[f.MimeType] {
+		return "", fmt.Errorf("%s is %s; slides accepts PNG, JPEG or GIF images", f.Name, f.MimeType)
+	}
+	if f.Size > maxSlidesImageBytes {
+		return "", fmt.Errorf("%s is %d bytes, over the 50MB limit of slides", f.Name, f.Size)
+	}
+
+	perms, err := svc.Permissions.List(f.Id).Fields("permissions(type,role)").SupportsAllDrives(true).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("read sharing of %s: %w", f.Name, err)
+	}
+	if !sharedWithAnyone(perms.Permissions) {
+		return "", fmt.Errorf("%w: %s must be viewable by anyone with the link; share it in Drive, or pass --url", errImageNotPublic, f.Name)
+	}
+
+	return "https://drive.google.com/uc?export=download&id=" + url.QueryEscape(f.Id), nil
+}
+
+// SlidesInsertImageCmd inserts an image on a slide.
+type SlidesInsertImageCmd struct {
+	Account        string  `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string  `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageID         string  `name:"page-id" required:"" help:"Object ID of the slide."`
+	URL            string  `name:"url" help:"Publicly accessible image URL (PNG, JPEG or GIF)."`
+	DriveFileID    string  `name:"drive-file-id" help:"Drive image file ID; the file must already be viewable by anyone with the link."`
+	X              float64 `name:"x" help:"Distance from the left edge of the slide, in points."`
+	Y              float64 `name:"y" help:"Distance from the top edge of the slide, in points."`
+	Width          float64 `name:"width" help:"Width in points (requires --height)."`
+	Height         float64 `name:"height" help:"Height in points (requires --width)."`
+}
+
+func (c *SlidesInsertImageCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.insert_image"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if (c.URL == "") == (c.DriveFileID == "") {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "specify exactly one of --url or --drive-file-id")
+	}
+	if c.URL != "" {
+		if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return output.WriteError(output.ExitCodeError, "invalid_arguments", "--url must be an http or https URL")
+		}
+	}
+	props, err := elementProperties(c.PageID, c.X, c.Y, c.Width, c.Height)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.insert_image",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.insert_image",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_id":         c.PageID,
+				"url":             c.URL,
+				"drive_file_id":   c.DriveFileID,
+				"x":               c.X,
+				"y":               c.Y,
+				"width":           c.Width,
+				"height":          c.Height,
+			},
+		})
+	}
+
+	imageURL := c.URL
+	if c.DriveFileID != "" {
+		if imageURL, err = driveImageURL(ctx, c.Account, c.DriveFileID); err != nil {
+			var authErr *googleapi.AuthRequiredError
+			if isAuthErr(err, &authErr) {
+				return slidesAuthError(err)
+			}
+			if errors.Is(err, errImageNotPublic) {
+				return output.WriteError(output.ExitCodeError, "image_not_public", err.Error())
+			}
+			return writeGoogleAPIError("slides_insert_image_error", err)
+		}
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	imageID := newObjectID()
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: []*slides.Request{{CreateImage: &slides.CreateImageRequest{
+			ObjectId:          imageID,
+			Url:               imageURL,
+			ElementProperties: props,
+		}}},
+	}).Do(); err != nil {
+		return writeGoogleAPIError("slides_insert_image_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.insert_image",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"page_id":         c.PageID,
+		"object_id":       imageID,
+	})
+}
+
+// parseTableValues reads --values for insert-table and returns the table
+// size, taking --rows and --columns as minimums.
+func parseTableValues(valuesJSON string, rows, columns int) ([][]string, int, int, error) {
+	var cells [][]string
+	if valuesJSON != "" {
+		var values [][]any
+		if err := json.Unmarshal([]byte(valuesJSON), &values); err != nil {
+			return nil, 0, 0, fmt.Errorf("parse --values JSON: %w", err)
+		}
+		for _, row := range values {
+			texts := make([]string, len(row))
+			for i, v := range row {
+				texts[i] = cellString(v)
+			}
+			cells = append(cells, texts)
+		}
+		rows = max(rows, len(values))
+		columns = max(columns, maxRowWidth(values))
+	}
+	if rows < 1 || columns < 1 {
+		return nil, 0, 0, fmt.Errorf("table needs at least one row and one column; set --rows and --columns or --values")
+	}
+
+	return cells, rows, columns, nil
+}
+
+// SlidesInsertTableCmd inserts a table on a slide.
+type SlidesInsertTableCmd struct {
+	Account        string  `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string  `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageID         string  `name:"page-id" required:"" help:"Object ID of the slide."`
+	Rows           int     `name:"rows" help:"Number of rows (default: rows in --values)."`
+	Columns        int     `name:"columns" help:"Number of columns (default: widest row in --values)."`
+	Values         string  `name:"values" help:"JSON array of rows to fill the cells (e.g. [[\"Task\",\"Owner\"],[\"Import\",\"Alice\"]])."`
+	X              float64 `name:"x" help:"Distance from the left edge of the slide, in points."`
+	Y              float64 `name:"y" help:"Distance from the top edge of the slide, in points."`
+	Width          float64 `name:"width" help:"Width in points (requires --height)."`
+	Height         float64 `name:"height" help:"Height in points (requires --width)."`
+}
+
+func (c *SlidesInsertTableCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.insert_table"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if c.Rows < 0 || c.Columns < 0 {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--rows and --columns must not be negative")
+	}
+	cells, rows, columns, err := parseTableValues(c.Values, c.Rows, c.Columns)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_values", err.Error())
+	}
+	props, err := elementProperties(c.PageID, c.X, c.Y, c.Width, c.Height)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.insert_table",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.insert_table",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_id":         c.PageID,
+				"rows":            rows,
+				"columns":         columns,
+				"values":          cells,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesWrite(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	tableID := newObjectID()
+	requests := []*slides.Request{{CreateTable: &slides.CreateTableRequest{
+		ObjectId:          tableID,
+		Rows:              int64(rows),
+		Columns:           int64(columns),
+		ElementProperties: props,
+	}}}
+	for r, row := range cells {
+		for col, text := range row {
+			if text == "" {
+				continue
+			}
+			requests = append(requests, &slides.Request{InsertText: &slides.InsertTextRequest{
+				ObjectId: tableID,
+				CellLocation: &slides.TableCellLocation{
+					RowIndex:        int64(r),
+					ColumnIndex:     int64(col),
+					ForceSendFields: []string{"RowIndex", "ColumnIndex"},
+				},
+				Text: text,
+			}})
+		}
+	}
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests: requests,
+	}).Do(); err != nil {
+		return writeGoogleAPIError("slides_insert_table_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.insert_table",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"page_id":         c.PageID,
+		"object_id":       tableID,
+		"rows":            rows,
+		"columns":         columns,
+	})
+}
+
+// SlidesNotesCmd groups speaker notes subcommands.
+type SlidesNotesCmd struct {
+	Set SlidesNotesSetCmd `cmd:"" help:"Set or append the speaker notes of a slide."`
+}
+
+// SlidesNotesSetCmd writes the speaker notes of a slide.
+type SlidesNotesSetCmd struct {
+	Account        string `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageID         string `name:"page-id" required:"" help:"Object ID of the slide."`
+	Text           string `name:"text" help:"Notes text."`
+	File           string `name:"file" help:"Read the notes text from a file (- for stdin)."`
+	Append         bool   `name:"append" help:"Add the text after the existing notes instead of replacing them."`
+	ConfirmReplace bool   `name:"confirm-replace" help:"Required confirmation flag to replace notes that are not empty."`
+}
+
+func (c *SlidesNotesSetCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.notes.set"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if (c.Text == "") == (c.File == "") {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "specify exactly one of --text or --file")
+	}
+	text := c.Text
+	if c.File != "" {
+		data, err := readInputFile(c.File)
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "file_read_error", err.Error())
+		}
+		text = strings.TrimSuffix(data, "\n")
+		if text == "" {
+			return output.WriteError(output.ExitCodeError, "invalid_values", "notes text is empty")
+		}
+	}
+
+	var svc *slides.Service
+	var err error
+	if root.DryRun {
+		svc, err = googleapi.NewSlidesReadOnly(ctx, c.Account)
+	} else {
+		svc, err = googleapi.NewSlidesWrite(ctx, c.Account)
+	}
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	page, err := svc.Presentations.Pages.Get(c.PresentationID, c.PageID).Do()
+	if err != nil {
+		return writeGoogleAPIError("slides_notes_error", err)
+	}
+	notesID, current := speakerNotes(page)
+	if notesID == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", fmt.Sprintf("page %s is not a slide and has no speaker notes", c.PageID))
+	}
+	mode := "replace"
+	if c.Append {
+		mode = "append"
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.notes.set",
+			Account: normalizeEmail(c.Account),
+			Target:  c.PresentationID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.notes.set",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_id":         c.PageID,
+				"mode":            mode,
+				"current_notes":   current,
+				"text":            text,
+			},
+		})
+	}
+
+	if !c.Append && current != "" && !c.ConfirmReplace {
+		return output.WriteError(output.ExitCodeError, "replace_requires_confirmation",
+			"slide already has speaker notes; use --append, or --confirm-replace to overwrite them")
+	}
+
+	var requests []*slides.Request
+	notes := text
+	switch {
+	case c.Append && current != "":
+		notes = current + "\n" + text
+		requests = append(requests, &slides.Request{InsertText: &slides.InsertTextRequest{
+			ObjectId:        notesID,
+			Text:            "\n" + text,
+			InsertionIndex:  int64(utf16Len(current)),
+			ForceSendFields: []string{"InsertionIndex"},
+		}})
+	default:
+		if current != "" {
+			requests = append(requests, &slides.Request{DeleteText: &slides.DeleteTextRequest{
+				ObjectId:  notesID,
+				TextRange: &slides.Range{Type: "ALL"},
+			}})
+		}
+		requests = append(requests, &slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: notesID, Text: text}})
+	}
+
+	if _, err := svc.Presentations.BatchUpdate(c.PresentationID, &slides.BatchUpdatePresentationRequest{
+		Requests:     requests,
+		WriteControl: &slides.WriteControl{RequiredRevisionId: page.RevisionId},
+	}).Do(); err != nil {
+		if isRevisionConflict(err) {
+			return writeConflictError("presentation changed while the notes were being written; read it again and retry")
+		}
+		return writeGoogleAPIError("slides_notes_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "slides.notes.set",
+		Account: normalizeEmail(c.Account),
+		Target:  c.PresentationID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"page_id":         c.PageID,
+		"mode":            mode,
+		"notes":           notes,
+	})
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestElementProperties(t *testing.T) {
+	props, err := elementProperties("p1", 10, 20, 300, 200)
+	if err != nil {
+		t.Fatalf("elementProperties: %v", err)
+	}
+	if props.PageObjectId != "p1" || props.Size.Width.Magnitude != 300 || props.Size.Height.Unit != "PT" ||
+		props.Transform.TranslateX != 10 || props.Transform.TranslateY != 20 || props.Transform.ScaleX != 1 {
+		t.Errorf("props = %+v", props)
+	}
+
+	props, err = elementProperties("p1", 0, 0, 0, 0)
+	if err != nil || props.Size != nil || props.Transform != nil {
+		t.Errorf("default placement = %+v, %v", props, err)
+	}
+
+	for _, args := range [][4]float64{{0, 0, 100, 0}, {-1, 0, 0, 0}} {
+		if _, err := elementProperties("p1", args[0], args[1], args[2], args[3]); err == nil {
+			t.Errorf("elementProperties(%v) succeeded, want error", args)
+		}
+	}
+}
+
+func TestSharedWithAnyone(t *testing.T) {
+	private := []*drive.Permission{{Type: "user", Role: "owner"}, {Type: "domain", Role: "reader"}}
+	if sharedWithAnyone(private) {
+		t.Error("user and domain sharing reported as public")
+	}
+	if !sharedWithAnyone(append(private, &drive.Permission{Type: "anyone", Role: "reader"})) {
+		t.Error("anyone-with-link sharing not reported as public")
+	}
+}
+
+func TestParseTableValues(t *testing.T) {
+	cells, rows, columns, err := parseTableValues(`[["Task","Owner"],["Import",null,3]]`, 4, 0)
+	if err != nil {
+		t.Fatalf("parseTableValues: %v", err)
+	}
+	if rows != 4 || columns != 3 {
+		t.Errorf("size = %dx%d, want 4x3", rows, columns)
+	}
+	if len(cells) != 2 || !slices.Equal(cells[1], []string{"Import", "", "3"}) {
+		t.Errorf("cells = %q", cells)
+	}
+
+	if _, _, _, err := parseTableValues("", 2, 0); err == nil {
+		t.Error("parseTableValues without columns succeeded, want error")
+	}
+	if _, _, _, err := parseTableValues("{", 0, 0); err == nil {
+		t.Error("parseTableValues with invalid JSON succeeded, want error")
+	}
+}
+
+func TestSlidesInsertImageCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	for _, cmd := range []*SlidesInsertImageCmd{
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1"},
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1", URL: "https://example.com/a.png", DriveFileID: "f"},
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1", URL: "file:///etc/passwd"},
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1", URL: "https://example.com/a.png", Width: 100},
+	} {
+		code, _ := runForCode(t, func() error {
+			return cmd.Run(context.Background(), &RootFlags{DryRun: true})
+		})
+		if code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q, want invalid_arguments", cmd, code)
+		}
+	}
+}
+
+func TestSlidesInsertTableCmd_InvalidValues(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&SlidesInsertTableCmd{Account: "a@example.com", PresentationID: "p", PageID: "s1"}).Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "invalid_values" {
+		t.Errorf("code = %q, want invalid_values", code)
+	}
+}
+
+func TestSlidesNotesSetCmd_RequiresOneSource(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	for _, cmd := range []*SlidesNotesSetCmd{
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1"},
+		{Account: "a@example.com", PresentationID: "p", PageID: "s1", Text: "x", File: "notes.txt"},
+	} {
+		code, _ := runForCode(t, func() error {
+			return cmd.Run(context.Background(), &RootFlags{DryRun: true})
+		})
+		if code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q, want invalid_arguments", cmd, code)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// thumbnailSizes are the thumbnail sizes Slides renders; LARGE is 1600px
+// wide, MEDIUM 800px and SMALL 200px.
+var thumbnailSizes = map[string]bool{"SMALL": true, "MEDIUM": true, "LARGE": true}
+
+// thumbnailFileName names the PNG of a slide so that a directory listing
+// sorts in slide order.
+func thumbnailFileName(slideNumber int, pageID string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, pageID)
+
+	return fmt.Sprintf("slide-%03d-%s.png", slideNumber, safe)
+}
+
+// thumbnailTarget is a slide to render and the file it is written to.
+type thumbnailTarget struct {
+	PageID      string `json:"page_id"`
+	SlideNumber int    `json:"slide_number"`
+	Path        string `json:"path"`
+}
+
+// thumbnailTargets picks the slides to render, in deck order.
+func thumbnailTargets(pres *slides.Presentation, pageIDs []string, all bool, outputDir string) ([]thumbnailTarget, error) {
+	var targets []thumbnailTarget
+	for i, s := range pres.Slides {
+		if all || slices.Contains(pageIDs, s.ObjectId) {
+			targets = append(targets, thumbnailTarget{
+				PageID:      s.ObjectId,
+				SlideNumber: i + 1,
+				Path:        filepath.Join(outputDir, thumbnailFileName(i+1, s.ObjectId)),
+			})
+		}
+	}
+	if !all {
+		for _, id := range pageIDs {
+			if _, _, err := findSlide(pres, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// SlidesThumbnailCmd renders slides to PNG files.
+type SlidesThumbnailCmd struct {
+	Account        string   `name:"account" required:"" short:"a" help:"Google account email."`
+	PresentationID string   `name:"presentation-id" required:"" help:"Google Slides presentation ID."`
+	PageIDs        []string `name:"page-id" help:"Comma-separated slide object IDs to render."`
+	All            bool     `name:"all" help:"Render every slide."`
+	Size           string   `name:"size" default:"LARGE" help:"Thumbnail size: SMALL (200px), MEDIUM (800px) or LARGE (1600px)."`
+	OutputDir      string   `name:"output-dir" required:"" help:"Directory to write slide-NNN-<page-id>.png files to."`
+	Overwrite      bool     `name:"overwrite" help:"Allow overwriting existing files (default: disabled)."`
+}
+
+func (c *SlidesThumbnailCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "slides.thumbnail"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+	if err := ensureWithinAllowedOutputDir(c.OutputDir, root.AllowedOutputDir); err != nil {
+		return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
+	}
+
+	if (len(c.PageIDs) == 0) == !c.All {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "specify exactly one of --page-id or --all")
+	}
+	size := strings.ToUpper(c.Size)
+	if !thumbnailSizes[size] {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments",
+			fmt.Sprintf("unsupported size %q; use %s", c.Size, strings.Join(sortedKeys(thumbnailSizes), ", ")))
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.thumbnail",
+			Account: normalizeEmail(c.Account),
+			Target:  c.OutputDir,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "slides.thumbnail",
+			"params": map[string]any{
+				"account":         c.Account,
+				"presentation_id": c.PresentationID,
+				"page_ids":        c.PageIDs,
+				"all":             c.All,
+				"size":            size,
+				"output_dir":      c.OutputDir,
+				"overwrite":       c.Overwrite,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewSlidesReadOnly(ctx, c.Account)
+	if err != nil {
+		return slidesAuthError(err)
+	}
+
+	pres, err := svc.Presentations.Get(c.PresentationID).Fields("presentationId,slides.objectId").Do()
+	if err != nil {
+		return writeGoogleAPIError("slides_thumbnail_error", err)
+	}
+	targets, err := thumbnailTargets(pres, c.PageIDs, c.All, c.OutputDir)
+	if err != nil {
+		return writeGoogleAPIError("slides_thumbnail_error", err)
+	}
+
+	// Every path is checked before rendering starts, so an existing file
+	// does not leave the directory half written.
+	for _, t := range targets {
+		if err := ensureWithinAllowedOutputDir(t.Path, root.AllowedOutputDir); err != nil {
+			return output.WriteError(output.ExitCodePermission, "output_not_allowed", err.Error())
+		}
+		if _, err := os.Stat(t.Path); err == nil && !c.Overwrite {
+			return output.WriteError(output.ExitCodeError, "file_write_error",
+				fmt.Sprintf("%s already exists; pass --overwrite to replace it", t.Path))
+		}
+	}
+
+	// Thumbnail URLs are short-lived and fetched without credentials.
+	client := googleapi.NewUnauthenticatedHTTPClient()
+
+	type thumbnailResult struct {
+		thumbnailTarget
+		Width        int64 `json:"width"`
+		Height       int64 `json:"height"`
+		BytesWritten int64 `json:"bytes_written"`
+	}
+
+	results := make([]thumbnailResult, 0, len(targets))
+	auditThumbnails := func() error {
+		return appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "slides.thumbnail",
+			Account: normalizeEmail(c.Account),
+			Target:  c.OutputDir,
+			DryRun:  false,
+		})
+	}
+	// A failure part way through audits and reports the files already
+	// written, which are kept.
+	thumbnailFailed := func(code string, err error) error {
+		details := map[string]any{"files_written": len(results)}
+		if len(results) > 0 {
+			if auditErr := auditThumbnails(); auditErr != nil {
+				return output.WriteError(output.ExitCodeError, "audit_error", auditErr.Error())
+			}
+			paths := make([]string, 0, len(results))
+			for _, r := range results {
+				paths = append(paths, r.Path)
+			}
+			details["paths"] = paths
+		}
+		if code == "file_write_error" {
+			return output.WriteErrorDetails(output.ExitCodeError, code, err.Error(), details)
+		}
+
+		return writeGoogleAPIErrorDetails(code, err, details)
+	}
+
+	for _, t := range targets {
+		thumb, err := svc.Presentations.Pages.GetThumbnail(c.PresentationID, t.PageID).
+			ThumbnailPropertiesThumbnailSize(size).
+			ThumbnailPropertiesMimeType("PNG").
+			Context(ctx).Do()
+		if err != nil {
+			return thumbnailFailed("slides_thumbnail_error", fmt.Errorf("slide %d (%s): %w", t.SlideNumber, t.PageID, err))
+		}
+
+		resp, err := downloadURL(ctx, client, thumb.ContentUrl, "thumbnail")
+		if err != nil {
+			return thumbnailFailed("slides_thumbnail_error", fmt.Errorf("slide %d (%s): %w", t.SlideNumber, t.PageID, err))
+		}
+		written, err := writeFileAtomically(t.Path, resp.Body, c.Overwrite)
+		resp.Body.Close()
+		if err != nil {
+			return thumbnailFailed("file_write_error", fmt.Errorf("write %s: %w", t.Path, err))
+		}
+		results = append(results, thumbnailResult{thumbnailTarget: t, Width: thumb.Width, Height: thumb.Height, BytesWritten: written})
+	}
+
+	if err := auditThumbnails(); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"presentation_id": c.PresentationID,
+		"size":            size,
+		"output_dir":      c.OutputDir,
+		"thumbnails":      results,
+	})
+}
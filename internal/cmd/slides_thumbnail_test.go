@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestThumbnailFileName(t *testing.T) {
+	if got := thumbnailFileName(7, "g1a2:b/../c"); got != "slide-007-g1a2_b____c.png" {
+		t.Errorf("thumbnailFileName = %q", got)
+	}
+}
+
+func TestThumbnailTargets(t *testing.T) {
+	pres := &slides.Presentation{Slides: []*slides.Page{{ObjectId: "a"}, {ObjectId: "b"}, {ObjectId: "c"}}}
+
+	targets, err := thumbnailTargets(pres, []string{"c", "a"}, false, "out")
+	if err != nil {
+		t.Fatalf("thumbnailTargets: %v", err)
+	}
+	if len(targets) != 2 || targets[0].PageID != "a" || targets[1].SlideNumber != 3 ||
+		targets[1].Path != filepath.Join("out", "slide-003-c.png") {
+		t.Errorf("targets = %+v, want a then c in deck order", targets)
+	}
+
+	if targets, _ := thumbnailTargets(pres, nil, true, "out"); len(targets) != 3 {
+		t.Errorf("--all targets = %+v", targets)
+	}
+
+	_, err = thumbnailTargets(pres, []string{"missing"}, false, "out")
+	var apiErr *gapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Errorf("missing page error = %v, want 404", err)
+	}
+}
+
+func TestSlidesThumbnailCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	dir := t.TempDir()
+	for _, cmd := range []*SlidesThumbnailCmd{
+		{Account: "a@example.com", PresentationID: "p", OutputDir: dir, Size: "LARGE"},
+		{Account: "a@example.com", PresentationID: "p", OutputDir: dir, Size: "LARGE", All: true, PageIDs: []string{"s1"}},
+		{Account: "a@example.com", PresentationID: "p", OutputDir: dir, Size: "HUGE", All: true},
+	} {
+		code, _ := runForCode(t, func() error {
+			return cmd.Run(context.Background(), &RootFlags{DryRun: true})
+		})
+		if code != "invalid_arguments" {
+			t.Errorf("%+v: code = %q, want invalid_arguments", cmd, code)
+		}
+	}
+}
+
+func TestSlidesThumbnailCmd_OutputDirContainment(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	allowed := t.TempDir()
+	cmd := &SlidesThumbnailCmd{Account: "a@example.com", PresentationID: "p", OutputDir: t.TempDir(), Size: "LARGE", All: true}
+	code, exit := runForCode(t, func() error {
+		return cmd.Run(context.Background(), &RootFlags{DryRun: true, AllowedOutputDir: allowed})
+	})
+	if code != "output_not_allowed" || exit != output.ExitCodePermission {
+		t.Errorf("got %s (exit %d), want output_not_allowed", code, exit)
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.Error(w, "expired", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("\x89PNG"))
+	}))
+	defer srv.Close()
+
+	resp, err := downloadURL(context.Background(), srv.Client(), srv.URL+"/ok", "thumbnail")
+	if err != nil {
+		t.Fatalf("downloadURL: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "\x89PNG" {
+		t.Errorf("body = %q", body)
+	}
+
+	_, err = downloadURL(context.Background(), srv.Client(), srv.URL+"/gone", "thumbnail")
+	var apiErr *gapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+		t.Errorf("expired URL error = %v, want 403", err)
+	}
+}
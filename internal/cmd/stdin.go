@@ -25,3 +25,27 @@ func readStdinWithLimit(limit int64) (string, error) {
 
 	return string(b), nil
 }
+
+// readInputFile reads a file given by a flag, or stdin when path is "-",
+// with the same size limit as stdin.
+func readInputFile(path string) (string, error) {
+	if path == "-" {
+		return readStdinWithLimit(maxStdinBytes)
+	}
+
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, maxStdinBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+	if int64(len(b)) > maxStdinBytes {
+		return "", fmt.Errorf("%s exceeds %d bytes", path, maxStdinBytes)
+	}
+
+	return string(b), nil
+}
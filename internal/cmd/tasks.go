@@ -0,0 +1,550 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/api/tasks/v1"
+
+	"github.com/kubot64/gog-lite/internal/googleapi"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+// TasksCmd groups Google Tasks subcommands.
+type TasksCmd struct {
+	Lists    TasksListsCmd    `cmd:"" help:"List task lists."`
+	List     TasksListCmd     `cmd:"" help:"List tasks in a task list."`
+	Get      TasksGetCmd      `cmd:"" help:"Get a task by ID."`
+	Create   TasksCreateCmd   `cmd:"" help:"Create a task."`
+	Update   TasksUpdateCmd   `cmd:"" help:"Update a task."`
+	Complete TasksCompleteCmd `cmd:"" help:"Mark a task as completed."`
+	Delete   TasksDeleteCmd   `cmd:"" help:"Delete a task."`
+}
+
+// Task statuses in the Tasks API.
+const (
+	taskStatusNeedsAction = "needsAction"
+	taskStatusCompleted   = "completed"
+)
+
+// taskInfo is a task in command output.
+type taskInfo struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Notes       string `json:"notes,omitempty"`
+	Status      string `json:"status"`
+	Due         string `json:"due,omitempty"`
+	Completed   string `json:"completed,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	Position    string `json:"position,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+	WebViewLink string `json:"web_view_link,omitempty"`
+}
+
+func toTaskInfo(t *tasks.Task) taskInfo {
+	info := taskInfo{
+		ID:          t.Id,
+		Title:       t.Title,
+		Notes:       t.Notes,
+		Status:      t.Status,
+		Parent:      t.Parent,
+		Position:    t.Position,
+		Updated:     t.Updated,
+		WebViewLink: t.WebViewLink,
+	}
+	// The API stores only the date of a due time.
+	if len(t.Due) >= len(time.DateOnly) {
+		info.Due = t.Due[:len(time.DateOnly)]
+	}
+	if t.Completed != nil {
+		info.Completed = *t.Completed
+	}
+
+	return info
+}
+
+// parseTaskDue accepts a date (YYYY-MM-DD) or an RFC3339 time and returns
+// the due timestamp the API expects. Only the date is kept.
+func parseTaskDue(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	if d, err := time.Parse(time.DateOnly, s); err == nil {
+		return d.Format(time.DateOnly) + "T00:00:00.000Z", nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", fmt.Errorf("--due must be YYYY-MM-DD or RFC3339, got %q", s)
+	}
+
+	return t.Format(time.DateOnly) + "T00:00:00.000Z", nil
+}
+
+func parseTaskStatus(s string) (string, error) {
+	switch s {
+	case "", taskStatusNeedsAction, taskStatusCompleted:
+		return s, nil
+	}
+
+	return "", fmt.Errorf("--status must be %s or %s, got %q", taskStatusNeedsAction, taskStatusCompleted, s)
+}
+
+// TasksListsCmd lists task lists.
+type TasksListsCmd struct {
+	Account  string `name:"account" required:"" short:"a" help:"Google account email."`
+	Max      int64  `name:"max" default:"100" help:"Maximum results per page (up to 100)."`
+	AllPages bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page     string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *TasksListsCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.lists"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	svc, err := googleapi.NewTasksReadOnly(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	type taskListInfo struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		Updated string `json:"updated,omitempty"`
+	}
+
+	lists, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []taskListInfo, error) {
+		req := svc.Tasklists.List().MaxResults(c.Max)
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		} else if c.Page != "" {
+			req = req.PageToken(c.Page)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("tasks lists: %w", err)
+		}
+
+		infos := make([]taskListInfo, 0, len(resp.Items))
+		for _, l := range resp.Items {
+			infos = append(infos, taskListInfo{ID: l.Id, Title: l.Title, Updated: l.Updated})
+		}
+
+		return resp.NextPageToken, infos, nil
+	})
+	if err != nil {
+		return writeGoogleAPIError("tasks_lists_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"lists":         lists,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// TasksListCmd lists tasks.
+type TasksListCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID        string `name:"list-id" default:"@default" help:"Task list ID."`
+	ShowCompleted bool   `name:"show-completed" default:"true" negatable:"" help:"Include completed tasks (--no-show-completed for open tasks only)."`
+	ShowHidden    bool   `name:"show-hidden" help:"Include completed tasks hidden by clearing the list."`
+	DueMin        string `name:"due-min" help:"Only tasks due on or after this date (YYYY-MM-DD or RFC3339)."`
+	DueMax        string `name:"due-max" help:"Only tasks due before this date (YYYY-MM-DD or RFC3339)."`
+	Max           int64  `name:"max" default:"20" help:"Maximum results per page (up to 100)."`
+	AllPages      bool   `name:"all-pages" help:"Fetch all pages of results."`
+	Page          string `name:"page" help:"Page token for pagination."`
+}
+
+func (c *TasksListCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.list"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if err := enforceRateLimit("tasks.list", 120, time.Minute); err != nil {
+		return output.WriteError(output.ExitCodeError, "rate_limited", err.Error())
+	}
+
+	dueMin, err := parseTaskDue(c.DueMin)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+	dueMax, err := parseTaskDue(c.DueMax)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	svc, err := googleapi.NewTasksReadOnly(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	items, nextPageToken, err := collectAllPages(c.AllPages, func(pageToken string) (string, []taskInfo, error) {
+		req := svc.Tasks.List(c.ListID).
+			MaxResults(c.Max).
+			ShowCompleted(c.ShowCompleted).
+			ShowHidden(c.ShowHidden)
+
+		if dueMin != "" {
+			req = req.DueMin(dueMin)
+		}
+
+		if dueMax != "" {
+			req = req.DueMax(dueMax)
+		}
+
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		} else if c.Page != "" {
+			req = req.PageToken(c.Page)
+		}
+
+		resp, err := req.Do()
+		if err != nil {
+			return "", nil, fmt.Errorf("tasks list: %w", err)
+		}
+
+		infos := make([]taskInfo, 0, len(resp.Items))
+		for _, t := range resp.Items {
+			infos = append(infos, toTaskInfo(t))
+		}
+
+		return resp.NextPageToken, infos, nil
+	})
+	if err != nil {
+		return writeGoogleAPIError("tasks_list_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"list_id":       c.ListID,
+		"tasks":         items,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+// TasksGetCmd gets a task.
+type TasksGetCmd struct {
+	Account string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID  string `name:"list-id" default:"@default" help:"Task list ID."`
+	TaskID  string `name:"task-id" required:"" help:"Task ID."`
+}
+
+func (c *TasksGetCmd) Run(ctx context.Context, _ *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.get"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	svc, err := googleapi.NewTasksReadOnly(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	task, err := svc.Tasks.Get(c.ListID, c.TaskID).Do()
+	if err != nil {
+		return writeGoogleAPIError("tasks_get_error", err)
+	}
+
+	return output.WriteJSON(os.Stdout, toTaskInfo(task))
+}
+
+// TasksCreateCmd creates a task.
+type TasksCreateCmd struct {
+	Account  string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID   string `name:"list-id" default:"@default" help:"Task list ID."`
+	Title    string `name:"title" required:"" help:"Task title."`
+	Notes    string `name:"notes" help:"Task notes."`
+	Due      string `name:"due" help:"Due date (YYYY-MM-DD or RFC3339; only the date is kept)."`
+	Parent   string `name:"parent" help:"Parent task ID, to create a subtask."`
+	Previous string `name:"previous" help:"Sibling task ID to place the new task after (default: first)."`
+}
+
+func (c *TasksCreateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.create"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	due, err := parseTaskDue(c.Due)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "tasks.create",
+			Account: normalizeEmail(c.Account),
+			Target:  c.ListID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "tasks.create",
+			"params": map[string]any{
+				"account":  c.Account,
+				"list_id":  c.ListID,
+				"title":    c.Title,
+				"notes":    c.Notes,
+				"due":      due,
+				"parent":   c.Parent,
+				"previous": c.Previous,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewTasksWrite(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	req := svc.Tasks.Insert(c.ListID, &tasks.Task{Title: c.Title, Notes: c.Notes, Due: due})
+	if c.Parent != "" {
+		req = req.Parent(c.Parent)
+	}
+	if c.Previous != "" {
+		req = req.Previous(c.Previous)
+	}
+	created, err := req.Do()
+	if err != nil {
+		return writeGoogleAPIError("tasks_create_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "tasks.create",
+		Account: normalizeEmail(c.Account),
+		Target:  created.Id,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, toTaskInfo(created))
+}
+
+// TasksUpdateCmd updates fields of a task.
+type TasksUpdateCmd struct {
+	Account  string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID   string `name:"list-id" default:"@default" help:"Task list ID."`
+	TaskID   string `name:"task-id" required:"" help:"Task ID."`
+	Title    string `name:"title" help:"New title."`
+	Notes    string `name:"notes" help:"New notes."`
+	Due      string `name:"due" help:"New due date (YYYY-MM-DD or RFC3339)."`
+	ClearDue bool   `name:"clear-due" help:"Remove the due date."`
+	Status   string `name:"status" help:"New status: needsAction or completed."`
+}
+
+func (c *TasksUpdateCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.update"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	due, err := parseTaskDue(c.Due)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_time", err.Error())
+	}
+	status, err := parseTaskStatus(c.Status)
+	if err != nil {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", err.Error())
+	}
+	if due != "" && c.ClearDue {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments", "--due and --clear-due cannot be combined")
+	}
+	if c.Title == "" && c.Notes == "" && due == "" && !c.ClearDue && status == "" {
+		return output.WriteError(output.ExitCodeError, "invalid_arguments",
+			"nothing to update; set --title, --notes, --due, --clear-due or --status")
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "tasks.update",
+			Account: normalizeEmail(c.Account),
+			Target:  c.TaskID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "tasks.update",
+			"params": map[string]any{
+				"account":   c.Account,
+				"list_id":   c.ListID,
+				"task_id":   c.TaskID,
+				"title":     c.Title,
+				"notes":     c.Notes,
+				"due":       due,
+				"clear_due": c.ClearDue,
+				"status":    status,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewTasksWrite(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	patch := &tasks.Task{Title: c.Title, Notes: c.Notes, Due: due, Status: status}
+	if c.ClearDue {
+		patch.NullFields = append(patch.NullFields, "Due")
+	}
+	if status == taskStatusNeedsAction {
+		patch.NullFields = append(patch.NullFields, "Completed")
+	}
+	updated, err := svc.Tasks.Patch(c.ListID, c.TaskID, patch).Do()
+	if err != nil {
+		return writeGoogleAPIError("tasks_update_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "tasks.update",
+		Account: normalizeEmail(c.Account),
+		Target:  c.TaskID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, toTaskInfo(updated))
+}
+
+// TasksCompleteCmd marks a task as completed.
+type TasksCompleteCmd struct {
+	Account string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID  string `name:"list-id" default:"@default" help:"Task list ID."`
+	TaskID  string `name:"task-id" required:"" help:"Task ID."`
+}
+
+func (c *TasksCompleteCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.complete"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	if root.DryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "tasks.complete",
+			Account: normalizeEmail(c.Account),
+			Target:  c.TaskID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "tasks.complete",
+			"params": map[string]any{
+				"account": c.Account,
+				"list_id": c.ListID,
+				"task_id": c.TaskID,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewTasksWrite(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	updated, err := svc.Tasks.Patch(c.ListID, c.TaskID, &tasks.Task{Status: taskStatusCompleted}).Do()
+	if err != nil {
+		return writeGoogleAPIError("tasks_complete_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "tasks.complete",
+		Account: normalizeEmail(c.Account),
+		Target:  c.TaskID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, toTaskInfo(updated))
+}
+
+// TasksDeleteCmd deletes a task.
+type TasksDeleteCmd struct {
+	Account       string `name:"account" required:"" short:"a" help:"Google account email."`
+	ListID        string `name:"list-id" default:"@default" help:"Task list ID."`
+	TaskID        string `name:"task-id" required:"" help:"Task ID."`
+	ConfirmDelete bool   `name:"confirm-delete" help:"Required confirmation flag for delete operations."`
+	ApprovalToken string `name:"approval-token" help:"One-time approval token for dangerous actions."`
+}
+
+func (c *TasksDeleteCmd) Run(ctx context.Context, root *RootFlags) error {
+	if err := enforceActionPolicy(c.Account, "tasks.delete"); err != nil {
+		return output.WriteError(output.ExitCodePermission, "policy_denied", err.Error())
+	}
+
+	dryRun := root.DryRun
+	if !dryRun && !c.ConfirmDelete {
+		return output.WriteError(output.ExitCodeError, "delete_requires_confirmation",
+			"tasks delete requires --confirm-delete")
+	}
+	if !dryRun {
+		required, err := actionRequiresApproval("tasks.delete")
+		if err != nil {
+			return output.WriteError(output.ExitCodeError, "policy_error", err.Error())
+		}
+		if required {
+			if err := consumeApprovalToken(c.Account, "tasks.delete", c.ApprovalToken); err != nil {
+				return output.WriteError(output.ExitCodePermission, "approval_required", err.Error())
+			}
+		}
+	}
+
+	if dryRun {
+		if err := appendAuditLog(root.AuditLog, auditEntry{
+			Action:  "tasks.delete",
+			Account: normalizeEmail(c.Account),
+			Target:  c.TaskID,
+			DryRun:  true,
+		}); err != nil {
+			return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+		}
+		return output.WriteJSON(os.Stdout, map[string]any{
+			"dry_run": true,
+			"action":  "tasks.delete",
+			"params": map[string]any{
+				"account": c.Account,
+				"list_id": c.ListID,
+				"task_id": c.TaskID,
+			},
+		})
+	}
+
+	svc, err := googleapi.NewTasksWrite(ctx, c.Account)
+	if err != nil {
+		return tasksAuthError(err)
+	}
+
+	if err := svc.Tasks.Delete(c.ListID, c.TaskID).Do(); err != nil {
+		return writeGoogleAPIError("tasks_delete_error", err)
+	}
+
+	if err := appendAuditLog(root.AuditLog, auditEntry{
+		Action:  "tasks.delete",
+		Account: normalizeEmail(c.Account),
+		Target:  c.TaskID,
+		DryRun:  false,
+	}); err != nil {
+		return output.WriteError(output.ExitCodeError, "audit_error", err.Error())
+	}
+
+	return output.WriteJSON(os.Stdout, map[string]any{
+		"deleted": true,
+		"list_id": c.ListID,
+		"task_id": c.TaskID,
+	})
+}
+
+func tasksAuthError(err error) error {
+	var authErr *googleapi.AuthRequiredError
+	if isAuthErr(err, &authErr) {
+		return output.WriteError(output.ExitCodeAuth, "auth_required", err.Error())
+	}
+
+	return output.WriteError(output.ExitCodeError, "tasks_error", err.Error())
+}
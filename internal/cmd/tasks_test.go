@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/tasks/v1"
+
+	"github.com/kubot64/gog-lite/internal/config"
+	"github.com/kubot64/gog-lite/internal/output"
+)
+
+func TestParseTaskDue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"2026-10-20", "2026-10-20T00:00:00.000Z"},
+		{"2026-10-20T23:30:00+09:00", "2026-10-20T00:00:00.000Z"},
+	}
+	for _, tt := range tests {
+		got, err := parseTaskDue(tt.input)
+		if err != nil {
+			t.Errorf("parseTaskDue(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTaskDue(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, s := range []string{"tomorrow", "2026-13-01", "20/10/2026"} {
+		if _, err := parseTaskDue(s); err == nil {
+			t.Errorf("parseTaskDue(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestToTaskInfo(t *testing.T) {
+	completed := "2026-10-18T09:00:00.000Z"
+	info := toTaskInfo(&tasks.Task{
+		Id:        "t1",
+		Title:     "Write report",
+		Status:    taskStatusCompleted,
+		Due:       "2026-10-20T00:00:00.000Z",
+		Completed: &completed,
+	})
+	if info.Due != "2026-10-20" || info.Completed != completed || info.Status != taskStatusCompleted {
+		t.Errorf("toTaskInfo = %+v", info)
+	}
+}
+
+func TestTasksUpdateCmd_Validation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	tests := []struct {
+		cmd  *TasksUpdateCmd
+		code string
+	}{
+		{&TasksUpdateCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1"}, "invalid_arguments"},
+		{&TasksUpdateCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1", Status: "done"}, "invalid_arguments"},
+		{&TasksUpdateCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1", Due: "2026-10-20", ClearDue: true}, "invalid_arguments"},
+		{&TasksUpdateCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1", Due: "soon"}, "invalid_time"},
+	}
+	for _, tt := range tests {
+		code, _ := runForCode(t, func() error {
+			return tt.cmd.Run(context.Background(), &RootFlags{DryRun: true})
+		})
+		if code != tt.code {
+			t.Errorf("%+v: code = %q, want %q", tt.cmd, code, tt.code)
+		}
+	}
+}
+
+func TestTasksCreateCmd_DryRun(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	cmd := &TasksCreateCmd{Account: "a@example.com", ListID: "@default", Title: "Follow up", Due: "2026-10-20"}
+	var err error
+	stdout := captureStdout(t, func() {
+		err = cmd.Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload struct {
+		DryRun bool   `json:"dry_run"`
+		Action string `json:"action"`
+		Params struct {
+			Due string `json:"due"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &payload); err != nil {
+		t.Fatalf("parse stdout JSON: %v (got %q)", err, stdout)
+	}
+	if !payload.DryRun || payload.Action != "tasks.create" || payload.Params.Due != "2026-10-20T00:00:00.000Z" {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	entries := readAuditEntries(t)
+	if len(entries) != 1 || entries[0].Action != "tasks.create" || !entries[0].DryRun {
+		t.Errorf("audit entries = %+v", entries)
+	}
+}
+
+func TestTasksCreateCmd_PolicyDenied(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	if err := config.WritePolicy(config.PolicyFile{AllowedActions: []string{"tasks.list"}}); err != nil {
+		t.Fatalf("WritePolicy: %v", err)
+	}
+
+	code, exit := runForCode(t, func() error {
+		return (&TasksCreateCmd{Account: "a@example.com", ListID: "@default", Title: "x"}).Run(context.Background(), &RootFlags{DryRun: true})
+	})
+	if code != "policy_denied" || exit != output.ExitCodePermission {
+		t.Errorf("got %s (exit %d), want policy_denied", code, exit)
+	}
+}
+
+func TestTasksDeleteCmd_RequiresConfirmation(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, _ := runForCode(t, func() error {
+		return (&TasksDeleteCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1"}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "delete_requires_confirmation" {
+		t.Errorf("code = %q, want delete_requires_confirmation", code)
+	}
+}
+
+func TestTasksDeleteCmd_RequiresApproval(t *testing.T) {
+	cfgHome := t.TempDir()
+	t.Setenv("HOME", cfgHome)
+	t.Setenv("XDG_CONFIG_HOME", cfgHome)
+
+	code, exit := runForCode(t, func() error {
+		return (&TasksDeleteCmd{Account: "a@example.com", ListID: "@default", TaskID: "t1", ConfirmDelete: true}).Run(context.Background(), &RootFlags{})
+	})
+	if code != "approval_required" || exit != output.ExitCodePermission {
+		t.Errorf("got %s (exit %d), want approval_required", code, exit)
+	}
+}
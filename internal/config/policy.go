@@ -14,6 +14,10 @@ type PolicyFile struct {
 	AllowedActions         []string `json:"allowed_actions,omitempty"`
 	BlockedAccounts        []string `json:"blocked_accounts,omitempty"`
 	RequireApprovalActions []string `json:"require_approval_actions,omitempty"`
+	AllowedInviteDomains   []string `json:"allowed_invite_domains,omitempty"`
+	// SheetsForceRawInput stores Sheets writes as literal values, so written
+	// text cannot become formulas such as =IMPORTXML(...).
+	SheetsForceRawInput bool `json:"sheets_force_raw_input,omitempty"`
 }
 
 func PolicyPath() (string, error) {
@@ -80,6 +84,7 @@ func (p *PolicyFile) normalize() {
 	p.AllowedActions = normalizeUnique(p.AllowedActions)
 	p.BlockedAccounts = normalizeUnique(p.BlockedAccounts)
 	p.RequireApprovalActions = normalizeUnique(p.RequireApprovalActions)
+	p.AllowedInviteDomains = normalizeUnique(p.AllowedInviteDomains)
 }
 
 func normalizeUnique(in []string) []string {
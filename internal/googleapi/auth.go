@@ -52,6 +52,21 @@ func optionsForEmailWithScopes(
 	email string,
 	scopes []string,
 ) ([]option.ClientOption, error) {
+	c, err := httpClientForEmailWithScopes(ctx, serviceName, email, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+}
+
+// httpClientForEmailWithScopes returns an authenticated client with retries.
+func httpClientForEmailWithScopes(
+	ctx context.Context,
+	serviceName string,
+	email string,
+	scopes []string,
+) (*http.Client, error) {
 	scopes = normalizeScopes(scopes)
 	if len(scopes) == 0 {
 		return nil, fmt.Errorf("no scopes configured for %s", serviceName)
@@ -92,12 +107,19 @@ func optionsForEmailWithScopes(
 		Base:   baseTransport,
 	})
 
-	c := &http.Client{
+	return &http.Client{
 		Transport: retryTransport,
 		Timeout:   defaultHTTPTimeout,
-	}
+	}, nil
+}
 
-	return []option.ClientOption{option.WithHTTPClient(c)}, nil
+// NewUnauthenticatedHTTPClient returns a client with the same retries and
+// timeout as the API clients, for signed URLs that need no credentials.
+func NewUnauthenticatedHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: NewRetryTransport(newBaseTransport()),
+		Timeout:   defaultHTTPTimeout,
+	}
 }
 
 func normalizeScopes(scopes []string) []string {
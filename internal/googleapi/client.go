@@ -3,13 +3,16 @@ package googleapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/people/v1"
 	"google.golang.org/api/sheets/v4"
 	"google.golang.org/api/slides/v1"
+	"google.golang.org/api/tasks/v1"
 
 	"github.com/kubot64/gog-lite/internal/googleauth"
 )
@@ -22,10 +25,14 @@ const (
 	scopeDocsReadonly     = "https://www.googleapis.com/auth/documents.readonly"
 	scopeDocsWrite        = "https://www.googleapis.com/auth/documents"
 	scopeDriveReadonly    = "https://www.googleapis.com/auth/drive.readonly"
+	scopeDriveWrite       = "https://www.googleapis.com/auth/drive"
 	scopeSheetsReadonly   = "https://www.googleapis.com/auth/spreadsheets.readonly"
 	scopeSheetsWrite      = "https://www.googleapis.com/auth/spreadsheets"
 	scopeSlidesReadonly   = "https://www.googleapis.com/auth/presentations.readonly"
 	scopeSlidesWrite      = "https://www.googleapis.com/auth/presentations"
+	scopeTasksReadonly    = "https://www.googleapis.com/auth/tasks.readonly"
+	scopeTasksWrite       = "https://www.googleapis.com/auth/tasks"
+	scopeContactsReadonly = "https://www.googleapis.com/auth/contacts.readonly"
 )
 
 // Legacy constructors — delegate to read-only variants for least privilege.
@@ -98,6 +105,24 @@ func NewDriveReadOnly(ctx context.Context, email string) (*drive.Service, error)
 	return drive.NewService(ctx, opts...)
 }
 
+// NewDriveReadOnlyHTTPClient returns an authenticated client for Drive URLs
+// returned by the API itself, such as revision export links.
+func NewDriveReadOnlyHTTPClient(ctx context.Context, email string) (*http.Client, error) {
+	c, err := httpClientForEmailWithScopes(ctx, string(googleauth.ServiceDocs), email, []string{scopeDriveReadonly})
+	if err != nil {
+		return nil, fmt.Errorf("drive options: %w", err)
+	}
+	return c, nil
+}
+
+func NewDriveWrite(ctx context.Context, email string) (*drive.Service, error) {
+	opts, err := optionsForEmailWithScopes(ctx, string(googleauth.ServiceDocs), email, []string{scopeDriveWrite})
+	if err != nil {
+		return nil, fmt.Errorf("drive options: %w", err)
+	}
+	return drive.NewService(ctx, opts...)
+}
+
 func NewSheetsReadOnly(ctx context.Context, email string) (*sheets.Service, error) {
 	opts, err := optionsForEmailWithScopes(ctx, string(googleauth.ServiceSheets), email, []string{scopeSheetsReadonly})
 	if err != nil {
@@ -129,3 +154,29 @@ func NewSlidesWrite(ctx context.Context, email string) (*slides.Service, error)
 	}
 	return slides.NewService(ctx, opts...)
 }
+
+func NewTasksReadOnly(ctx context.Context, email string) (*tasks.Service, error) {
+	opts, err := optionsForEmailWithScopes(ctx, string(googleauth.ServiceTasks), email, []string{scopeTasksReadonly})
+	if err != nil {
+		return nil, fmt.Errorf("tasks options: %w", err)
+	}
+	return tasks.NewService(ctx, opts...)
+}
+
+func NewTasksWrite(ctx context.Context, email string) (*tasks.Service, error) {
+	opts, err := optionsForEmailWithScopes(ctx, string(googleauth.ServiceTasks), email, []string{scopeTasksWrite})
+	if err != nil {
+		return nil, fmt.Errorf("tasks options: %w", err)
+	}
+	return tasks.NewService(ctx, opts...)
+}
+
+// NewContactsReadOnly returns a People API client limited to reading the
+// account's contacts.
+func NewContactsReadOnly(ctx context.Context, email string) (*people.Service, error) {
+	opts, err := optionsForEmailWithScopes(ctx, string(googleauth.ServiceContacts), email, []string{scopeContactsReadonly})
+	if err != nil {
+		return nil, fmt.Errorf("contacts options: %w", err)
+	}
+	return people.NewService(ctx, opts...)
+}
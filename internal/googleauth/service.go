@@ -17,6 +17,8 @@ const (
 	ServiceDrive    Service = "drive"
 	ServiceSheets   Service = "sheets"
 	ServiceSlides   Service = "slides"
+	ServiceTasks    Service = "tasks"
+	ServiceContacts Service = "contacts"
 )
 
 const (
@@ -49,11 +51,17 @@ var serviceScopes = map[Service][]string{
 	ServiceSlides: {
 		"https://www.googleapis.com/auth/presentations",
 	},
+	ServiceTasks: {
+		"https://www.googleapis.com/auth/tasks",
+	},
+	ServiceContacts: {
+		"https://www.googleapis.com/auth/contacts.readonly",
+	},
 }
 
 // AllServices returns all supported services.
 func AllServices() []Service {
-	return []Service{ServiceGmail, ServiceCalendar, ServiceDocs, ServiceDrive, ServiceSheets, ServiceSlides}
+	return []Service{ServiceGmail, ServiceCalendar, ServiceDocs, ServiceDrive, ServiceSheets, ServiceSlides, ServiceTasks, ServiceContacts}
 }
 
 // ParseService parses a service name string.
@@ -63,7 +71,7 @@ func ParseService(s string) (Service, error) {
 		return svc, nil
 	}
 
-	return "", fmt.Errorf("%w %q (expected gmail, calendar, docs, drive, sheets, or slides)", errUnknownService, s)
+	return "", fmt.Errorf("%w %q (expected gmail, calendar, docs, drive, sheets, slides, tasks, or contacts)", errUnknownService, s)
 }
 
 // Scopes returns the OAuth2 scopes required for the given service.
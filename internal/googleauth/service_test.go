@@ -20,6 +20,8 @@ func TestParseService_Valid(t *testing.T) {
 		{"SHEETS", googleauth.ServiceSheets},
 		{"slides", googleauth.ServiceSlides},
 		{"SLIDES", googleauth.ServiceSlides},
+		{"tasks", googleauth.ServiceTasks},
+		{"Contacts", googleauth.ServiceContacts},
 	}
 	for _, tt := range tests {
 		got, err := googleauth.ParseService(tt.input)
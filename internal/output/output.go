@@ -14,6 +14,7 @@ const (
 	ExitCodeAuth       = 2
 	ExitCodeNotFound   = 3
 	ExitCodePermission = 4
+	ExitCodeConflict   = 5
 )
 
 // ExitCodeError wraps an error with a specific exit code.
@@ -60,14 +61,21 @@ func WriteJSON(w io.Writer, val any) error {
 
 // errorPayload is the JSON structure written to stderr on errors.
 type errorPayload struct {
-	Error string `json:"error"`
-	Code  string `json:"code"`
+	Error   string         `json:"error"`
+	Code    string         `json:"code"`
+	Details map[string]any `json:"details,omitempty"`
 }
 
 // WriteError writes a JSON error to stderr and returns an ExitCodeErr.
 // The returned error should be returned from Run() to trigger os.Exit.
 func WriteError(code int, codeStr, msg string) error {
-	payload := errorPayload{Error: msg, Code: codeStr}
+	return WriteErrorDetails(code, codeStr, msg, nil)
+}
+
+// WriteErrorDetails is WriteError with machine-readable details, such as how
+// far a multi-step write got before it failed.
+func WriteErrorDetails(code int, codeStr, msg string, details map[string]any) error {
+	payload := errorPayload{Error: msg, Code: codeStr, Details: details}
 	enc := json.NewEncoder(os.Stderr)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")